@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"log/slog"
 	"os"
 
@@ -8,15 +9,16 @@ import (
 )
 
 func main() {
-	// Initialize structured logger
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
-	slog.SetDefault(logger)
+	// Initialize structured logger (format from the environment; --log-format overrides it)
+	if err := cli.ConfigureLogging(os.Getenv(cli.LogFormatEnv)); err != nil {
+		_ = cli.ConfigureLogging("text")
+		slog.Warn("Ignoring log format from environment", "variable", cli.LogFormatEnv, "error", err)
+	}
 
 	// Execute root command
-	if err := cli.Execute(); err != nil {
+	err := cli.Execute()
+	if err != nil && !errors.Is(err, cli.ErrViolationsFound) {
 		slog.Error("Fatal error", "error", err)
-		os.Exit(1)
 	}
+	os.Exit(cli.ExitCode(err))
 }
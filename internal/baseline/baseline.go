@@ -0,0 +1,79 @@
+package baseline
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// Counts maps bucket names to their violation counts
+type Counts map[string]int
+
+// Increase is a bucket whose violation count rose above its baseline
+type Increase struct {
+	Bucket   string
+	Baseline int
+	Current  int
+}
+
+// FromBuckets counts the violations in each bucket, excluding the Snoozed bucket
+func FromBuckets(bucketGroup *domain.BucketGroup) Counts {
+	counts := make(Counts)
+	for _, bucket := range bucketGroup.Buckets {
+		if bucket.Name != domain.SnoozedBucketName {
+			counts[bucket.Name] += len(bucket.Bugs)
+		}
+	}
+	return counts
+}
+
+// Load reads a baseline file written by Save
+func Load(path string) (Counts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var counts Counts
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %w", path, err)
+	}
+	for name, count := range counts {
+		if count < 0 {
+			return nil, fmt.Errorf("invalid baseline count for %q in %s: must be non-negative", name, path)
+		}
+	}
+	return counts, nil
+}
+
+// Save writes the counts to a baseline file
+func (c Counts) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline file: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
+	return nil
+}
+
+// Increases returns the buckets whose current count exceeds the baseline, sorted by bucket name
+// (buckets missing from the baseline count as zero)
+func (c Counts) Increases(current Counts) []Increase {
+	var increases []Increase
+	for name, count := range current {
+		if count > c[name] {
+			increases = append(increases, Increase{Bucket: name, Baseline: c[name], Current: count})
+		}
+	}
+	slices.SortFunc(increases, func(a, b Increase) int {
+		return cmp.Compare(a.Bucket, b.Bucket)
+	})
+	return increases
+}
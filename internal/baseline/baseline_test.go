@@ -0,0 +1,76 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestIncreases(t *testing.T) {
+	base := Counts{"🔴 URGENT": 5, "🟡 ATTENTION": 3, "🔵 REVIEW": 2}
+
+	tests := []struct {
+		name    string
+		current Counts
+		want    []Increase
+	}{
+		{"unchanged", Counts{"🔴 URGENT": 5, "🟡 ATTENTION": 3, "🔵 REVIEW": 2}, nil},
+		{"fewer violations", Counts{"🔴 URGENT": 1}, nil},
+		{
+			"one bucket up, another down",
+			Counts{"🔴 URGENT": 6, "🟡 ATTENTION": 1},
+			[]Increase{{Bucket: "🔴 URGENT", Baseline: 5, Current: 6}},
+		},
+		{
+			"new bucket counts from zero",
+			Counts{"🔴 URGENT": 5, "🟣 NEEDS DATA": 1, "🔵 REVIEW": 4},
+			[]Increase{
+				{Bucket: "🔵 REVIEW", Baseline: 2, Current: 4},
+				{Bucket: "🟣 NEEDS DATA", Baseline: 0, Current: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := base.Increases(tt.current); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Increases = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFromBucketsSkipsSnoozed(t *testing.T) {
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{
+		{Name: "🔴 URGENT", Bugs: []*domain.Bug{{Key: "DEMO-1"}, {Key: "DEMO-2"}}},
+		{Name: domain.SnoozedBucketName, Bugs: []*domain.Bug{{Key: "DEMO-3"}}},
+	}}
+
+	if got, want := FromBuckets(bucketGroup), (Counts{"🔴 URGENT": 2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromBuckets = %v, want %v", got, want)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	counts := Counts{"🔴 URGENT": 2, "🔵 REVIEW": 0}
+
+	if err := counts.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, counts) {
+		t.Errorf("Load = %v, want %v", loaded, counts)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"🔴 URGENT": -1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "non-negative") {
+		t.Errorf("Load with a negative count = %v, want an error", err)
+	}
+}
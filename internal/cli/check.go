@@ -1,24 +1,57 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/neilmpatterson/bug-butler/internal/baseline"
 	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
 	"github.com/neilmpatterson/bug-butler/internal/jira"
+	"github.com/neilmpatterson/bug-butler/internal/notify"
 	"github.com/neilmpatterson/bug-butler/internal/output"
+	"github.com/neilmpatterson/bug-butler/internal/schedule"
 	"github.com/neilmpatterson/bug-butler/internal/sla"
+	"github.com/neilmpatterson/bug-butler/internal/snooze"
+	"github.com/neilmpatterson/bug-butler/internal/stats"
 )
 
 var (
-	configPath     string
-	debugMode      bool
-	priorityFilter string
-	statusFilter   string
+	configPath         string
+	debugMode          bool
+	priorityFilter     string
+	statusFilter       string
+	fixVersionFilter   string
+	dumpBugsPath       string
+	plainOutput        bool
+	detectDuplicates   bool
+	violationsExitCode int // Exit code used for ErrViolationsFound
+	skipProjectCheck   bool
+	githubOutputPath   string
+	metricsFilePath    string
+	dedupeSummaries    bool
+	deployTimeFlag     string
+	columnsFlag        string
+	whatIfMode         bool
+	allowPartial       bool
+	allFields          bool
+	bucketFilter       string
+	summaryLine        bool
+	customJQL          string
+	baselinePath       string
+	updateBaseline     bool
+	bucketOrder        string
+	checkSchedule      string
 )
 
 var checkCmd = &cobra.Command{
@@ -37,17 +70,155 @@ func init() {
 	checkCmd.Flags().BoolVar(&debugMode, "debug", false, "Enable debug logging")
 	checkCmd.Flags().StringVar(&priorityFilter, "priority", "", "Filter by priority (comma-separated, e.g., 'Critical,High')")
 	checkCmd.Flags().StringVar(&statusFilter, "status", "", "Filter by status (comma-separated, e.g., 'Needs Triage,Backlog')")
+	checkCmd.Flags().StringVar(&fixVersionFilter, "fix-version", "", "Filter by fix version (comma-separated, e.g., '2.4.0,2.5.0')")
+	checkCmd.Flags().StringVar(&customJQL, "jql", "", "Fetch bugs with this JQL verbatim instead of the built-in query (ignores additional_jql and filters)")
+	checkCmd.Flags().StringVar(&dumpBugsPath, "dump-bugs", "", "Write the fetched bugs as JSON to this file")
+	checkCmd.Flags().BoolVar(&allFields, "all-fields", false, allFieldsUsage)
+	checkCmd.Flags().BoolVar(&dedupeSummaries, "dedupe", false, "Collapse bugs with the same normalized summary into one row per bucket")
+	checkCmd.Flags().BoolVar(&detectDuplicates, "detect-duplicates", false, "Flag likely duplicate bugs by summary similarity")
+	checkCmd.Flags().StringVar(&deployTimeFlag, "deploy-time", "", "Count and flag bugs created after this deploy time (RFC3339, e.g., 2025-10-01T14:00:00Z)")
+	checkCmd.Flags().StringVar(&columnsFlag, "columns", "", "Bucket table columns in display order (comma-separated: key,summary,priority,status,age,created,over_by,assignee,comments,source,tags,new)")
+	checkCmd.Flags().StringVar(&bucketFilter, "bucket", "", "Only report this bucket, case-insensitive with or without its emoji (exit code still counts all buckets)")
+	checkCmd.Flags().StringVar(&bucketOrder, "bucket-order", "asc", "Bucket display order by severity: asc (most severe first) or desc (least severe first)")
+	checkCmd.Flags().BoolVar(&whatIfMode, "what-if", false, "Interactively try different rule thresholds against the fetched bugs")
+	checkCmd.Flags().BoolVarP(&interactiveMode, "interactive", "i", false, "Before exiting with violations, summarize them and offer to export the report (terminal stdin only)")
+	checkCmd.Flags().BoolVar(&plainOutput, "plain", false, "Plain-text output without emoji, colors, or banners")
+	checkCmd.Flags().StringVar(&outputFormat, "format", "", formatFlagUsage)
+	checkCmd.Flags().BoolVar(&summaryLine, "summary-line", false, "End with a machine-readable line of violation counts (BUGBUTLER_RESULT total=N <bucket>=N ...)")
+	checkCmd.Flags().StringVar(&githubOutputPath, "github-output", "", "Append violation counts as key=value lines to this file (e.g., $GITHUB_OUTPUT)")
+	checkCmd.Flags().StringVar(&metricsFilePath, "metrics-file", "", "Write bug and violation gauges in OpenMetrics text format to this file (e.g., for a Pushgateway)")
+	checkCmd.Flags().BoolVar(&allowPartial, "allow-partial", false, "Proceed with the bugs fetched so far if pagination fails partway")
+	checkCmd.Flags().BoolVar(&skipProjectCheck, "skip-project-check", false, "Skip verifying that configured projects exist before fetching")
+	checkCmd.Flags().StringVar(&baselinePath, "baseline", "", "Fail only if a bucket's violation count exceeds its count in this baseline file (JSON)")
+	checkCmd.Flags().BoolVar(&updateBaseline, "update-baseline", false, "Write the current bucket counts to the --baseline file instead of comparing")
+	checkCmd.Flags().StringVar(&checkSchedule, "schedule", "", "Run the check on this cron schedule (e.g., \"0 9 * * 1-5\", local time) until interrupted")
+	checkCmd.Flags().IntVar(&violationsExitCode, "violations-exit-code", 1, "Exit code when SLA violations are found (0 to treat as success)")
 	rootCmd.AddCommand(checkCmd)
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
+	// Flags are validated and applied once, so scheduled runs only reload config and refetch
+	deployTime, err := prepareCheck()
+	if err != nil {
+		return err
+	}
+	if checkSchedule != "" {
+		return runCheckOnSchedule(cmd, deployTime)
+	}
+	return runCheckOnce(cmd, deployTime)
+}
+
+// validateScheduleFlags rejects flags that only make sense for a single run alongside --schedule
+func validateScheduleFlags() error {
+	switch {
+	case interactiveMode || whatIfMode:
+		return fmt.Errorf("--schedule cannot be combined with --interactive or --what-if")
+	case updateBaseline:
+		return fmt.Errorf("--schedule cannot be combined with --update-baseline (every run would overwrite the baseline)")
+	case githubOutputPath != "":
+		return fmt.Errorf("--schedule cannot be combined with --github-output (every run would append to the file)")
+	}
+	return nil
+}
+
+// runCheckOnSchedule runs the check at each time the --schedule cron expression matches, logging
+// each run's result, until interrupted; failed runs and violations don't stop the schedule
+func runCheckOnSchedule(cmd *cobra.Command, deployTime time.Time) error {
+	if err := validateScheduleFlags(); err != nil {
+		return err
+	}
+
+	sched, err := schedule.Parse(checkSchedule)
+	if err != nil {
+		return fmt.Errorf("invalid --schedule: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cmd.SetContext(ctx)
+
+	for {
+		next := sched.Next(time.Now())
+		if next.IsZero() {
+			return fmt.Errorf("--schedule %q never runs", checkSchedule)
+		}
+		slog.Info("Waiting for next scheduled check", "schedule", checkSchedule, "next_run", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			slog.Info("Stopping scheduled checks")
+			return nil
+		case <-timer.C:
+		}
+
+		start := time.Now()
+		err := runCheckOnce(cmd, deployTime)
+		switch {
+		case err == nil:
+			slog.Info("Scheduled check passed", "duration", time.Since(start).Round(time.Second))
+		case errors.Is(err, ErrViolationsFound):
+			slog.Warn("Scheduled check found SLA violations", "duration", time.Since(start).Round(time.Second))
+		default:
+			slog.Error("Scheduled check failed", "error", err)
+		}
+	}
+}
+
+// prepareCheck validates the check flags and applies their logging and output settings,
+// returning the parsed --deploy-time (zero if unset)
+func prepareCheck() (time.Time, error) {
 	// Set log level based on debug flag
 	if debugMode {
-		slog.SetLogLoggerLevel(slog.LevelDebug)
+		logLevel.Set(slog.LevelDebug)
 		slog.Debug("Debug mode enabled")
 	}
 
-	fmt.Println("🔍 Loading configuration...")
+	if err := validateAllFields(); err != nil {
+		return time.Time{}, err
+	}
+
+	if customJQL != "" && (priorityFilter != "" || statusFilter != "" || fixVersionFilter != "") {
+		return time.Time{}, fmt.Errorf("--jql cannot be combined with --priority, --status, or --fix-version (add the conditions to the JQL instead)")
+	}
+
+	if bucketOrder != "asc" && bucketOrder != "desc" {
+		return time.Time{}, fmt.Errorf("--bucket-order must be asc or desc, got %q", bucketOrder)
+	}
+
+	if updateBaseline && baselinePath == "" {
+		return time.Time{}, fmt.Errorf("--update-baseline requires --baseline")
+	}
+
+	if violationsExitCode < 0 || violationsExitCode > 255 {
+		return time.Time{}, fmt.Errorf("--violations-exit-code must be between 0 and 255")
+	}
+
+	var deployTime time.Time
+	if deployTimeFlag != "" {
+		parsed, err := time.Parse(time.RFC3339, deployTimeFlag)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --deploy-time %q (expected RFC3339, e.g., 2025-10-01T14:00:00Z): %w", deployTimeFlag, err)
+		}
+		deployTime = parsed
+	}
+
+	if err := output.SetColumns(splitCommaList(columnsFlag)); err != nil {
+		return time.Time{}, fmt.Errorf("invalid --columns: %w", err)
+	}
+
+	// Configure output styling
+	if err := configureOutput(outputFormat); err != nil {
+		return time.Time{}, err
+	}
+
+	return deployTime, nil
+}
+
+// runCheckOnce fetches and evaluates bugs once, returning ErrViolationsFound if any violate an SLA
+func runCheckOnce(cmd *cobra.Command, deployTime time.Time) error {
+	output.Println("🔍 Loading configuration...")
 
 	// Load configuration
 	cfg, err := config.Load(configPath)
@@ -55,75 +226,518 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	projectNames := cfg.Jira.ProjectKeys
+	// Apply output locale and date formats
+	if err := applyOutputConfig(cfg.Output); err != nil {
+		return err
+	}
+
+	if err := validateBucketFilter(cfg); err != nil {
+		return err
+	}
+
+	connections := cfg.JiraConnections()
+	multiInstance := len(connections) > 1
+
+	var allProjects []string
+	for _, conn := range connections {
+		allProjects = append(allProjects, conn.ProjectKeys...)
+	}
+	projectNames := allProjects
 	if len(projectNames) > 3 {
-		projectNames = append(cfg.Jira.ProjectKeys[:3], fmt.Sprintf("... +%d more", len(cfg.Jira.ProjectKeys)-3))
+		projectNames = append(allProjects[:3:3], fmt.Sprintf("... +%d more", len(allProjects)-3))
 	}
-	fmt.Printf("📋 Projects: %s\n", strings.Join(projectNames, ", "))
-	fmt.Printf("📏 SLA Rules: %d configured\n", len(cfg.SLARules))
+	output.Printf("📋 Projects: %s\n", strings.Join(projectNames, ", "))
+	if multiInstance {
+		output.Printf("🌐 Jira instances: %d configured\n", len(connections))
+	}
+	output.Printf("📏 SLA Rules: %d configured\n", len(cfg.SLARules))
 
 	slog.Debug("Configuration loaded successfully",
 		"jira_url", cfg.Jira.BaseURL,
-		"project_count", len(cfg.Jira.ProjectKeys),
+		"jira_instances", len(connections),
+		"project_count", len(allProjects),
 		"sla_rules", len(cfg.SLARules),
 	)
 
-	fmt.Println("\n🔐 Authenticating with Jira...")
+	// Parse priority, status, and fix version filters
+	priorities := splitCommaList(priorityFilter)
+	statuses := splitCommaList(statusFilter)
+	fixVersions := splitCommaList(fixVersionFilter)
+
+	// Comment counts are only fetched when thrashing detection or the Comments column uses them
+	fetchComments := cfg.Check.ThrashingComments > 0 || output.ColumnSelected("comments")
+
+	// Fetch bugs from each Jira instance and merge the results
+	var bugs []*domain.Bug
+	for _, conn := range connections {
+		instanceBugs, err := fetchInstanceBugs(conn, multiInstance, cfg.Tags, len(cfg.Check.ActiveParentStatuses) > 0, fetchComments, priorities, statuses, fixVersions)
+		if err != nil {
+			return err
+		}
+		bugs = append(bugs, instanceBugs...)
+	}
+
+	// Dump raw bug data if requested
+	if err := dumpBugs(bugs); err != nil {
+		return err
+	}
+
+	if len(bugs) == 0 {
+		output.Println("\n✅ No unresolved bugs found!")
+		printSummaryLine(cfg, &domain.BucketGroup{})
+		if baselinePath != "" {
+			if _, err := checkBaseline(&domain.BucketGroup{}); err != nil {
+				return err
+			}
+		}
+		if err := writeMetricsFile(cfg, &domain.BucketGroup{}, 0); err != nil {
+			return err
+		}
+		return writeGitHubOutput(cfg, &domain.BucketGroup{})
+	}
+
+	output.Print("⚖️  Evaluating against SLA rules...")
+
+	// Bugs deliberately deferred with 'bug-butler snooze' go to the Snoozed bucket
+	snoozes, err := snooze.Load(cfg.Check.SnoozeFile)
+	if err != nil {
+		return err
+	}
+
+	// Rules on the working-hours clock skip nights, weekends, and holidays
+	var workingHours *domain.WorkingHours
+	if cfg.UsesWorkingHours() {
+		if workingHours, err = sla.LoadWorkingHours(cfg.WorkingHours); err != nil {
+			return fmt.Errorf("failed to load working hours: %w", err)
+		}
+	}
+
+	// Evaluate bugs against SLA rules
+	evaluator := newEvaluator(cfg, cfg.SLARules, snoozes, workingHours)
+	bucketGroup := evaluator.Evaluate(bugs)
+
+	output.Println(" done")
+
+	// Explore alternative thresholds against the fetched bugs instead of reporting
+	if whatIfMode {
+		runWhatIf(cfg, bugs, snoozes, workingHours, bucketGroup)
+		return nil
+	}
+
+	// Display results
+	output.SetMaxDisplayAge(cfg.Check.MaxDisplayAgeDays)
+	output.SetShowSource(multiInstance)
+	output.SetShowTags(len(cfg.Tags) > 0)
+	output.SetDedupe(dedupeSummaries)
+	output.SetDeployTime(deployTime)
+	if bucketFilter == "" {
+		output.SetMinBucketSize(cfg.Check.MinBucketSize)
+	}
+
+	// Least severe buckets first for a "clean up easy wins" view
+	if bucketOrder == "desc" {
+		bucketGroup.SortDescending()
+	}
+
+	// With --bucket, only the matching bucket is reported; the exit code still reflects all of them
+	report := bucketGroup
+	if bucketFilter != "" {
+		report = bucketGroup.Only(bucketFilter)
+	}
+	if bucketFilter != "" && len(report.Buckets) == 0 {
+		output.Printf("\n✅ No bugs in bucket %q (%d violations in other buckets)\n", bucketFilter, bucketGroup.ActiveViolations())
+	} else {
+		output.DisplayBuckets(report)
+	}
+	if !deployTime.IsZero() {
+		output.DisplaySinceDeploy(stats.CreatedAfter(bugs, deployTime), deployTime)
+	}
+	if cfg.Check.AtRiskPercent > 0 {
+		output.DisplayAtRisk(bucketGroup.AtRisk)
+	}
+
+	// Break the backlog down by status phase if configured
+	if len(cfg.StatusPhases) > 0 {
+		output.DisplayPhases(stats.CountByPhase(bugs, cfg.StatusPhases))
+	}
+
+	// Surface heavily discussed unresolved bugs if configured
+	if cfg.Check.ThrashingComments > 0 {
+		output.DisplayThrashing(stats.FindThrashing(bugs, cfg.Check.ThrashingComments), cfg.Check.ThrashingComments)
+	}
+
+	// Roll up violating bugs per epic if an epic link field is configured
+	if epicLinkConfigured(connections) {
+		output.DisplayEpics(stats.GroupByEpic(bucketGroup.ActiveBugs()))
+	}
+
+	// Surface likely duplicates if requested
+	if detectDuplicates {
+		clusters := stats.FindDuplicates(bugs, cfg.Check.DuplicateSimilarity())
+		output.DisplayDuplicates(clusters)
+	}
+
+	// Write counts for CI steps if requested
+	if err := writeGitHubOutput(cfg, report); err != nil {
+		return err
+	}
+	if err := writeMetricsFile(cfg, report, len(bugs)); err != nil {
+		return err
+	}
+
+	// Post the report to configured destinations (failures don't abort the run)
+	sendNotifications(cmd.Context(), notify.FromConfig(cfg.Notify), report, cfg.Notify.MaxBugs, cfg.Notify.SummaryWidth)
+
+	// Scripts can grep the final line for counts (covers all buckets, even with --bucket)
+	printSummaryLine(cfg, bucketGroup)
+
+	// Pause on a terminal so the user can review and export before the run fails
+	if interactiveMode && bucketGroup.ActiveViolations() > 0 {
+		if err := reviewViolations(bucketGroup); err != nil {
+			return err
+		}
+	}
+
+	// With --baseline, only violations above the recorded counts fail the run
+	if baselinePath != "" {
+		regressed, err := checkBaseline(bucketGroup)
+		if err != nil || !regressed {
+			return err
+		}
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return ErrViolationsFound
+	}
+
+	// Signal violations to main, which maps them to --violations-exit-code (snoozed bugs don't count)
+	if bucketGroup.ActiveViolations() > 0 {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return ErrViolationsFound
+	}
+
+	return nil
+}
+
+// defaultReportPath is the suggested file name when exporting the report interactively
+const defaultReportPath = "bug-butler-report.md"
+
+// reviewViolations summarizes active violations and offers to export the full report as Markdown
+// It does nothing unless stdin is a terminal, so piped and CI runs are unaffected
+func reviewViolations(bucketGroup *domain.BucketGroup) error {
+	if !output.IsTerminal(os.Stdin) {
+		slog.Debug("Skipping interactive review, stdin is not a terminal")
+		return nil
+	}
+	return promptExport(bucketGroup)
+}
+
+// promptExport prints the per-bucket summary and writes the Markdown report if the user asks for it
+func promptExport(bucketGroup *domain.BucketGroup) error {
+	output.Printf("\n⏸  %d active SLA violations\n", bucketGroup.ActiveViolations())
+	for _, bucket := range bucketGroup.Buckets {
+		if bucket.Name != domain.SnoozedBucketName {
+			output.Printf("  %s: %d bugs\n", bucket.Name, len(bucket.Bugs))
+		}
+	}
+
+	if !promptYesNo("Export the report as Markdown?") {
+		return nil
+	}
+	path := promptString(fmt.Sprintf("File path (default %s)", defaultReportPath))
+	if path == "" {
+		path = defaultReportPath
+	}
+	if err := os.WriteFile(path, []byte(output.RenderMarkdown(bucketGroup, 0, 0)), 0644); err != nil {
+		return fmt.Errorf("failed to export report: %w", err)
+	}
+	output.Printf("💾 Report written to %s\n", path)
+	return nil
+}
+
+// newEvaluator creates an SLA evaluator for the given rules with the configured check settings
+func newEvaluator(cfg *config.Config, rules []config.SLARule, snoozes snooze.List, workingHours *domain.WorkingHours) *sla.Evaluator {
+	evaluator := sla.NewEvaluator(rules)
+	evaluator.SetWorkingHours(workingHours)
+	evaluator.SetIgnoreOlderThan(cfg.Check.IgnoreOlderThanDays)
+	evaluator.SetDataQuality(cfg.DataQuality.RequiredFields, cfg.DataQuality.Bucket, cfg.DataQuality.Severity)
+	evaluator.SetAtRiskPercent(cfg.Check.AtRiskPercent)
+	evaluator.SetActiveParentStatuses(cfg.Check.ActiveParentStatuses)
+	evaluator.SetLabelBuckets(cfg.LabelBuckets)
+	if len(snoozes) > 0 {
+		now := time.Now()
+		evaluator.SetSnoozed(func(key string) bool { return snoozes.Active(key, now) })
+	}
+	return evaluator
+}
+
+// fetchInstanceBugs authenticates with one Jira instance and fetches its bugs
+// The instance name is shown in progress messages when several are configured
+func fetchInstanceBugs(conn config.JiraConfig, showName bool, tags []config.TagQuery, fetchParents, fetchComments bool, priorities, statuses, fixVersions []string) ([]*domain.Bug, error) {
+	target := "Jira"
+	if showName {
+		target = conn.Name
+	}
+
+	output.Printf("\n🔐 Authenticating with %s...\n", target)
 
 	// Create Jira client
-	jiraClient, err := jira.NewClient(cfg.Jira)
+	jiraClient, err := jira.NewClient(conn)
 	if err != nil {
-		return fmt.Errorf("failed to create Jira client: %w", err)
+		return nil, fmt.Errorf("failed to create Jira client for %s: %w", conn.Name, err)
+	}
+	jiraClient.SetAllFields(allFields)
+	jiraClient.SetLogRequests(logRequests)
+	jiraClient.SetFetchComments(fetchComments)
+
+	output.Println("✓ Authenticated successfully")
+
+	if err := verifyProjects(jiraClient); err != nil {
+		return nil, err
+	}
+	output.Println()
+
+	// Fetch bugs from Jira, showing pagination progress
+	progress := output.NewProgress(fmt.Sprintf("📥 Fetching bugs from %s...", target))
+	var bugs []*domain.Bug
+	if customJQL != "" {
+		bugs, err = jiraClient.FetchBugsByJQL(customJQL, progress.Update)
+	} else {
+		bugs, err = jiraClient.FetchBugsWithFilters(priorities, statuses, fixVersions, progress.Update)
+	}
+	progress.Done()
+	if err := checkPartial(err); err != nil {
+		return nil, fmt.Errorf("failed to fetch bugs from %s: %w", conn.Name, err)
 	}
 
-	fmt.Println("✓ Authenticated successfully")
-	fmt.Print("\n📥 Fetching bugs...")
+	output.Printf(" found %d bugs\n", len(bugs))
 
-	// Parse priority and status filters
-	var priorities, statuses []string
-	if priorityFilter != "" {
-		priorities = strings.Split(priorityFilter, ",")
-		for i := range priorities {
-			priorities[i] = strings.TrimSpace(priorities[i])
+	// Annotate bugs matched by tag queries
+	if len(tags) > 0 && len(bugs) > 0 {
+		output.Printf("🏷️  Applying %d tag queries...\n", len(tags))
+		if err := jiraClient.TagBugs(bugs, tags); err != nil {
+			return nil, fmt.Errorf("failed to tag bugs from %s: %w", conn.Name, err)
 		}
 	}
-	if statusFilter != "" {
-		statuses = strings.Split(statusFilter, ",")
-		for i := range statuses {
-			statuses[i] = strings.TrimSpace(statuses[i])
+
+	// Parent statuses decide which bugs are covered by active parent work; without them
+	// nothing is suppressed, so a failed lookup only warns
+	if fetchParents && len(bugs) > 0 {
+		if err := jiraClient.AnnotateParentStatuses(bugs); err != nil {
+			slog.Warn("Failed to fetch parent statuses; no bugs are suppressed by their parent", "instance", conn.Name, "error", err)
 		}
 	}
 
-	// Fetch bugs from Jira
-	bugs, err := jiraClient.FetchBugsWithFilters(priorities, statuses)
+	return bugs, nil
+}
+
+// epicLinkConfigured reports whether any Jira instance has an epic link field configured
+func epicLinkConfigured(connections []config.JiraConfig) bool {
+	for _, conn := range connections {
+		if conn.CustomFieldIDs.EpicLink != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyProjects fails fast when configured project keys don't exist, unless --skip-project-check is set
+func verifyProjects(jiraClient *jira.Client) error {
+	if skipProjectCheck {
+		return nil
+	}
+
+	if err := jiraClient.ValidateProjects(); err != nil {
+		return fmt.Errorf("project check failed (use --skip-project-check to bypass): %w", err)
+	}
+
+	output.Println("✓ Projects verified")
+	return nil
+}
+
+// checkPartial lets a fetch that failed partway proceed with the results so far when --allow-partial is set
+func checkPartial(err error) error {
+	var partial *jira.PartialResultsError
+	if !errors.As(err, &partial) {
+		return err
+	}
+	if !allowPartial {
+		return fmt.Errorf("%w (use --allow-partial to proceed with the %d issues fetched)", err, partial.Fetched)
+	}
+
+	slog.Warn("Proceeding with partial results", "pages", partial.Pages, "fetched", partial.Fetched, "error", partial.Err)
+	output.Printf("\n⚠️  Partial results: continuing with %d issues from %d pages after a fetch error\n", partial.Fetched, partial.Pages)
+	return nil
+}
+
+// writeGitHubOutput appends violation counts for every configured bucket to the --github-output file
+func writeGitHubOutput(cfg *config.Config, bucketGroup *domain.BucketGroup) error {
+	if githubOutputPath == "" {
+		return nil
+	}
+
+	if err := output.WriteGitHubOutput(githubOutputPath, reportedBucketNames(cfg), bucketGroup); err != nil {
+		return fmt.Errorf("failed to write GitHub output: %w", err)
+	}
+
+	slog.Debug("Wrote GitHub output", "path", githubOutputPath)
+	return nil
+}
+
+// writeMetricsFile writes bug and violation gauges for every configured bucket to the --metrics-file file
+func writeMetricsFile(cfg *config.Config, bucketGroup *domain.BucketGroup, unresolvedBugs int) error {
+	if metricsFilePath == "" {
+		return nil
+	}
+
+	if err := output.WriteMetricsFile(metricsFilePath, reportedBucketNames(cfg), bucketGroup, unresolvedBugs); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	slog.Debug("Wrote metrics file", "path", metricsFilePath)
+	return nil
+}
+
+// reportedBucketNames returns the configured buckets, limited to the --bucket match if set
+func reportedBucketNames(cfg *config.Config) []string {
+	var bucketNames []string
+	for _, name := range configuredBucketNames(cfg) {
+		if bucketFilter == "" || domain.BucketNameMatches(name, bucketFilter) {
+			bucketNames = append(bucketNames, name)
+		}
+	}
+	return bucketNames
+}
+
+// configuredBucketNames returns the buckets the label buckets, SLA rules, and data quality checks can fill, in evaluation order
+func configuredBucketNames(cfg *config.Config) []string {
+	var bucketNames []string
+	for _, lb := range cfg.LabelBuckets {
+		bucketNames = append(bucketNames, lb.Bucket)
+	}
+	for _, rule := range cfg.SLARules {
+		bucketNames = append(bucketNames, rule.Bucket)
+		for _, tier := range rule.Tiers {
+			bucketNames = append(bucketNames, tier.Bucket)
+		}
+	}
+	if len(cfg.DataQuality.RequiredFields) > 0 {
+		bucketNames = append(bucketNames, cfg.DataQuality.Bucket)
+	}
+	return bucketNames
+}
+
+// printSummaryLine prints the machine-readable counts line if --summary-line is set
+// An empty bucket group (nothing evaluated) reports zero for every configured bucket
+func printSummaryLine(cfg *config.Config, bucketGroup *domain.BucketGroup) {
+	if !summaryLine {
+		return
+	}
+	output.Println(output.FormatSummaryLine(bucketGroup.ActiveViolations(), bucketGroup.Buckets, configuredBucketNames(cfg)))
+}
+
+// checkBaseline compares bucket counts against the --baseline file (or rewrites it with
+// --update-baseline) and reports whether any bucket's count increased
+func checkBaseline(bucketGroup *domain.BucketGroup) (bool, error) {
+	current := baseline.FromBuckets(bucketGroup)
+	if updateBaseline {
+		if err := current.Save(baselinePath); err != nil {
+			return false, err
+		}
+		output.Printf("\n📌 Baseline updated: %s\n", baselinePath)
+		return false, nil
+	}
+
+	previous, err := baseline.Load(baselinePath)
 	if err != nil {
-		return fmt.Errorf("failed to fetch bugs: %w", err)
+		return false, err
 	}
 
-	fmt.Printf(" found %d bugs\n", len(bugs))
+	increases := previous.Increases(current)
+	if len(increases) == 0 {
+		output.Println("\n✅ No bucket exceeds its baseline count")
+		return false, nil
+	}
+	output.Println("\n📈 Violations increased versus baseline:")
+	for _, increase := range increases {
+		output.Printf("   %s: %d → %d (+%d)\n", increase.Bucket, increase.Baseline, increase.Current, increase.Current-increase.Baseline)
+	}
+	return true, nil
+}
 
-	if len(bugs) == 0 {
-		fmt.Println("\n✅ No unresolved bugs found!")
+// validateBucketFilter rejects a --bucket name that no configured bucket matches
+func validateBucketFilter(cfg *config.Config) error {
+	if bucketFilter == "" {
 		return nil
 	}
+	var available []string
+	for _, name := range append(configuredBucketNames(cfg), domain.SnoozedBucketName) {
+		if domain.BucketNameMatches(name, bucketFilter) {
+			return nil
+		}
+		if !slices.Contains(available, name) {
+			available = append(available, name)
+		}
+	}
+	return fmt.Errorf("--bucket %q matches no configured bucket (available: %s)", bucketFilter, strings.Join(available, ", "))
+}
 
-	fmt.Print("⚖️  Evaluating against SLA rules...")
+// sendNotifications posts each route's buckets as a Markdown report to its notifier, warning on failures
+func sendNotifications(ctx context.Context, routes []notify.Route, bucketGroup *domain.BucketGroup, maxBugs, summaryWidth int) {
+	for _, route := range routes {
+		notifier := route.Notifier
 
-	// Create SLA evaluator
-	evaluator := sla.NewEvaluator(cfg.SLARules)
+		// Routed destinations only hear about their own buckets, and nothing when those are empty
+		selected := route.Select(bucketGroup)
+		if route.Filtered() && len(selected.Buckets) == 0 {
+			slog.Debug("No routed buckets to notify", "notifier", notifier.Name())
+			continue
+		}
 
-	// Evaluate bugs against SLA rules
-	bucketGroup := evaluator.Evaluate(bugs)
+		report := output.RenderMarkdown(selected, maxBugs, summaryWidth)
+		if err := notifier.Notify(ctx, report); err != nil {
+			slog.Warn("Failed to send notification", "notifier", notifier.Name(), "error", err)
+			output.Printf("⚠️  Failed to post report to %s (continuing)\n", notifier.Name())
+			continue
+		}
+		output.Printf("📨 Posted report to %s\n", notifier.Name())
+	}
+}
 
-	fmt.Println(" done")
+// splitCommaList splits a comma-separated flag value into trimmed entries
+func splitCommaList(value string) []string {
+	if value == "" {
+		return nil
+	}
 
-	// Display results
-	output.DisplayBuckets(bucketGroup)
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
+
+// allFieldsUsage describes the --all-fields flag
+const allFieldsUsage = "Fetch every navigable Jira field and include all custom fields in --dump-bugs output (slower)"
+
+// validateAllFields rejects --all-fields without --dump-bugs, since only the dump uses the extra data
+func validateAllFields() error {
+	if allFields && dumpBugsPath == "" {
+		return fmt.Errorf("--all-fields requires --dump-bugs")
+	}
+	return nil
+}
+
+// dumpBugs writes the fetched bugs to the --dump-bugs path, if one was given
+func dumpBugs(bugs []*domain.Bug) error {
+	if dumpBugsPath == "" {
+		return nil
+	}
 
-	// Exit with error code if there are violations
-	if len(bucketGroup.Buckets) > 0 {
-		os.Exit(1)
+	if err := output.DumpBugs(dumpBugsPath, bugs); err != nil {
+		return fmt.Errorf("failed to dump bugs: %w", err)
 	}
 
+	output.Printf("💾 Wrote %d bugs to %s\n", len(bugs), dumpBugsPath)
 	return nil
 }
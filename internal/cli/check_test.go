@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+	"github.com/neilmpatterson/bug-butler/internal/jira"
+	"github.com/neilmpatterson/bug-butler/internal/notify"
+)
+
+// labelBucketConfig has a label bucket alongside a tiered rule and a data quality bucket
+func labelBucketConfig() *config.Config {
+	return &config.Config{
+		LabelBuckets: []config.LabelBucket{{Label: "hotfix", Bucket: "🚒 HOTFIX"}},
+		SLARules: []config.SLARule{{
+			Name:   "critical",
+			Bucket: "🔴 URGENT",
+			Tiers:  []config.SLATier{{Bucket: "🔥 ESCALATED"}},
+		}},
+		DataQuality: config.DataQualityConfig{RequiredFields: []string{"priority"}, Bucket: "📋 DATA"},
+	}
+}
+
+func TestConfiguredBucketNames(t *testing.T) {
+	want := []string{"🚒 HOTFIX", "🔴 URGENT", "🔥 ESCALATED", "📋 DATA"}
+	if got := configuredBucketNames(labelBucketConfig()); !reflect.DeepEqual(got, want) {
+		t.Errorf("configuredBucketNames = %v, want %v", got, want)
+	}
+}
+
+func TestValidateBucketFilterAcceptsLabelBucket(t *testing.T) {
+	defer func(saved string) { bucketFilter = saved }(bucketFilter)
+
+	bucketFilter = "hotfix"
+	if err := validateBucketFilter(labelBucketConfig()); err != nil {
+		t.Errorf("validateBucketFilter(%q) = %v, want nil", bucketFilter, err)
+	}
+
+	bucketFilter = "nonexistent"
+	if err := validateBucketFilter(labelBucketConfig()); err == nil {
+		t.Errorf("validateBucketFilter(%q) = nil, want an error", bucketFilter)
+	}
+}
+
+func TestFetchInstanceBugsFromTwoInstances(t *testing.T) {
+	// newInstance starts a Jira stand-in whose search returns one bug with the given key
+	newInstance := func(key string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == jira.DefaultSearchPath {
+				fmt.Fprintf(w, `{"issues":[{"key":%q,"fields":{"summary":"Broken","priority":{"name":"High"}}}]}`, key)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	cloud := newInstance("CLOUD-1")
+	legacy := newInstance("LEGACY-1")
+
+	cfg := &config.Config{
+		Jira:          config.JiraConfig{Name: "cloud", BaseURL: cloud.URL, ProjectKeys: []string{"CLOUD"}},
+		JiraInstances: []config.JiraConfig{{Name: "legacy", BaseURL: legacy.URL, ProjectKeys: []string{"LEGACY"}}},
+	}
+
+	sources := make(map[string]string)
+	for _, conn := range cfg.JiraConnections() {
+		bugs, err := fetchInstanceBugs(conn, true, nil, false, false, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("fetchInstanceBugs(%s): %v", conn.Name, err)
+		}
+		for _, bug := range bugs {
+			sources[bug.Key] = bug.Source
+		}
+	}
+
+	want := map[string]string{"CLOUD-1": "cloud", "LEGACY-1": "legacy"}
+	if !reflect.DeepEqual(sources, want) {
+		t.Errorf("bug sources = %v, want %v", sources, want)
+	}
+}
+
+func TestCheckPartial(t *testing.T) {
+	defer func(saved bool) { allowPartial = saved }(allowPartial)
+
+	partial := &jira.PartialResultsError{Pages: 2, Fetched: 200, Err: errors.New("502 Bad Gateway")}
+
+	allowPartial = false
+	err := checkPartial(partial)
+	if err == nil || !strings.Contains(err.Error(), "--allow-partial") {
+		t.Errorf("checkPartial without --allow-partial = %v, want an error suggesting it", err)
+	}
+
+	allowPartial = true
+	if err := checkPartial(partial); err != nil {
+		t.Errorf("checkPartial with --allow-partial = %v, want nil", err)
+	}
+
+	// Other errors fail regardless of --allow-partial
+	if err := checkPartial(errors.New("401 Unauthorized")); err == nil {
+		t.Error("checkPartial(auth error) = nil, want the error")
+	}
+}
+
+// recordingNotifier records the reports it is sent, failing every delivery if err is set
+type recordingNotifier struct {
+	name    string
+	err     error
+	reports []string
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Notify(_ context.Context, report string) error {
+	n.reports = append(n.reports, report)
+	return n.err
+}
+
+func TestSendNotificationsRoutesBuckets(t *testing.T) {
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{
+		{Name: "🔴 URGENT", Severity: 1, Bugs: []*domain.Bug{{Key: "DEMO-1"}}},
+		{Name: "🔵 REVIEW", Severity: 3, Bugs: []*domain.Bug{{Key: "DEMO-2"}}},
+	}}
+
+	everything := &recordingNotifier{name: "everything"}
+	oncall := &recordingNotifier{name: "oncall", err: errors.New("channel archived")}
+	team := &recordingNotifier{name: "team"}
+	hotfix := &recordingNotifier{name: "hotfix"}
+	routes := []notify.Route{
+		{Notifier: everything},
+		{Notifier: oncall, Buckets: []string{"urgent"}},
+		{Notifier: team, Severities: []int{3}},
+		{Notifier: hotfix, Buckets: []string{"hotfix"}},
+	}
+
+	sendNotifications(context.Background(), routes, bucketGroup, 0, 0)
+
+	tests := []struct {
+		notifier      *recordingNotifier
+		want, notWant []string
+	}{
+		{everything, []string{"DEMO-1", "DEMO-2"}, nil},
+		{oncall, []string{"DEMO-1"}, []string{"DEMO-2"}},
+		{team, []string{"DEMO-2"}, []string{"DEMO-1"}},
+	}
+	for _, tt := range tests {
+		// A failed delivery doesn't stop the routes after it
+		if len(tt.notifier.reports) != 1 {
+			t.Fatalf("%s got %d reports, want 1", tt.notifier.name, len(tt.notifier.reports))
+		}
+		report := tt.notifier.reports[0]
+		for _, key := range tt.want {
+			if !strings.Contains(report, key) {
+				t.Errorf("%s report missing %s:\n%s", tt.notifier.name, key, report)
+			}
+		}
+		for _, key := range tt.notWant {
+			if strings.Contains(report, key) {
+				t.Errorf("%s report includes %s from another route:\n%s", tt.notifier.name, key, report)
+			}
+		}
+	}
+	if len(hotfix.reports) != 0 {
+		t.Errorf("hotfix route with no matching buckets was sent %d reports, want none", len(hotfix.reports))
+	}
+}
+
+func TestPromptExport(t *testing.T) {
+	defer func(saved *bufio.Reader) { promptReader = saved }(promptReader)
+	t.Chdir(t.TempDir())
+
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{
+		{Name: "🚒 Critical", Bugs: []*domain.Bug{{Key: "DEMO-1", Summary: "Checkout fails"}}},
+	}}
+
+	tests := []struct {
+		name     string
+		answers  string
+		wantPath string
+	}{
+		{"declined", "n\n", ""},
+		{"custom path", "y\nreport.md\n", "report.md"},
+		{"default path", "yes\n\n", defaultReportPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promptReader = bufio.NewReader(strings.NewReader(tt.answers))
+			if err := promptExport(bucketGroup); err != nil {
+				t.Fatalf("promptExport: %v", err)
+			}
+
+			entries, _ := os.ReadDir(".")
+			if tt.wantPath == "" {
+				if len(entries) != 0 {
+					t.Errorf("declined export wrote %d files", len(entries))
+				}
+				return
+			}
+			defer os.Remove(tt.wantPath)
+			data, err := os.ReadFile(tt.wantPath)
+			if err != nil {
+				t.Fatalf("report not written to %s: %v", tt.wantPath, err)
+			}
+			if !strings.Contains(string(data), "DEMO-1") {
+				t.Errorf("report missing DEMO-1:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestPromptHelpersShareReader(t *testing.T) {
+	defer func(saved *bufio.Reader) { promptReader = saved }(promptReader)
+	promptReader = bufio.NewReader(strings.NewReader("2\nyes\n  release-1  \n"))
+
+	if got := promptChoice("Pick one", []string{"a", "b", "c"}); got != 1 {
+		t.Errorf("promptChoice = %d, want 1", got)
+	}
+	if !promptYesNo("Continue?") {
+		t.Error("promptYesNo = false, want true")
+	}
+	if got := promptString("Name"); got != "release-1" {
+		t.Errorf("promptString = %q, want release-1", got)
+	}
+	// Input is exhausted, so prompts fall back to their empty answers
+	if got := promptChoice("Pick one", []string{"a"}); got != -1 {
+		t.Errorf("promptChoice at EOF = %d, want -1", got)
+	}
+}
+
+func TestValidateScheduleFlags(t *testing.T) {
+	defer func(interactive, whatIf, update bool, github string) {
+		interactiveMode, whatIfMode, updateBaseline, githubOutputPath = interactive, whatIf, update, github
+	}(interactiveMode, whatIfMode, updateBaseline, githubOutputPath)
+
+	tests := []struct {
+		name    string
+		set     func()
+		wantErr string
+	}{
+		{"plain schedule", func() {}, ""},
+		{"interactive", func() { interactiveMode = true }, "--interactive"},
+		{"update baseline", func() { updateBaseline = true }, "--update-baseline"},
+		{"github output", func() { githubOutputPath = "out.txt" }, "--github-output"},
+	}
+	for _, tt := range tests {
+		interactiveMode, whatIfMode, updateBaseline, githubOutputPath = false, false, false, ""
+		tt.set()
+		err := validateScheduleFlags()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: validateScheduleFlags = %v, want nil", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: validateScheduleFlags = %v, want an error naming %s", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSendNotificationsSummaryWidth(t *testing.T) {
+	summary := "Checkout button unresponsive after applying a discount code on mobile Safari"
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{
+		{Name: "🔴 URGENT", Severity: 1, Bugs: []*domain.Bug{{Key: "DEMO-1", Summary: summary}}},
+	}}
+
+	tests := []struct {
+		width int
+		want  string
+	}{
+		// 0 keeps the whole summary, even past the terminal table's 40 characters
+		{0, summary},
+		{60, summary[:57] + "..."},
+		{20, summary[:17] + "..."},
+	}
+	for _, tt := range tests {
+		n := &recordingNotifier{name: "slack"}
+		sendNotifications(context.Background(), []notify.Route{{Notifier: n}}, bucketGroup, 0, tt.width)
+
+		if len(n.reports) != 1 {
+			t.Fatalf("width %d: got %d reports, want 1", tt.width, len(n.reports))
+		}
+		if !strings.Contains(n.reports[0], "| "+tt.want+" |") {
+			t.Errorf("width %d: report summary isn't %q:\n%s", tt.width, tt.want, n.reports[0])
+		}
+	}
+}
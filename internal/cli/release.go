@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/jira"
+	"github.com/neilmpatterson/bug-butler/internal/output"
+	"github.com/neilmpatterson/bug-butler/internal/stats"
+)
+
+var (
+	releaseSince string
+	releaseUntil string
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Report resolved bugs by week for release notes",
+	Long: `Release reports how many bugs were resolved each week over a date
+range, with a breakdown by priority. Weeks start on Monday.
+
+By default it covers the last 4 weeks. Use --since and --until
+(YYYY-MM-DD, until is exclusive) to report on a release window:
+
+  bug-butler release --since 2025-09-01 --until 2025-10-01`,
+	RunE: runRelease,
+}
+
+func init() {
+	releaseCmd.Flags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to configuration file")
+	releaseCmd.Flags().BoolVar(&debugMode, "debug", false, "Enable debug logging")
+	releaseCmd.Flags().StringVar(&releaseSince, "since", "", "Start date, inclusive (YYYY-MM-DD; default: 4 weeks before --until)")
+	releaseCmd.Flags().StringVar(&releaseUntil, "until", "", "End date, exclusive (YYYY-MM-DD; default: tomorrow)")
+	releaseCmd.Flags().StringVar(&dumpBugsPath, "dump-bugs", "", "Write the fetched bugs as JSON to this file")
+	releaseCmd.Flags().BoolVar(&allFields, "all-fields", false, allFieldsUsage)
+	releaseCmd.Flags().BoolVar(&plainOutput, "plain", false, "Plain-text output without emoji, colors, or banners")
+	releaseCmd.Flags().StringVar(&outputFormat, "format", "", formatFlagUsage)
+	releaseCmd.Flags().BoolVar(&allowPartial, "allow-partial", false, "Proceed with the bugs fetched so far if pagination fails partway")
+	releaseCmd.Flags().BoolVar(&skipProjectCheck, "skip-project-check", false, "Skip verifying that configured projects exist before fetching")
+	rootCmd.AddCommand(releaseCmd)
+}
+
+func runRelease(cmd *cobra.Command, args []string) error {
+	// Set log level based on debug flag
+	if debugMode {
+		logLevel.Set(slog.LevelDebug)
+		slog.Debug("Debug mode enabled")
+	}
+
+	// Configure output styling
+	if err := configureOutput(outputFormat); err != nil {
+		return err
+	}
+
+	if err := validateAllFields(); err != nil {
+		return err
+	}
+
+	// Resolve the date range (until is exclusive, so default to tomorrow to include today)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	endDate := today.AddDate(0, 0, 1)
+	if releaseUntil != "" {
+		parsed, err := time.Parse("2006-01-02", releaseUntil)
+		if err != nil {
+			return fmt.Errorf("invalid --until date %q (expected YYYY-MM-DD): %w", releaseUntil, err)
+		}
+		endDate = parsed
+	}
+	startDate := endDate.AddDate(0, 0, -28)
+	if releaseSince != "" {
+		parsed, err := time.Parse("2006-01-02", releaseSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q (expected YYYY-MM-DD): %w", releaseSince, err)
+		}
+		startDate = parsed
+	}
+	if !startDate.Before(endDate) {
+		return fmt.Errorf("--since must be before --until")
+	}
+
+	output.Println("🔍 Loading configuration...")
+
+	// Load configuration
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Apply output locale and date formats
+	if err := applyOutputConfig(cfg.Output); err != nil {
+		return err
+	}
+
+	output.Println("\n🔐 Authenticating with Jira...")
+
+	// Create Jira client
+	jiraClient, err := jira.NewClient(cfg.Jira)
+	if err != nil {
+		return fmt.Errorf("failed to create Jira client: %w", err)
+	}
+	jiraClient.SetAllFields(allFields)
+	jiraClient.SetLogRequests(logRequests)
+
+	output.Println("✓ Authenticated successfully")
+
+	if err := verifyProjects(jiraClient); err != nil {
+		return err
+	}
+
+	// Fetch resolved bugs, showing pagination progress
+	progress := output.NewProgress("\n📥 Fetching resolved bugs...")
+	bugs, err := jiraClient.FetchResolvedBugs(startDate, endDate, progress.Update)
+	progress.Done()
+	if err := checkPartial(err); err != nil {
+		return fmt.Errorf("failed to fetch resolved bugs: %w", err)
+	}
+
+	output.Printf(" found %d bugs\n", len(bugs))
+
+	// Dump raw bug data if requested
+	if err := dumpBugs(bugs); err != nil {
+		return err
+	}
+
+	weeks := stats.GroupResolvedByWeek(bugs, startDate, endDate)
+	output.DisplayReleaseReport(weeks, startDate, endDate)
+
+	return nil
+}
@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/output"
 )
 
 const version = "0.1.0"
@@ -18,6 +24,17 @@ It tracks bugs based on priority, status, and time since last activity
 to help you identify what needs immediate attention.`,
 }
 
+// LogFormatEnv is the environment variable selecting the log format when --log-format isn't set
+const LogFormatEnv = "BUG_BUTLER_LOG_FORMAT"
+
+var logFormat string
+
+// logRequests logs each Jira request's JQL and fields at info level (--log-requests)
+var logRequests bool
+
+// logLevel is the level of the default logger (raised by --debug)
+var logLevel = new(slog.LevelVar)
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
@@ -27,10 +44,80 @@ var versionCmd = &cobra.Command{
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Log format: text or json (default from $"+LogFormatEnv+", else text)")
+	rootCmd.PersistentFlags().BoolVar(&logRequests, "log-requests", false, "Log the JQL and fields of every Jira request at info level (credentials are never logged)")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if logFormat == "" {
+			return nil // Keep the handler main configured from the environment
+		}
+		return ConfigureLogging(logFormat)
+	}
 	rootCmd.AddCommand(versionCmd)
 }
 
+// outputFormat is the --format flag shared by the reporting commands; plainOutput (--plain) is shorthand for plain
+var outputFormat string
+
+// formatFlagUsage describes the --format flag
+const formatFlagUsage = "Output format: auto, rich, or plain (auto is plain when stdout isn't a terminal)"
+
+// configureOutput applies the terminal output format (normally --format) and --plain to the output package
+func configureOutput(format string) error {
+	if plainOutput {
+		if format != "" && format != output.FormatPlain {
+			return fmt.Errorf("--plain conflicts with --format %s", format)
+		}
+		return output.SetFormat(output.FormatPlain)
+	}
+	return output.SetFormat(format)
+}
+
+// applyOutputConfig applies the output locale and date formats from the configuration
+func applyOutputConfig(cfg config.OutputConfig) error {
+	if err := output.SetLocale(cfg.Locale); err != nil {
+		return fmt.Errorf("invalid output.locale: %w", err)
+	}
+	if err := output.SetDateFormat(cfg.DateFormat, cfg.MonthFormat); err != nil {
+		return fmt.Errorf("invalid output settings: %w", err)
+	}
+	return nil
+}
+
+// ConfigureLogging installs the default slog handler writing to stderr in the given format
+// An empty format selects text
+func ConfigureLogging(format string) error {
+	opts := &slog.HandlerOptions{Level: logLevel}
+
+	var handler slog.Handler
+	switch format {
+	case "", "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return fmt.Errorf("invalid log format %q (must be text or json)", format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// ErrViolationsFound is returned by check when SLA violations were found
+var ErrViolationsFound = errors.New("SLA violations found")
+
 // Execute runs the root command
 func Execute() error {
 	return rootCmd.Execute()
 }
+
+// ExitCode maps the result of Execute to a process exit code
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, ErrViolationsFound):
+		return violationsExitCode
+	default:
+		return 1
+	}
+}
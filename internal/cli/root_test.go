@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	defer func(saved int) { violationsExitCode = saved }(violationsExitCode)
+
+	tests := []struct {
+		name          string
+		violationCode int
+		err           error
+		want          int
+	}{
+		{"success", 3, nil, 0},
+		{"violations use the configured code", 3, ErrViolationsFound, 3},
+		{"wrapped violations", 3, fmt.Errorf("check: %w", ErrViolationsFound), 3},
+		{"violations treated as success", 0, ErrViolationsFound, 0},
+		{"other errors", 3, errors.New("auth failed"), 1},
+	}
+
+	for _, tt := range tests {
+		violationsExitCode = tt.violationCode
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("%s: ExitCode = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfigureLoggingJSON(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	defer func(saved *os.File) { os.Stderr = saved }(os.Stderr)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+
+	if err := ConfigureLogging("json"); err != nil {
+		t.Fatalf("ConfigureLogging(json): %v", err)
+	}
+	slog.Info("Fetched bugs", "count", 3)
+	w.Close()
+
+	line, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record map[string]any
+	if err := json.Unmarshal(line, &record); err != nil {
+		t.Fatalf("log line %q is not JSON: %v", line, err)
+	}
+	if record["msg"] != "Fetched bugs" || record["count"] != float64(3) {
+		t.Errorf("log record = %v, want msg \"Fetched bugs\" and count 3", record)
+	}
+
+	if err := ConfigureLogging("xml"); err == nil {
+		t.Error("ConfigureLogging(xml) = nil, want an error")
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/output"
+)
+
+var showRuleExamples bool
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the configured SLA rules",
+	Long: `Rules prints each configured SLA rule (name, priority, statuses,
+max age, bucket, and severity) in evaluation order. No Jira connection
+is made.
+
+Use --examples to also describe a bug that would violate each rule,
+which helps when onboarding to a team's SLA configuration.`,
+	RunE: runRules,
+}
+
+func init() {
+	rulesCmd.Flags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to configuration file")
+	rulesCmd.Flags().BoolVar(&showRuleExamples, "examples", false, "Show an example violating bug for each rule")
+	rulesCmd.Flags().BoolVar(&plainOutput, "plain", false, "Plain-text output without emoji, colors, or banners")
+	rulesCmd.Flags().StringVar(&outputFormat, "format", "", formatFlagUsage)
+	rootCmd.AddCommand(rulesCmd)
+}
+
+func runRules(cmd *cobra.Command, args []string) error {
+	if err := configureOutput(outputFormat); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := applyOutputConfig(cfg.Output); err != nil {
+		return err
+	}
+
+	output.DisplayRules(cfg.SLARules, showRuleExamples)
+	return nil
+}
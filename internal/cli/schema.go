@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for the configuration file",
+	Long: `Schema prints a JSON Schema describing the configuration file
+(jira settings, SLA rules, and report options).
+
+Point your editor's YAML/JSON language server at the output to get
+validation and autocompletion while editing config files:
+
+  bug-butler schema > bug-butler.schema.json`,
+	RunE: runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	schema, err := config.JSONSchema()
+	if err != nil {
+		return fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	fmt.Println(string(schema))
+	return nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/output"
+	"github.com/neilmpatterson/bug-butler/internal/snooze"
+)
+
+var (
+	snoozeUntil string
+	snoozeClear bool
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze KEY",
+	Short: "Defer a bug's SLA violations until a date",
+	Long: `Snooze records a bug as deliberately deferred. Until the given date,
+check lists the bug in a low-severity "Snoozed" bucket instead of the
+bucket its SLA rule would put it in, and snoozed bugs don't affect the
+exit code.
+
+Snoozes are stored in check.snooze_file (default: bug-butler-snoozes.json).
+The snooze ends at the start of the --until date (UTC).
+
+Examples:
+  bug-butler snooze PROJ-123 --until 2025-06-01
+  bug-butler snooze PROJ-123 --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnooze,
+}
+
+func init() {
+	snoozeCmd.Flags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to configuration file")
+	snoozeCmd.Flags().StringVar(&snoozeUntil, "until", "", "Date the snooze ends (YYYY-MM-DD)")
+	snoozeCmd.Flags().BoolVar(&snoozeClear, "clear", false, "Remove the bug's snooze")
+	rootCmd.AddCommand(snoozeCmd)
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if snoozeClear == (snoozeUntil != "") {
+		return fmt.Errorf("exactly one of --until or --clear is required")
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	snoozes, err := snooze.Load(cfg.Check.SnoozeFile)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if snoozeClear {
+		delete(snoozes, key)
+		if err := snoozes.Save(cfg.Check.SnoozeFile, now); err != nil {
+			return err
+		}
+		output.Printf("⏰ %s is no longer snoozed\n", key)
+		return nil
+	}
+
+	until, err := snooze.ParseDate(snoozeUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until date: %w", err)
+	}
+	if !now.Before(until) {
+		return fmt.Errorf("--until must be in the future")
+	}
+
+	snoozes[key] = until
+	if err := snoozes.Save(cfg.Check.SnoozeFile, now); err != nil {
+		return err
+	}
+
+	output.Printf("💤 %s snoozed until %s\n", key, output.FormatDate(until))
+	return nil
+}
@@ -19,15 +19,15 @@ import (
 
 var statsCmd = &cobra.Command{
 	Use:   "stats",
-	Short: "Display bug trend statistics over 24 months",
-	Long: `Stats fetches bugs created over the past 24 months and displays
-trend analysis including monthly creation rates, unresolved bug trends,
-priority breakdowns, and progress toward reduction goals.
+	Short: "Display bug trend statistics over the configured period",
+	Long: `Stats fetches bugs created over the past stats.months_to_analyze months
+(default 24) and displays trend analysis including monthly creation rates,
+unresolved bug trends, priority breakdowns, and progress toward reduction goals.
 
 The stats command shows:
 - Sparkline of unresolved bug backlog over time
 - Monthly breakdown of created, resolved, and unresolved bugs
-- Current month goal tracking (comparing to same month last year)
+- Current month goal tracking (comparing to the same month last year, or other configured baselines)
 - Priority distribution trends
 
 This helps track whether your team is making progress on reducing
@@ -35,23 +35,59 @@ the overall bug backlog and meeting reduction goals.`,
 	RunE: runStats,
 }
 
-var interactiveMode bool
+var (
+	interactiveMode bool
+	exportCSVPath   string
+	outputFile      string
+	statsGroupBy    string
+)
+
+// statsFormatUsage describes the stats --format flag, which can also write a PDF report
+const statsFormatUsage = "Output format: auto, rich, plain, or pdf (auto on the terminal plus a PDF report written to --output-file)"
 
 func init() {
 	statsCmd.Flags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to configuration file")
 	statsCmd.Flags().BoolVar(&debugMode, "debug", false, "Enable debug logging")
 	statsCmd.Flags().BoolVarP(&interactiveMode, "interactive", "i", false, "Interactive mode - prompt for sprint options")
+	statsCmd.Flags().StringVar(&dumpBugsPath, "dump-bugs", "", "Write the fetched bugs as JSON to this file")
+	statsCmd.Flags().BoolVar(&allFields, "all-fields", false, allFieldsUsage)
+	statsCmd.Flags().StringVar(&exportCSVPath, "export-csv", "", "Write the monthly statistics as CSV to this file")
+	statsCmd.Flags().BoolVar(&plainOutput, "plain", false, "Plain-text output without emoji, colors, or banners")
+	statsCmd.Flags().StringVar(&outputFormat, "format", "", statsFormatUsage)
+	statsCmd.Flags().StringVar(&outputFile, "output-file", "", "Path of the report file written by --format pdf")
+	statsCmd.Flags().StringVar(&statsGroupBy, "group-by", "", "Also show a monthly table per group: component (each component as a pseudo-project)")
+	statsCmd.Flags().BoolVar(&allowPartial, "allow-partial", false, "Proceed with the bugs fetched so far if pagination fails partway")
+	statsCmd.Flags().BoolVar(&skipProjectCheck, "skip-project-check", false, "Skip verifying that configured projects exist before fetching")
 	rootCmd.AddCommand(statsCmd)
 }
 
 func runStats(cmd *cobra.Command, args []string) error {
 	// Set log level based on debug flag
 	if debugMode {
-		slog.SetLogLoggerLevel(slog.LevelDebug)
+		logLevel.Set(slog.LevelDebug)
 		slog.Debug("Debug mode enabled")
 	}
 
-	fmt.Println("🔍 Loading configuration...")
+	// A PDF report is written alongside the usual terminal output
+	pdfPath, terminalFormat, err := pdfReportPath()
+	if err != nil {
+		return err
+	}
+
+	if statsGroupBy != "" && statsGroupBy != "component" {
+		return fmt.Errorf("--group-by must be component, got %q", statsGroupBy)
+	}
+
+	// Configure output styling
+	if err := configureOutput(terminalFormat); err != nil {
+		return err
+	}
+
+	if err := validateAllFields(); err != nil {
+		return err
+	}
+
+	output.Println("🔍 Loading configuration...")
 
 	// Load configuration
 	cfg, err := config.Load(configPath)
@@ -59,55 +95,87 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	fmt.Printf("📋 Projects: %d configured\n", len(cfg.Jira.ProjectKeys))
-	fmt.Printf("📊 Analysis Period: Last %d months\n", cfg.Stats.MonthsToAnalyze)
-	fmt.Printf("🎯 Reduction Goal: %.0f%%\n", cfg.Stats.ReductionGoalPercent)
+	// Apply output locale and date formats
+	if err := applyOutputConfig(cfg.Output); err != nil {
+		return err
+	}
+
+	output.Printf("📋 Projects: %d configured\n", len(cfg.Jira.ProjectKeys))
+	output.Printf("📊 Analysis Period: Last %d months\n", cfg.Stats.MonthsToAnalyze-cfg.Stats.TrimLeadingMonths)
+	output.Printf("🎯 Reduction Goal: %.0f%%\n", cfg.Stats.ReductionGoalPercent)
 
 	slog.Debug("Configuration loaded successfully",
 		"project_count", len(cfg.Jira.ProjectKeys),
 		"months_to_analyze", cfg.Stats.MonthsToAnalyze,
 		"reduction_goal", cfg.Stats.ReductionGoalPercent,
+		"sprint_field_id", cfg.Jira.CustomFieldIDs.Sprint,
+		"story_points_field_id", cfg.Jira.CustomFieldIDs.StoryPoints,
 	)
 
-	fmt.Println("\n🔐 Authenticating with Jira...")
+	output.Println("\n🔐 Authenticating with Jira...")
 
 	// Create Jira client
 	jiraClient, err := jira.NewClient(cfg.Jira)
 	if err != nil {
 		return fmt.Errorf("failed to create Jira client: %w", err)
 	}
+	jiraClient.SetAllFields(allFields)
+	jiraClient.SetLogRequests(logRequests)
 
-	fmt.Println("✓ Authenticated successfully")
+	output.Println("✓ Authenticated successfully")
 
-	// Calculate date range: last N months + current month
-	now := time.Now()
-	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if err := verifyProjects(jiraClient); err != nil {
+		return err
+	}
 
-	// We need to fetch ALL bugs from the beginning to calculate unresolved counts
-	// But for display we'll only show the last N months
-	// Fetch from 3 years ago to ensure we have enough history
-	startDate := currentMonth.AddDate(-3, 0, 0)
+	// Fetch the analyzed months plus the history their goal baselines need
+	now := time.Now()
+	startDate := stats.FetchStart(now, cfg.Stats.MonthsToAnalyze)
 
-	fmt.Printf("\n📥 Fetching bug data...\n")
-	fmt.Printf("  Date range: %s to %s\n", startDate.Format("2006-01-02"), now.Format("2006-01-02"))
+	output.Printf("\n📥 Fetching bug data...\n")
+	output.Printf("  Date range: %s to %s\n", output.FormatDate(startDate), output.FormatDate(now))
 
-	// Fetch bugs from Jira
-	bugs, err := jiraClient.FetchBugsByDateRange(startDate, now)
-	if err != nil {
+	// Fetch bugs from Jira, showing pagination progress
+	progress := output.NewProgress("  Fetching pages...")
+	bugs, err := jiraClient.FetchBugsByDateRange(startDate, now, progress.Update)
+	progress.Done()
+	if err := checkPartial(err); err != nil {
 		return fmt.Errorf("failed to fetch bugs: %w", err)
 	}
 
-	fmt.Printf("  Found %d bugs\n", len(bugs))
+	output.Printf(" found %d bugs\n", len(bugs))
+
+	// Dump raw bug data if requested
+	if err := dumpBugs(bugs); err != nil {
+		return err
+	}
 
 	if len(bugs) == 0 {
-		fmt.Println("\n⚠️  No bug data available for the selected time range")
+		output.Println("\n⚠️  No bug data available for the selected time range")
 		return nil
 	}
 
-	fmt.Print("\n📈 Analyzing trends...")
+	output.Print("\n📈 Analyzing trends...")
 
 	// Create analyzer with config
 	analyzer := stats.NewAnalyzer(cfg.Stats.ReductionGoalPercent, cfg.Stats.MonthsToAnalyze)
+	analyzer.SetBugIssueTypes(cfg.Jira.BugIssueTypes)
+	analyzer.SetSprintStates(cfg.Stats.SprintStates)
+	analyzer.SetSprintMinIssues(cfg.Stats.SprintMinIssues)
+	analyzer.SetDefaultStoryPoints(cfg.Stats.SprintDefaultPoints)
+	analyzer.SetGoalComparisonMode(cfg.Stats.Goal.ComparisonMode)
+	analyzer.SetGoalRounding(cfg.Stats.Goal.Rounding)
+	analyzer.SetGoalBaselineOffsets(cfg.Stats.Goal.BaselineOffsetsMonths)
+	analyzer.SetPriorityGoals(cfg.Stats.PriorityGoals)
+	analyzer.SetSprintSortBy(cfg.Stats.SprintSortBy)
+	analyzer.SetExcludeFutureDated(cfg.Stats.FutureDatedBugs == "exclude")
+	analyzer.SetResolvedStatuses(cfg.Stats.ResolvedStatuses)
+	analyzer.SetIgnoredResolutions(cfg.Stats.IgnoredResolutions)
+	analyzer.SetPriorityWeights(cfg.Stats.PriorityWeights)
+	analyzer.SetZeroResolvedAlert(cfg.Stats.ZeroResolvedAlertAvg)
+	analyzer.SetStatusPhases(cfg.StatusPhases)
+	analyzer.SetTrimLeadingMonths(cfg.Stats.TrimLeadingMonths)
+	analyzer.SetMinLifetime(time.Duration(cfg.Stats.MinLifetimeMinutes * float64(time.Minute)))
 
 	// Analyze bugs
 	trendStats, err := analyzer.Analyze(bugs)
@@ -115,7 +183,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to analyze trends: %w", err)
 	}
 
-	fmt.Println(" done")
+	output.Println(" done")
 
 	// Get sprint configuration (interactive or from config)
 	var sprintCfg sprintFilterConfig
@@ -133,7 +201,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	// Calculate sprint statistics if enabled
 	if sprintCfg.showSprints {
-		fmt.Print("\n🏃 Analyzing sprint statistics...")
+		output.Print("\n🏃 Analyzing sprint statistics...")
 
 		// Extract and filter sprint IDs by name pattern (before fetching issues!)
 		var sprintIDs []string
@@ -144,11 +212,11 @@ func runStats(cmd *cobra.Command, args []string) error {
 				sprintCfg.nameBeginsWith,
 				sprintCfg.namePattern,
 			)
-			fmt.Printf("\n  Filtered to %d sprints (from bugs data)\n", len(sprintIDs))
+			output.Printf("\n  Filtered to %d sprints (from bugs data)\n", len(sprintIDs))
 		} else {
 			// No filtering - extract all sprints
 			sprintIDs = stats.ExtractSprintIDs(bugs)
-			fmt.Printf("\n  Found %d sprints with bugs\n", len(sprintIDs))
+			output.Printf("\n  Found %d sprints with bugs\n", len(sprintIDs))
 		}
 
 		slog.Debug("Sprint extraction complete",
@@ -158,7 +226,6 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 		if len(sprintIDs) > 0 {
 			slog.Debug("Sprint IDs", "ids", sprintIDs)
-			fmt.Print("  Fetching issues for filtered sprints...")
 
 			// Apply sprint board filter if configured (to match Jira board's Sprint Report)
 			if sprintCfg.boardFilter != "" {
@@ -167,16 +234,19 @@ func runStats(cmd *cobra.Command, args []string) error {
 			}
 
 			// Fetch all done issues for these sprints
-			sprintIssues, err := jiraClient.FetchIssuesBySprints(sprintIDs)
-			if err != nil {
+			jiraClient.SetSprintBatching(cfg.Stats.SprintBatchSize, cfg.Stats.SprintConcurrency)
+			sprintProgress := output.NewProgress("  Fetching issues for filtered sprints...")
+			sprintIssues, err := jiraClient.FetchIssuesBySprints(sprintIDs, sprintProgress.Update)
+			sprintProgress.Done()
+			if err := checkPartial(err); err != nil {
 				slog.Warn("Failed to fetch sprint issues", "error", err)
-				fmt.Println(" failed (continuing without sprint stats)")
+				output.Println(" failed (continuing without sprint stats)")
 			} else {
-				fmt.Printf(" found %d issues\n", len(sprintIssues))
+				output.Printf(" found %d issues\n", len(sprintIssues))
 				slog.Debug("Sprint issues fetched",
 					"issue_count", len(sprintIssues),
 				)
-				fmt.Print("  Calculating sprint metrics...")
+				output.Print("  Calculating sprint metrics...")
 
 				// Calculate sprint statistics (with optional name filtering)
 				trendStats.SprintStats = analyzer.CalculateSprintStats(
@@ -189,16 +259,13 @@ func runStats(cmd *cobra.Command, args []string) error {
 					"sprint_stats_count", len(trendStats.SprintStats),
 				)
 
-				fmt.Println(" done")
+				output.Println(" done")
 			}
 		} else {
-			fmt.Println("\n  ⚠️  No sprints found in bug data")
-			fmt.Println("  This could mean:")
-			fmt.Println("    - Bugs don't have sprint assignments")
-			fmt.Println("    - Sprint custom field ID is incorrect (currently using customfield_10020)")
-			fmt.Println("  Run with --debug to see raw field data")
+			output.Print(noSprintsMessage(cfg.Jira.CustomFieldIDs.Sprint))
 
 			slog.Debug("No sprints extracted",
+				"sprint_field_id", cfg.Jira.CustomFieldIDs.Sprint,
 				"bugs_checked", len(bugs),
 				"bugs_with_sprint_data", countBugsWithSprints(bugs),
 			)
@@ -206,11 +273,54 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display results
+	output.SetSparklineMonths(cfg.Stats.SparklineMonths)
+	output.SetSparklineMarkers(cfg.Stats.SparklineMarkers)
+	output.SetMarkPartialMonth(cfg.Stats.ShouldMarkPartialMonth())
+	output.SetWeightedBacklog(len(cfg.Stats.PriorityWeights) > 0)
 	output.DisplayTrendStats(trendStats)
 
+	// Component teams within one project get their own monthly tables
+	if statsGroupBy == "component" {
+		trends, err := componentTrends(analyzer, bugs)
+		if err != nil {
+			return err
+		}
+		output.DisplayComponentTrends(trends)
+	}
+
+	// Export monthly statistics if requested
+	if exportCSVPath != "" {
+		if err := output.WriteTrendStatsCSV(exportCSVPath, trendStats.MonthlyData); err != nil {
+			return err
+		}
+		output.Printf("\n💾 Monthly statistics written to %s\n", exportCSVPath)
+	}
+
+	if pdfPath != "" {
+		if err := output.WriteTrendStatsPDF(pdfPath, trendStats); err != nil {
+			return err
+		}
+		output.Printf("\n📄 PDF report written to %s\n", pdfPath)
+	}
+
 	return nil
 }
 
+// pdfReportPath returns the --output-file path when --format pdf is set, along with the
+// format for the terminal output (auto alongside a PDF, otherwise --format unchanged)
+func pdfReportPath() (string, string, error) {
+	if outputFormat != output.FormatPDF {
+		if outputFile != "" {
+			return "", "", fmt.Errorf("--output-file requires --format pdf")
+		}
+		return "", outputFormat, nil
+	}
+	if outputFile == "" {
+		return "", "", fmt.Errorf("--format pdf requires --output-file")
+	}
+	return outputFile, output.FormatAuto, nil
+}
+
 // countBugsWithSprints counts how many bugs have sprint data
 func countBugsWithSprints(bugs []*domain.Bug) int {
 	count := 0
@@ -222,11 +332,14 @@ func countBugsWithSprints(bugs []*domain.Bug) int {
 	return count
 }
 
+// promptReader is shared by the prompt helpers so input buffered by one prompt is not lost to the next
+// Tests replace it to script answers
+var promptReader = bufio.NewReader(os.Stdin)
+
 // promptYesNo prompts the user with a yes/no question and returns true for yes
 func promptYesNo(question string) bool {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("\n%s (y/n): ", question)
-	response, err := reader.ReadString('\n')
+	output.Printf("\n%s (y/n): ", question)
+	response, err := promptReader.ReadString('\n')
 	if err != nil {
 		return false
 	}
@@ -237,14 +350,13 @@ func promptYesNo(question string) bool {
 // promptChoice prompts the user to select from a list of options
 // Returns the 0-based index of the selected option, or -1 for invalid/empty input
 func promptChoice(question string, options []string) int {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("\n%s\n", question)
+	output.Printf("\n%s\n", question)
 	for i, option := range options {
-		fmt.Printf("  %d. %s\n", i+1, option)
+		output.Printf("  %d. %s\n", i+1, option)
 	}
-	fmt.Printf("\nEnter choice (1-%d): ", len(options))
+	output.Printf("\nEnter choice (1-%d): ", len(options))
 
-	response, err := reader.ReadString('\n')
+	response, err := promptReader.ReadString('\n')
 	if err != nil {
 		return -1
 	}
@@ -267,15 +379,23 @@ func promptChoice(question string, options []string) int {
 
 // promptString prompts the user for a string input
 func promptString(question string) string {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("\n%s: ", question)
-	response, err := reader.ReadString('\n')
+	output.Printf("\n%s: ", question)
+	response, err := promptReader.ReadString('\n')
 	if err != nil {
 		return ""
 	}
 	return strings.TrimSpace(response)
 }
 
+// noSprintsMessage explains why no sprints were found, naming the sprint field ID in use
+func noSprintsMessage(sprintFieldID string) string {
+	return "\n  ⚠️  No sprints found in bug data\n" +
+		"  This could mean:\n" +
+		"    - Bugs don't have sprint assignments\n" +
+		fmt.Sprintf("    - Sprint custom field ID is incorrect (currently using %s)\n", sprintFieldID) +
+		"  Run with --debug to see raw field data\n"
+}
+
 // sprintFilterConfig holds the interactive sprint filter configuration
 type sprintFilterConfig struct {
 	showSprints       bool
@@ -318,10 +438,10 @@ func getInteractiveSprintConfig(cfg *config.Config) sprintFilterConfig {
 	if choice == -1 {
 		// Default to first option (either config settings or no filtering)
 		if hasConfigFilters {
-			fmt.Println("⚠️  Invalid or empty input, defaulting to config file settings")
+			output.Println("⚠️  Invalid or empty input, defaulting to config file settings")
 			choice = 0
 		} else {
-			fmt.Println("⚠️  Invalid or empty input, defaulting to no filtering")
+			output.Println("⚠️  Invalid or empty input, defaulting to no filtering")
 			choice = 0
 		}
 	}
@@ -366,3 +486,21 @@ func getInteractiveSprintConfig(cfg *config.Config) sprintFilterConfig {
 
 	return filterCfg
 }
+
+// componentTrends analyzes the bugs of each component separately, most bugs first
+func componentTrends(analyzer *stats.Analyzer, bugs []*domain.Bug) ([]domain.ComponentTrend, error) {
+	groups := stats.GroupByComponent(bugs)
+	trends := make([]domain.ComponentTrend, 0, len(groups))
+	for _, group := range groups {
+		groupStats, err := analyzer.Analyze(group.Bugs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze component %q: %w", group.Component, err)
+		}
+		trends = append(trends, domain.ComponentTrend{
+			Component:   group.Component,
+			Bugs:        len(group.Bugs),
+			MonthlyData: groupStats.MonthlyData,
+		})
+	}
+	return trends, nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+	"github.com/neilmpatterson/bug-butler/internal/output"
+	"github.com/neilmpatterson/bug-butler/internal/stats"
+)
+
+func TestNoSprintsMessageUsesConfiguredField(t *testing.T) {
+	msg := noSprintsMessage("customfield_10005")
+	if !strings.Contains(msg, "currently using customfield_10005") {
+		t.Errorf("message doesn't name the configured field:\n%s", msg)
+	}
+	if strings.Contains(msg, "customfield_10020") {
+		t.Errorf("message still names the default field:\n%s", msg)
+	}
+}
+
+func TestPDFReportPathLeavesFormatFlag(t *testing.T) {
+	defer func(format, file string) { outputFormat, outputFile = format, file }(outputFormat, outputFile)
+
+	tests := []struct {
+		format, file string
+		wantPath     string
+		wantTerminal string
+		wantErr      bool
+	}{
+		{"pdf", "report.pdf", "report.pdf", output.FormatAuto, false},
+		{"plain", "", "", "plain", false},
+		{"", "", "", "", false},
+		{"pdf", "", "", "", true},
+		{"rich", "report.pdf", "", "", true},
+	}
+	for _, tt := range tests {
+		outputFormat, outputFile = tt.format, tt.file
+		path, terminal, err := pdfReportPath()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("pdfReportPath(%q, %q) error = %v, want error %v", tt.format, tt.file, err, tt.wantErr)
+			continue
+		}
+		if path != tt.wantPath || terminal != tt.wantTerminal {
+			t.Errorf("pdfReportPath(%q, %q) = %q, %q, want %q, %q", tt.format, tt.file, path, terminal, tt.wantPath, tt.wantTerminal)
+		}
+		// The flag is left alone, so a later run in the same process still sees --format pdf
+		if outputFormat != tt.format {
+			t.Errorf("pdfReportPath(%q, %q) changed --format to %q", tt.format, tt.file, outputFormat)
+		}
+	}
+}
+
+func TestComponentTrends(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := thisMonth.AddDate(0, -1, 0)
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", Priority: "High", Components: []string{"Checkout"}, Created: lastMonth.AddDate(0, 0, 2)},
+		{Key: "DEMO-2", Priority: "High", Components: []string{"Checkout", "Search"}, Created: thisMonth},
+		{Key: "DEMO-3", Priority: "High", Components: []string{"Checkout"}, Created: thisMonth},
+		{Key: "DEMO-4", Priority: "High", Created: lastMonth.AddDate(0, 0, 3)},
+	}
+
+	trends, err := componentTrends(stats.NewAnalyzer(10, 6), bugs)
+	if err != nil {
+		t.Fatalf("componentTrends: %v", err)
+	}
+
+	// created sums each component's monthly created counts by month
+	created := func(trend domain.ComponentTrend) map[time.Time]int {
+		counts := make(map[time.Time]int)
+		for _, m := range trend.MonthlyData {
+			counts[m.Month] += m.TotalCreated
+		}
+		return counts
+	}
+	want := []struct {
+		component string
+		bugs      int
+		created   map[time.Time]int
+	}{
+		{"Checkout", 3, map[time.Time]int{lastMonth: 1, thisMonth: 2}},
+		{"Search", 1, map[time.Time]int{thisMonth: 1}},
+		{"", 1, map[time.Time]int{lastMonth: 1}},
+	}
+	if len(trends) != len(want) {
+		t.Fatalf("got %d component trends, want %d", len(trends), len(want))
+	}
+	for i, w := range want {
+		if trends[i].Component != w.component || trends[i].Bugs != w.bugs {
+			t.Errorf("trend %d = %q with %d bugs, want %q with %d", i, trends[i].Component, trends[i].Bugs, w.component, w.bugs)
+		}
+		if got := created(trends[i]); !maps.Equal(got, w.created) {
+			t.Errorf("%q created by month = %v, want %v", w.component, got, w.created)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+	"github.com/neilmpatterson/bug-butler/internal/output"
+	"github.com/neilmpatterson/bug-butler/internal/snooze"
+)
+
+// runWhatIf lets the user adjust rule thresholds and re-evaluates the already fetched bugs in memory
+func runWhatIf(cfg *config.Config, bugs []*domain.Bug, snoozes snooze.List, workingHours *domain.WorkingHours, baseline *domain.BucketGroup) {
+	rules := whatIfRules(cfg.SLARules)
+
+	output.Println("\n🧪 What-if mode: adjust rule thresholds and see the effect without re-fetching")
+	baselineTotal := totalViolations(baseline)
+	printWhatIfCounts(baseline, baselineTotal)
+
+	for {
+		options := make([]string, 0, len(rules)+1)
+		for _, rule := range rules {
+			options = append(options, fmt.Sprintf("%s (max age: %s)", rule.Name, formatRuleThreshold(rule)))
+		}
+		options = append(options, "Done")
+
+		choice := promptChoice("Select a rule to adjust:", options)
+		if choice < 0 || choice == len(rules) {
+			return
+		}
+
+		// Tiered rules adjust one escalation tier at a time
+		rule := &rules[choice]
+		threshold := &rule.MaxAgeDays
+		name := rule.Name
+		if len(rule.Tiers) > 0 {
+			tierOptions := make([]string, len(rule.Tiers))
+			for i, tier := range rule.Tiers {
+				tierOptions[i] = fmt.Sprintf("%s (max age: %s days)", tier.Bucket, strconv.FormatFloat(tier.MaxAgeDays, 'f', -1, 64))
+			}
+			tier := promptChoice("Select a tier to adjust:", tierOptions)
+			if tier < 0 {
+				continue
+			}
+			threshold = &rule.Tiers[tier].MaxAgeDays
+			name = fmt.Sprintf("%s → %s", rule.Name, rule.Tiers[tier].Bucket)
+		}
+
+		value := promptString(fmt.Sprintf("New max age in days for %q", name))
+		days, err := strconv.ParseFloat(value, 64)
+		if err != nil || days < 0 {
+			output.Printf("Invalid number of days: %q\n", value)
+			continue
+		}
+		*threshold = days
+
+		printWhatIfCounts(newEvaluator(cfg, rules, snoozes, workingHours).Evaluate(bugs), baselineTotal)
+	}
+}
+
+// whatIfRules copies the enabled rules, including their tiers, so adjusting them leaves the configuration untouched
+func whatIfRules(configured []config.SLARule) []config.SLARule {
+	rules := make([]config.SLARule, 0, len(configured))
+	for _, rule := range configured {
+		if rule.IsEnabled() {
+			rule.Tiers = slices.Clone(rule.Tiers)
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// printWhatIfCounts prints violation counts per rule and bucket, with the change from the baseline total
+func printWhatIfCounts(bucketGroup *domain.BucketGroup, baselineTotal int) {
+	total := totalViolations(bucketGroup)
+	output.Printf("\nTotal violations: %d (%+d vs configured rules)\n", total, total-baselineTotal)
+
+	byRule := make(map[string]int)
+	var ruleNames []string
+	for _, bucket := range bucketGroup.Buckets {
+		output.Printf("  %s: %d bugs\n", bucket.Name, len(bucket.Bugs))
+		for _, bug := range bucket.Bugs {
+			if bug.Violation == nil {
+				continue
+			}
+			if byRule[bug.Violation.RuleName] == 0 {
+				ruleNames = append(ruleNames, bug.Violation.RuleName)
+			}
+			byRule[bug.Violation.RuleName]++
+		}
+	}
+
+	if len(ruleNames) > 0 {
+		output.Println("By rule:")
+		for _, name := range ruleNames {
+			output.Printf("  %s: %d bugs\n", name, byRule[name])
+		}
+	}
+}
+
+// totalViolations counts the bugs across all buckets
+func totalViolations(bucketGroup *domain.BucketGroup) int {
+	total := 0
+	for _, bucket := range bucketGroup.Buckets {
+		total += len(bucket.Bugs)
+	}
+	return total
+}
+
+// formatRuleThreshold describes a rule's threshold for the rule picker
+func formatRuleThreshold(rule config.SLARule) string {
+	if len(rule.Tiers) > 0 {
+		thresholds := make([]string, len(rule.Tiers))
+		for i, tier := range rule.Tiers {
+			thresholds[i] = strconv.FormatFloat(tier.MaxAgeDays, 'f', -1, 64)
+		}
+		return strings.Join(thresholds, " → ") + " days"
+	}
+	return strconv.FormatFloat(rule.MaxAgeDays, 'f', -1, 64) + " days"
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestWhatIfReevaluatesAdjustedTiers(t *testing.T) {
+	cfg := &config.Config{SLARules: []config.SLARule{{
+		Name:     "high",
+		Priority: "High",
+		Tiers: []config.SLATier{
+			{MaxAgeDays: 7, Bucket: "🟠 HIGH", Severity: 2},
+			{MaxAgeDays: 14, Bucket: "🔥 ESCALATED", Severity: 1},
+		},
+	}}}
+	bugs := []*domain.Bug{
+		{Key: "A-1", Priority: "High", Updated: time.Now().AddDate(0, 0, -10)},
+		{Key: "A-2", Priority: "High", Updated: time.Now().AddDate(0, 0, -3)},
+	}
+
+	counts := func(rules []config.SLARule) map[string]int {
+		result := make(map[string]int)
+		for _, bucket := range newEvaluator(cfg, rules, nil, nil).Evaluate(bugs).Buckets {
+			result[bucket.Name] = len(bucket.Bugs)
+		}
+		return result
+	}
+
+	rules := whatIfRules(cfg.SLARules)
+	if got := counts(rules); got["🟠 HIGH"] != 1 || got["🔥 ESCALATED"] != 0 {
+		t.Errorf("configured thresholds: buckets = %v, want A-1 in HIGH", got)
+	}
+
+	// Lowering the escalation tier moves A-1 up; lowering the first tier catches A-2
+	rules[0].Tiers[1].MaxAgeDays = 9
+	rules[0].Tiers[0].MaxAgeDays = 2
+	if got := counts(rules); got["🟠 HIGH"] != 1 || got["🔥 ESCALATED"] != 1 {
+		t.Errorf("adjusted thresholds: buckets = %v, want A-2 in HIGH and A-1 in ESCALATED", got)
+	}
+
+	if cfg.SLARules[0].Tiers[0].MaxAgeDays != 7 || cfg.SLARules[0].Tiers[1].MaxAgeDays != 14 {
+		t.Errorf("configured tiers changed to %v", cfg.SLARules[0].Tiers)
+	}
+}
+
+func TestWhatIfRulesSkipsDisabled(t *testing.T) {
+	disabled := false
+	rules := whatIfRules([]config.SLARule{
+		{Name: "critical", MaxAgeDays: 1},
+		{Name: "legacy", MaxAgeDays: 30, Enabled: &disabled},
+	})
+	if len(rules) != 1 || rules[0].Name != "critical" {
+		t.Errorf("whatIfRules = %v, want only the enabled rule", rules)
+	}
+}
+
+func TestFormatRuleThreshold(t *testing.T) {
+	tiered := config.SLARule{Tiers: []config.SLATier{{MaxAgeDays: 7}, {MaxAgeDays: 14.5}}}
+	if got, want := formatRuleThreshold(tiered), "7 → 14.5 days"; got != want {
+		t.Errorf("formatRuleThreshold(tiered) = %q, want %q", got, want)
+	}
+	if got, want := formatRuleThreshold(config.SLARule{MaxAgeDays: 3}), "3 days"; got != want {
+		t.Errorf("formatRuleThreshold = %q, want %q", got, want)
+	}
+}
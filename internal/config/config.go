@@ -2,10 +2,18 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml/v2"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
@@ -14,54 +22,303 @@ import (
 
 // Config represents the complete application configuration
 type Config struct {
-	Jira     JiraConfig  `koanf:"jira"`
-	SLARules []SLARule   `koanf:"sla_rules"`
-	Stats    StatsConfig `koanf:"stats"`
+	Jira          JiraConfig          `koanf:"jira"`
+	JiraInstances []JiraConfig        `koanf:"jira_instances"` // Additional Jira connections aggregated by check
+	SLARules      []SLARule           `koanf:"sla_rules"`
+	DataQuality   DataQualityConfig   `koanf:"data_quality"`
+	Check         CheckConfig         `koanf:"check"`
+	Stats         StatsConfig         `koanf:"stats"`
+	Output        OutputConfig        `koanf:"output"`
+	Notify        NotifyConfig        `koanf:"notify"`
+	Tags          []TagQuery          `koanf:"tags"`          // Named JQL queries whose matching bugs are tagged in the check report
+	LabelBuckets  []LabelBucket       `koanf:"label_buckets"` // Route bugs with a label to a bucket regardless of SLA rules
+	WorkingHours  WorkingHoursConfig  `koanf:"working_hours"` // Working-hours clock for rules with clock: working_hours
+	StatusPhases  map[string][]string `koanf:"status_phases"` // Phase names mapped to statuses for the backlog phase breakdown (unmapped = Other)
 }
 
 // JiraConfig holds Jira connection settings
 type JiraConfig struct {
-	BaseURL         string       `koanf:"base_url"`
-	Email           string       `koanf:"email"`
-	APIToken        string       `koanf:"api_token"`
-	ProjectKeys     []string     `koanf:"project_keys"` // Support multiple projects
-	ProjectKey      string       `koanf:"project_key"`  // Deprecated: kept for backward compatibility
-	AdditionalJQL   string       `koanf:"additional_jql"` // Optional additional JQL filters to append to queries
-	CustomFieldIDs  CustomFields `koanf:"custom_fields"`  // Custom field ID mappings for this Jira instance
+	Name                 string            `koanf:"name"` // Display name for this instance (default: base URL host)
+	BaseURL              string            `koanf:"base_url"`
+	Email                string            `koanf:"email"`
+	APIToken             string            `koanf:"api_token"`
+	ProjectKeys          []string          `koanf:"project_keys"`            // Support multiple projects
+	ProjectKey           string            `koanf:"project_key"`             // Deprecated: kept for backward compatibility
+	AdditionalJQL        string            `koanf:"additional_jql"`          // Optional additional JQL filters to append to queries
+	CustomFieldIDs       CustomFields      `koanf:"custom_fields"`           // Custom field ID mappings for this Jira instance
+	BugIssueTypes        []string          `koanf:"bug_issue_types"`         // Issue types counted as bugs (e.g., "Bug", "Defect")
+	RequestsPerSecond    float64           `koanf:"requests_per_second"`     // Max outbound API requests per second (0 = unlimited)
+	PageDelayMS          int               `koanf:"page_delay_ms"`           // Pause between the pages of a search, in milliseconds (0 = none)
+	SearchPath           string            `koanf:"search_path"`             // API path for JQL searches, e.g., on a fixture server (default: /rest/api/3/search/jql)
+	RateLimitWarning     int               `koanf:"rate_limit_warning"`      // Log a warning when X-RateLimit-Remaining drops below this (0 = disabled)
+	PriorityFallback     string            `koanf:"priority_fallback_field"` // Custom field ID read as priority when the standard priority is unset
+	PriorityAliases      map[string]string `koanf:"priority_aliases"`        // Priority names mapped to a canonical name (e.g., Critical: Highest)
+	ResolutionDateField  string            `koanf:"resolution_date_field"`   // Custom field ID read as the resolution date in preference to resolutiondate
+	ExtraFields          []string          `koanf:"extra_fields"`            // Additional custom field IDs to fetch into Bug.CustomFields (for JSON dumps)
+	ImpersonateAccountID string            `koanf:"impersonate_account_id"`  // Optional Atlassian account ID to act as on every request
+	ExtraHeaders         map[string]string `koanf:"extra_headers"`           // Headers added to every request (e.g., a gateway token; values support ${VAR})
 }
 
 // CustomFields holds custom field ID mappings that vary by Jira instance
 type CustomFields struct {
-	Sprint      string `koanf:"sprint"`       // Sprint field ID (e.g., "customfield_10005")
-	StoryPoints string `koanf:"story_points"` // Story Points field ID (e.g., "customfield_10002")
+	Sprint        string `koanf:"sprint"`         // Sprint field ID (e.g., "customfield_10005")
+	StoryPoints   string `koanf:"story_points"`   // Story Points field ID (e.g., "customfield_10002")
+	FirstResponse string `koanf:"first_response"` // First response date field ID (optional, for first_response SLAs)
+	EpicLink      string `koanf:"epic_link"`      // Epic Link field ID (optional, enables the Bugs by Epic table)
 }
 
 // SLARule defines a threshold for bug age based on priority and status
 type SLARule struct {
-	Name       string   `koanf:"name"`
-	Priority   string   `koanf:"priority"`
-	Status     []string `koanf:"status"`
-	MaxAgeDays float64  `koanf:"max_age_days"`
-	Bucket     string   `koanf:"bucket"`
-	Severity   int      `koanf:"severity"`
+	Name       string    `koanf:"name"`
+	Priority   string    `koanf:"priority"`
+	Status     []string  `koanf:"status"`
+	MaxAgeDays float64   `koanf:"max_age_days"`
+	Bucket     string    `koanf:"bucket"`
+	Severity   int       `koanf:"severity"`
+	FixVersion []string  `koanf:"fix_version"`
+	Tiers      []SLATier `koanf:"tiers"`    // Optional escalation tiers (replaces max_age_days/bucket/severity)
+	Enabled    *bool     `koanf:"enabled"`  // Optional toggle (default: true)
+	AgeFrom    string    `koanf:"age_from"` // What the age is measured from: updated (default), created, first_response
+	Clock      string    `koanf:"clock"`    // How age accrues: calendar (default) or working_hours (ages in working days)
 }
 
+// SLA rule clocks
+const (
+	ClockCalendar     = "calendar"      // Age accrues around the clock
+	ClockWorkingHours = "working_hours" // Age accrues only within working_hours, in working days
+)
+
+// UsesWorkingHours reports whether any enabled SLA rule measures age on the working-hours clock
+func (c *Config) UsesWorkingHours() bool {
+	for _, rule := range c.SLARules {
+		if rule.IsEnabled() && rule.Clock == ClockWorkingHours {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEnabled reports whether the rule is enabled (rules are enabled unless explicitly disabled)
+func (r SLARule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// SLATier defines one escalation step of an SLA rule
+type SLATier struct {
+	MaxAgeDays float64 `koanf:"max_age_days"`
+	Bucket     string  `koanf:"bucket"`
+	Severity   int     `koanf:"severity"`
+}
+
+// DataQualityConfig flags bugs missing required fields into a dedicated bucket
+type DataQualityConfig struct {
+	RequiredFields []string `koanf:"required_fields"` // Fields every bug must have (priority, assignee, sprint, story_points, fix_version)
+	Bucket         string   `koanf:"bucket"`          // Bucket for bugs missing data (default: "🟣 NEEDS DATA")
+	Severity       int      `koanf:"severity"`        // Bucket display priority (default: 4)
+}
+
+// LabelBucket routes bugs carrying a Jira label to a dedicated bucket, ahead of SLA rules
+type LabelBucket struct {
+	Label    string `koanf:"label"`    // Jira label to match (case-insensitive)
+	Bucket   string `koanf:"bucket"`   // Bucket for bugs with the label
+	Severity int    `koanf:"severity"` // Bucket display priority (default: 0, above severity 1 rules)
+}
+
+// supportedRequiredFields are the field names accepted in data_quality.required_fields
+var supportedRequiredFields = []string{"priority", "assignee", "sprint", "story_points", "fix_version"}
+
+// CheckConfig holds configuration for the SLA check report
+type CheckConfig struct {
+	MaxDisplayAgeDays    float64  `koanf:"max_display_age_days"`   // Ages beyond this display as ">N" (0 = no cap)
+	MinBucketSize        int      `koanf:"min_bucket_size"`        // List buckets with fewer bugs as one line each instead of a table (0 = show all tables)
+	IgnoreOlderThanDays  float64  `koanf:"ignore_older_than_days"` // Skip bugs older than this during evaluation (0 = disabled)
+	DuplicateThreshold   *float64 `koanf:"duplicate_threshold"`    // Summary similarity (0-1) for --detect-duplicates (default: 0.6)
+	AtRiskPercent        float64  `koanf:"at_risk_percent"`        // Flag compliant bugs within this % of their threshold (0 = disabled)
+	ThrashingComments    int      `koanf:"thrashing_comments"`     // Flag bugs with at least this many comments as possibly thrashing (0 = disabled)
+	SnoozeFile           string   `koanf:"snooze_file"`            // JSON file of snoozed bug keys written by 'bug-butler snooze' (default: bug-butler-snoozes.json)
+	ActiveParentStatuses []string `koanf:"active_parent_statuses"` // Don't flag bugs whose parent issue is in one of these statuses (e.g., In Progress)
+}
+
+// DefaultDuplicateThreshold is the summary similarity for --detect-duplicates when check.duplicate_threshold is unset
+const DefaultDuplicateThreshold = 0.6
+
+// DuplicateSimilarity returns the configured duplicate threshold, or the default if unset
+// An explicit 0 is kept rather than replaced by the default
+func (c CheckConfig) DuplicateSimilarity() float64 {
+	if c.DuplicateThreshold == nil {
+		return DefaultDuplicateThreshold
+	}
+	return *c.DuplicateThreshold
+}
+
+// WorkingHoursConfig defines the working-hours clock: a daily window on working days, minus holidays
+type WorkingHoursConfig struct {
+	Start        string   `koanf:"start"`         // Start of the working day as HH:MM (default: "09:00")
+	End          string   `koanf:"end"`           // End of the working day as HH:MM (default: "17:00")
+	Timezone     string   `koanf:"timezone"`      // IANA time zone the window applies in (default: UTC)
+	Days         []string `koanf:"days"`          // Working weekdays as mon..sun (default: mon-fri)
+	HolidaysFile string   `koanf:"holidays_file"` // File of non-working dates, one YYYY-MM-DD per line (optional)
+}
+
+// Window returns the working day's start and end as minutes after midnight
+func (w WorkingHoursConfig) Window() (start, end int, err error) {
+	if start, err = parseClockTime(w.Start); err != nil {
+		return 0, 0, fmt.Errorf("working_hours.start: %w", err)
+	}
+	if end, err = parseClockTime(w.End); err != nil {
+		return 0, 0, fmt.Errorf("working_hours.end: %w", err)
+	}
+	if end <= start {
+		return 0, 0, fmt.Errorf("working_hours.end must be after working_hours.start")
+	}
+	return start, end, nil
+}
+
+// Weekdays returns the configured working weekdays
+func (w WorkingHoursConfig) Weekdays() ([]time.Weekday, error) {
+	var days []time.Weekday
+	for i, name := range w.Days {
+		day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("working_hours.days[%d] must be one of: mon, tue, wed, thu, fri, sat, sun", i)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// Location returns the time zone the working window applies in
+func (w WorkingHoursConfig) Location() (*time.Location, error) {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("working_hours.timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// weekdayNames maps the accepted working_hours.days values to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+}
+
+// parseClockTime parses an HH:MM time of day into minutes after midnight ("24:00" ends the day)
+func parseClockTime(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// OutputConfig holds configuration for report rendering
+type OutputConfig struct {
+	Locale      string `koanf:"locale"`       // Locale for dates and numbers (e.g., "en-US", "de-DE"); default "en-US"
+	DateFormat  string `koanf:"date_format"`  // Go layout or preset ("iso") for full dates; empty uses the locale's
+	MonthFormat string `koanf:"month_format"` // Go layout for month labels; empty uses the date_format preset's or the locale's
+}
+
+// TagQuery tags bugs matched by a JQL query (e.g., "customer-impacting")
+type TagQuery struct {
+	Name string `koanf:"name"` // Tag shown in the report
+	JQL  string `koanf:"jql"`  // JQL condition, combined with the configured projects and bug types
+}
+
+// NotifyConfig holds settings for posting the check report to external destinations
+type NotifyConfig struct {
+	GitHub       GitHubNotifyConfig `koanf:"github"`
+	MaxBugs      int                `koanf:"max_bugs"`      // List only this many of the most overdue bugs, plus "and X more" lines (0 = all)
+	SummaryWidth int                `koanf:"summary_width"` // Truncate bug summaries in notifications to this many characters (0 = full summaries)
+	Routes       []NotifyRoute      `koanf:"routes"`        // Extra destinations that receive only some buckets (e.g., urgent to on-call)
+}
+
+// NotifyRoute posts the buckets matching its names or severities to its own destination
+type NotifyRoute struct {
+	Buckets    []string           `koanf:"buckets"`    // Bucket names routed here (case-insensitive, emoji optional)
+	Severities []int              `koanf:"severities"` // Bucket severities routed here
+	GitHub     GitHubNotifyConfig `koanf:"github"`
+}
+
+// GitHubNotifyConfig posts the report as a comment on a GitHub issue
+type GitHubNotifyConfig struct {
+	Repo   string `koanf:"repo"`    // Repository as "owner/name" (empty = disabled)
+	Issue  int    `koanf:"issue"`   // Issue number to comment on
+	Token  string `koanf:"token"`   // GitHub token (supports ${VAR} interpolation)
+	APIURL string `koanf:"api_url"` // API base URL (default: https://api.github.com)
+}
+
+// validate checks a GitHub destination with its repo set
+func (g GitHubNotifyConfig) validate(prefix string) error {
+	if !strings.Contains(g.Repo, "/") {
+		return fmt.Errorf("%s.repo must be in owner/name form", prefix)
+	}
+	if g.Issue < 1 {
+		return fmt.Errorf("%s.issue is required when %s.repo is set", prefix, prefix)
+	}
+	return nil
+}
+
+// GoalConfig holds settings for the reduction goal comparison
+type GoalConfig struct {
+	ComparisonMode        string `koanf:"comparison_mode"`         // calendar_month (default), trailing_30_days, or prorated
+	Rounding              string `koanf:"rounding"`                // Goal target rounding: nearest (default), floor, or ceil
+	BaselineOffsetsMonths []int  `koanf:"baseline_offsets_months"` // Months back to each baseline, one goal each (e.g., [12, 3] for YoY and QoQ); default [12]
+}
+
+// supportedGoalModes are the accepted stats.goal.comparison_mode values
+var supportedGoalModes = []string{"calendar_month", "trailing_30_days", "prorated"}
+
+// supportedGoalRoundings are the accepted stats.goal.rounding values
+var supportedGoalRoundings = []string{"nearest", "floor", "ceil"}
+
+// supportedSprintStates are the Jira sprint states accepted by stats.sprint_states
+var supportedSprintStates = []string{"active", "closed", "future"}
+
+// supportedSprintSorts are the accepted stats.sprint_sort_by values
+var supportedSprintSorts = []string{"name", "bug_percent", "start_date"}
+
 // StatsConfig holds configuration for bug trend statistics
 type StatsConfig struct {
-	ReductionGoalPercent   float64 `koanf:"reduction_goal_percent"`
-	MonthsToAnalyze        int     `koanf:"months_to_analyze"`
-	ShowSprints            bool    `koanf:"show_sprints"`
-	SprintNameBeginsWith   string  `koanf:"sprint_name_begins_with"` // Simple prefix filter (e.g., "TOOLS Sprint")
-	SprintNamePattern      string  `koanf:"sprint_name_pattern"`     // Advanced regex pattern (overrides begins_with)
-	SprintBoardFilter      string  `koanf:"sprint_board_filter"`     // JQL filter to match board's filter (e.g., from board settings)
+	ReductionGoalPercent float64            `koanf:"reduction_goal_percent"`
+	PriorityGoals        map[string]float64 `koanf:"priority_goals"` // Per-priority reduction goal percentages (e.g., Critical: 50), tracked alongside the overall goal
+	MonthsToAnalyze      int                `koanf:"months_to_analyze"`
+	SparklineMonths      int                `koanf:"sparkline_months"`            // Limit the backlog sparkline to the last N months (0 = all analyzed months)
+	TrimLeadingMonths    int                `koanf:"trim_leading_months"`         // Drop the earliest N fetched months, whose counts lack earlier history, from the report (0 = keep all)
+	SparklineMarkers     string             `koanf:"sparkline_markers"`           // Months annotated under the backlog sparkline: "endpoints" (first/middle/last, default) or "quarterly"
+	PriorityWeights      map[string]float64 `koanf:"priority_weights"`            // Weight unresolved bugs by priority in the backlog sparkline (unlisted priorities weigh 1)
+	FutureDatedBugs      string             `koanf:"future_dated_bugs"`           // Month grouping for bugs created in the future: "current_month" (default) or "exclude"
+	MinLifetimeMinutes   float64            `koanf:"min_lifetime_minutes"`        // Leave out bugs resolved within this many minutes of creation (0 = include all)
+	IgnoredResolutions   []string           `koanf:"ignored_resolutions"`         // Resolutions counted as unresolved in the backlog and resolved counts (e.g., Cannot Reproduce)
+	ResolvedStatuses     []string           `koanf:"resolved_statuses"`           // Statuses counted as resolved in the backlog trend even without a resolution (e.g., Done)
+	ZeroResolvedAlertAvg float64            `koanf:"zero_resolved_alert_average"` // Warn about a month with zero resolved when the prior 3 months averaged at least this many (0 = disabled)
+	MarkPartialMonth     *bool              `koanf:"mark_partial_month"`          // Label the in-progress month "(partial)" and leave it out of trend arrows (default: true)
+	ShowSprints          bool               `koanf:"show_sprints"`
+	SprintNameBeginsWith string             `koanf:"sprint_name_begins_with"`     // Simple prefix filter (e.g., "TOOLS Sprint")
+	SprintNamePattern    string             `koanf:"sprint_name_pattern"`         // Advanced regex pattern (overrides begins_with)
+	SprintBoardFilter    string             `koanf:"sprint_board_filter"`         // JQL filter to match board's filter (e.g., from board settings)
+	SprintStates         []string           `koanf:"sprint_states"`               // Only include sprints in these states: active, closed, future (empty = all)
+	SprintMinIssues      int                `koanf:"sprint_min_issues"`           // Exclude sprints with fewer total issues from sprint stats (0 = include all)
+	SprintDefaultPoints  float64            `koanf:"sprint_default_story_points"` // Story points assumed for sprint issues without an estimate (0 = leave them out of points percentages)
+	SprintBatchSize      int                `koanf:"sprint_batch_size"`           // Sprint IDs per sprint issue query, to stay under JQL length limits (default: 50)
+	SprintConcurrency    int                `koanf:"sprint_concurrency"`          // Max sprint issue queries in flight at once (default: 4)
+	SprintSortBy         string             `koanf:"sprint_sort_by"`              // Sprint stats order: name (natural, default), bug_percent, or start_date
+	Goal                 GoalConfig         `koanf:"goal"`
+}
+
+// ShouldMarkPartialMonth reports whether the in-progress month is labeled as partial (the default)
+func (s StatsConfig) ShouldMarkPartialMonth() bool {
+	return s.MarkPartialMonth == nil || *s.MarkPartialMonth
 }
 
-// Load reads configuration from a YAML file and environment variables
+// Load reads configuration from a YAML, JSON, or TOML file and environment variables
 func Load(configPath string) (*Config, error) {
 	k := koanf.New(".")
 
 	// Load from config file
-	if err := k.Load(file.Provider(configPath), yaml.Parser()); err != nil {
+	if err := k.Load(file.Provider(configPath), parserForPath(configPath)); err != nil {
 		return nil, fmt.Errorf("failed to load config file: %w", err)
 	}
 
@@ -80,10 +337,11 @@ func Load(configPath string) (*Config, error) {
 
 	// Interpolate environment variables in config values
 	if err := interpolateEnvVars(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to interpolate environment variables: %w", err)
+		return nil, fmt.Errorf("failed to interpolate secrets: %w", err)
 	}
 
-	// Set defaults for stats config if not provided
+	// Set defaults for check and stats config if not provided
+	cfg.setCheckDefaults()
 	cfg.setStatsDefaults()
 
 	// Validate configuration
@@ -94,61 +352,431 @@ func Load(configPath string) (*Config, error) {
 	return &cfg, nil
 }
 
+// parserForPath selects the config parser based on the file extension
+func parserForPath(configPath string) koanf.Parser {
+	switch strings.ToLower(filepath.Ext(configPath)) {
+	case ".yaml", ".yml":
+		return yaml.Parser()
+	case ".json":
+		return json.Parser()
+	case ".toml":
+		return toml.Parser()
+	default:
+		slog.Warn("Unknown config file extension, assuming YAML", "path", configPath)
+		return yaml.Parser()
+	}
+}
+
+// setCheckDefaults sets default values for check configuration if not provided
+func (c *Config) setCheckDefaults() {
+	if c.DataQuality.Bucket == "" {
+		c.DataQuality.Bucket = "🟣 NEEDS DATA"
+	}
+	if c.DataQuality.Severity == 0 {
+		c.DataQuality.Severity = 4
+	}
+	if c.Check.SnoozeFile == "" {
+		c.Check.SnoozeFile = "bug-butler-snoozes.json"
+	}
+	if c.WorkingHours.Start == "" {
+		c.WorkingHours.Start = "09:00"
+	}
+	if c.WorkingHours.End == "" {
+		c.WorkingHours.End = "17:00"
+	}
+	if c.WorkingHours.Timezone == "" {
+		c.WorkingHours.Timezone = "UTC"
+	}
+	if len(c.WorkingHours.Days) == 0 {
+		c.WorkingHours.Days = []string{"mon", "tue", "wed", "thu", "fri"}
+	}
+}
+
+// DefaultMonthsToAnalyze is the stats trend period when stats.months_to_analyze is unset
+const DefaultMonthsToAnalyze = 24
+
 // setStatsDefaults sets default values for stats configuration if not provided
 func (c *Config) setStatsDefaults() {
 	if c.Stats.ReductionGoalPercent == 0 {
 		c.Stats.ReductionGoalPercent = 10.0
 	}
 	if c.Stats.MonthsToAnalyze == 0 {
-		c.Stats.MonthsToAnalyze = 24
+		c.Stats.MonthsToAnalyze = DefaultMonthsToAnalyze
+	}
+	if c.Stats.Goal.ComparisonMode == "" {
+		c.Stats.Goal.ComparisonMode = "calendar_month"
+	}
+	if c.Stats.SprintBatchSize == 0 {
+		c.Stats.SprintBatchSize = 50
+	}
+	if c.Stats.SprintConcurrency == 0 {
+		c.Stats.SprintConcurrency = 4
+	}
+	if c.Stats.SprintSortBy == "" {
+		c.Stats.SprintSortBy = "name"
+	}
+	if c.Stats.Goal.Rounding == "" {
+		c.Stats.Goal.Rounding = "nearest"
+	}
+	if len(c.Stats.Goal.BaselineOffsetsMonths) == 0 {
+		c.Stats.Goal.BaselineOffsetsMonths = []int{12}
+	}
+	if c.Stats.SparklineMarkers == "" {
+		c.Stats.SparklineMarkers = "endpoints"
+	}
+	if c.Stats.FutureDatedBugs == "" {
+		c.Stats.FutureDatedBugs = "current_month"
 	}
 }
 
 // interpolateEnvVars replaces ${VAR} patterns with environment variable values
 func interpolateEnvVars(cfg *Config) error {
-	re := regexp.MustCompile(`\$\{([^}]+)\}`)
+	// Interpolate secrets
+	secrets := []*string{&cfg.Jira.APIToken, &cfg.Notify.GitHub.Token}
+	for i := range cfg.JiraInstances {
+		secrets = append(secrets, &cfg.JiraInstances[i].APIToken)
+	}
+	for i := range cfg.Notify.Routes {
+		secrets = append(secrets, &cfg.Notify.Routes[i].GitHub.Token)
+	}
+	for _, value := range secrets {
+		interpolated, err := interpolateValue(*value)
+		if err != nil {
+			return err
+		}
+		*value = interpolated
+	}
 
-	// Interpolate API token
-	if matches := re.FindStringSubmatch(cfg.Jira.APIToken); len(matches) > 1 {
-		envVar := matches[1]
-		value := os.Getenv(envVar)
-		if value == "" {
-			return fmt.Errorf("environment variable %s is not set", envVar)
+	// Header values often carry gateway tokens, so they're interpolated too
+	for _, conn := range append([]*JiraConfig{&cfg.Jira}, instancePointers(cfg.JiraInstances)...) {
+		for name, value := range conn.ExtraHeaders {
+			interpolated, err := interpolateValue(value)
+			if err != nil {
+				return fmt.Errorf("header %s: %w", name, err)
+			}
+			conn.ExtraHeaders[name] = interpolated
 		}
-		cfg.Jira.APIToken = value
 	}
 
 	return nil
 }
 
-// Validate checks that all required configuration fields are present and valid
-func (c *Config) Validate() error {
-	// Validate Jira config
-	if c.Jira.BaseURL == "" {
-		return fmt.Errorf("jira.base_url is required")
+// instancePointers returns pointers to each Jira instance config, for in-place updates
+func instancePointers(instances []JiraConfig) []*JiraConfig {
+	pointers := make([]*JiraConfig, len(instances))
+	for i := range instances {
+		pointers[i] = &instances[i]
+	}
+	return pointers
+}
+
+// envVarPattern matches ${VAR} and ${file:/path} references in config values
+var envVarPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// fileRefPrefix marks a ${file:/path} reference to a secret mounted as a file
+const fileRefPrefix = "file:"
+
+// interpolateValue replaces a ${VAR} reference with the environment variable's value,
+// or a ${file:/path} reference with the file's contents (surrounding whitespace trimmed)
+func interpolateValue(value string) (string, error) {
+	matches := envVarPattern.FindStringSubmatch(value)
+	if len(matches) < 2 {
+		return value, nil
+	}
+
+	if path, ok := strings.CutPrefix(matches[1], fileRefPrefix); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		secret := strings.TrimSpace(string(data))
+		if secret == "" {
+			return "", fmt.Errorf("secret file %s is empty", path)
+		}
+		return secret, nil
+	}
+
+	envVar := matches[1]
+	envValue := os.Getenv(envVar)
+	if envValue == "" {
+		return "", fmt.Errorf("environment variable %s is not set", envVar)
 	}
-	if c.Jira.Email == "" {
-		return fmt.Errorf("jira.email is required")
+	return envValue, nil
+}
+
+// validate checks a Jira connection's required fields and applies its defaults
+// The prefix names the connection's config path in error messages
+func (j *JiraConfig) validate(prefix string) error {
+	if j.BaseURL == "" {
+		return fmt.Errorf("%s.base_url is required", prefix)
 	}
-	if c.Jira.APIToken == "" {
-		return fmt.Errorf("jira.api_token is required")
+	if j.Email == "" {
+		return fmt.Errorf("%s.email is required", prefix)
+	}
+	if j.APIToken == "" {
+		return fmt.Errorf("%s.api_token is required", prefix)
 	}
 	// Support both project_key (single, deprecated) and project_keys (multiple)
-	if len(c.Jira.ProjectKeys) == 0 && c.Jira.ProjectKey == "" {
-		return fmt.Errorf("either jira.project_keys or jira.project_key is required")
+	if len(j.ProjectKeys) == 0 && j.ProjectKey == "" {
+		return fmt.Errorf("either %s.project_keys or %s.project_key is required", prefix, prefix)
 	}
 
 	// If old project_key is used, migrate it to project_keys
-	if c.Jira.ProjectKey != "" && len(c.Jira.ProjectKeys) == 0 {
-		c.Jira.ProjectKeys = []string{c.Jira.ProjectKey}
+	if j.ProjectKey != "" && len(j.ProjectKeys) == 0 {
+		j.ProjectKeys = []string{j.ProjectKey}
 	}
 
 	// Set default custom field IDs if not provided
-	if c.Jira.CustomFieldIDs.Sprint == "" {
-		c.Jira.CustomFieldIDs.Sprint = "customfield_10020" // Common Jira Cloud default
+	if j.CustomFieldIDs.Sprint == "" {
+		j.CustomFieldIDs.Sprint = "customfield_10020" // Common Jira Cloud default
+	}
+	if j.CustomFieldIDs.StoryPoints == "" {
+		j.CustomFieldIDs.StoryPoints = "customfield_10016" // Common Jira Cloud default
+	}
+
+	if j.RequestsPerSecond < 0 {
+		return fmt.Errorf("%s.requests_per_second must be non-negative", prefix)
+	}
+	if j.PageDelayMS < 0 {
+		return fmt.Errorf("%s.page_delay_ms must be non-negative", prefix)
+	}
+	if j.SearchPath != "" && !strings.HasPrefix(j.SearchPath, "/") {
+		return fmt.Errorf("%s.search_path must start with /", prefix)
+	}
+	if j.RateLimitWarning < 0 {
+		return fmt.Errorf("%s.rate_limit_warning must be non-negative", prefix)
+	}
+
+	for alias, canonical := range j.PriorityAliases {
+		if strings.TrimSpace(alias) == "" || strings.TrimSpace(canonical) == "" {
+			return fmt.Errorf("%s.priority_aliases entries must map a non-empty name to a non-empty name", prefix)
+		}
+	}
+
+	for name := range j.ExtraHeaders {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("%s.extra_headers has an invalid header name %q", prefix, name)
+		}
+	}
+
+	// Impersonation widens what the integration account can see, so only send it over TLS with token auth
+	if j.ImpersonateAccountID != "" {
+		if strings.ContainsAny(j.ImpersonateAccountID, " \t\r\n") {
+			return fmt.Errorf("%s.impersonate_account_id must not contain whitespace", prefix)
+		}
+		if u, err := url.Parse(j.BaseURL); err != nil || u.Scheme != "https" {
+			return fmt.Errorf("%s.impersonate_account_id requires an https base_url", prefix)
+		}
+	}
+
+	// Default to the standard Jira "Bug" issue type
+	if len(j.BugIssueTypes) == 0 {
+		j.BugIssueTypes = []string{"Bug"}
+	}
+
+	// Default the display name to the instance host
+	if j.Name == "" {
+		j.Name = j.BaseURL
+		if u, err := url.Parse(j.BaseURL); err == nil && u.Host != "" {
+			j.Name = u.Host
+		}
+	}
+
+	return nil
+}
+
+// JiraConnections returns the primary Jira connection followed by any additional instances
+func (c *Config) JiraConnections() []JiraConfig {
+	return append([]JiraConfig{c.Jira}, c.JiraInstances...)
+}
+
+// Validate checks that all required configuration fields are present and valid
+func (c *Config) Validate() error {
+	// Validate Jira connections
+	if err := c.Jira.validate("jira"); err != nil {
+		return err
+	}
+	for i := range c.JiraInstances {
+		if err := c.JiraInstances[i].validate(fmt.Sprintf("jira_instances[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	// Validate data quality config
+	for i, field := range c.DataQuality.RequiredFields {
+		if !slices.Contains(supportedRequiredFields, field) {
+			return fmt.Errorf("data_quality.required_fields[%d] must be one of: %s", i, strings.Join(supportedRequiredFields, ", "))
+		}
+	}
+	if c.DataQuality.Severity < 1 {
+		return fmt.Errorf("data_quality.severity must be >= 1")
+	}
+
+	// Validate label buckets
+	for i, lb := range c.LabelBuckets {
+		if lb.Label == "" {
+			return fmt.Errorf("label_buckets[%d].label is required", i)
+		}
+		if lb.Bucket == "" {
+			return fmt.Errorf("label_buckets[%d].bucket is required", i)
+		}
+		if lb.Severity < 0 {
+			return fmt.Errorf("label_buckets[%d].severity must be >= 0", i)
+		}
+	}
+
+	// Validate status phases; each status may belong to one phase only
+	phaseOf := make(map[string]string)
+	for _, phase := range slices.Sorted(maps.Keys(c.StatusPhases)) {
+		if strings.TrimSpace(phase) == "" {
+			return fmt.Errorf("status_phases: phase name must not be empty")
+		}
+		if len(c.StatusPhases[phase]) == 0 {
+			return fmt.Errorf("status_phases.%s must list at least one status", phase)
+		}
+		for _, status := range c.StatusPhases[phase] {
+			key := strings.ToLower(status)
+			if other, ok := phaseOf[key]; ok {
+				return fmt.Errorf("status_phases: status %q is mapped to both %q and %q", status, other, phase)
+			}
+			phaseOf[key] = phase
+		}
+	}
+
+	// Validate tag queries
+	for i, tag := range c.Tags {
+		if tag.Name == "" {
+			return fmt.Errorf("tags[%d].name is required", i)
+		}
+		if tag.JQL == "" {
+			return fmt.Errorf("tags[%d].jql is required", i)
+		}
+	}
+
+	// Validate notify config
+	if c.Notify.MaxBugs < 0 {
+		return fmt.Errorf("notify.max_bugs must be non-negative")
+	}
+	if c.Notify.SummaryWidth < 0 {
+		return fmt.Errorf("notify.summary_width must be non-negative")
+	}
+	if c.Notify.GitHub.Repo != "" {
+		if err := c.Notify.GitHub.validate("notify.github"); err != nil {
+			return err
+		}
+	}
+	for i, route := range c.Notify.Routes {
+		prefix := fmt.Sprintf("notify.routes[%d]", i)
+		if len(route.Buckets) == 0 && len(route.Severities) == 0 {
+			return fmt.Errorf("%s needs buckets or severities to route", prefix)
+		}
+		if route.GitHub.Repo == "" {
+			return fmt.Errorf("%s.github.repo is required", prefix)
+		}
+		if err := route.GitHub.validate(prefix + ".github"); err != nil {
+			return err
+		}
+	}
+
+	// Validate check config
+	if c.Check.MaxDisplayAgeDays < 0 {
+		return fmt.Errorf("check.max_display_age_days must be non-negative")
 	}
-	if c.Jira.CustomFieldIDs.StoryPoints == "" {
-		c.Jira.CustomFieldIDs.StoryPoints = "customfield_10016" // Common Jira Cloud default
+	if c.Check.IgnoreOlderThanDays < 0 {
+		return fmt.Errorf("check.ignore_older_than_days must be non-negative")
+	}
+	if threshold := c.Check.DuplicateSimilarity(); threshold < 0 || threshold > 1 {
+		return fmt.Errorf("check.duplicate_threshold must be between 0 and 1")
+	}
+	if c.Check.AtRiskPercent < 0 || c.Check.AtRiskPercent > 100 {
+		return fmt.Errorf("check.at_risk_percent must be between 0 and 100")
+	}
+	if c.Check.MinBucketSize < 0 {
+		return fmt.Errorf("check.min_bucket_size must be non-negative")
+	}
+	if c.Check.ThrashingComments < 0 {
+		return fmt.Errorf("check.thrashing_comments must be non-negative")
+	}
+
+	// Validate stats config
+	if c.Stats.MonthsToAnalyze < 1 {
+		return fmt.Errorf("stats.months_to_analyze must be at least 1")
+	}
+	if c.Stats.SparklineMonths < 0 {
+		return fmt.Errorf("stats.sparkline_months must be non-negative")
+	}
+	if c.Stats.TrimLeadingMonths < 0 {
+		return fmt.Errorf("stats.trim_leading_months must be non-negative")
+	}
+	if c.Stats.TrimLeadingMonths >= c.Stats.MonthsToAnalyze {
+		return fmt.Errorf("stats.trim_leading_months must be less than stats.months_to_analyze")
+	}
+	for priority, weight := range c.Stats.PriorityWeights {
+		if weight < 0 {
+			return fmt.Errorf("stats.priority_weights.%s must be non-negative", priority)
+		}
+	}
+	for priority, goal := range c.Stats.PriorityGoals {
+		if goal < 0 || goal > 100 {
+			return fmt.Errorf("stats.priority_goals.%s must be between 0 and 100", priority)
+		}
+	}
+	if c.Stats.ZeroResolvedAlertAvg < 0 {
+		return fmt.Errorf("stats.zero_resolved_alert_average must be non-negative")
+	}
+	if c.Stats.MinLifetimeMinutes < 0 {
+		return fmt.Errorf("stats.min_lifetime_minutes must be non-negative")
+	}
+	if c.Stats.SprintDefaultPoints < 0 {
+		return fmt.Errorf("stats.sprint_default_story_points must be non-negative")
+	}
+	if c.Stats.SprintMinIssues < 0 {
+		return fmt.Errorf("stats.sprint_min_issues must be non-negative")
+	}
+	if c.Stats.SprintBatchSize < 1 {
+		return fmt.Errorf("stats.sprint_batch_size must be at least 1")
+	}
+	if c.Stats.SprintConcurrency < 1 {
+		return fmt.Errorf("stats.sprint_concurrency must be at least 1")
+	}
+	for i, state := range c.Stats.SprintStates {
+		if !slices.Contains(supportedSprintStates, strings.ToLower(state)) {
+			return fmt.Errorf("stats.sprint_states[%d] must be one of: %s", i, strings.Join(supportedSprintStates, ", "))
+		}
+	}
+	if !slices.Contains(supportedSprintSorts, c.Stats.SprintSortBy) {
+		return fmt.Errorf("stats.sprint_sort_by must be one of: %s", strings.Join(supportedSprintSorts, ", "))
+	}
+	if !slices.Contains(supportedGoalModes, c.Stats.Goal.ComparisonMode) {
+		return fmt.Errorf("stats.goal.comparison_mode must be one of: %s", strings.Join(supportedGoalModes, ", "))
+	}
+	if !slices.Contains(supportedGoalRoundings, c.Stats.Goal.Rounding) {
+		return fmt.Errorf("stats.goal.rounding must be one of: %s", strings.Join(supportedGoalRoundings, ", "))
+	}
+	for i, offset := range c.Stats.Goal.BaselineOffsetsMonths {
+		if offset < 1 {
+			return fmt.Errorf("stats.goal.baseline_offsets_months[%d] must be at least 1", i)
+		}
+		if slices.Contains(c.Stats.Goal.BaselineOffsetsMonths[:i], offset) {
+			return fmt.Errorf("stats.goal.baseline_offsets_months[%d] duplicates %d", i, offset)
+		}
+	}
+	if c.Stats.SparklineMarkers != "endpoints" && c.Stats.SparklineMarkers != "quarterly" {
+		return fmt.Errorf("stats.sparkline_markers must be \"endpoints\" or \"quarterly\"")
+	}
+	if c.Stats.FutureDatedBugs != "current_month" && c.Stats.FutureDatedBugs != "exclude" {
+		return fmt.Errorf("stats.future_dated_bugs must be \"current_month\" or \"exclude\"")
+	}
+
+	// Validate the working-hours clock
+	if _, _, err := c.WorkingHours.Window(); err != nil {
+		return err
+	}
+	if _, err := c.WorkingHours.Weekdays(); err != nil {
+		return err
+	}
+	if _, err := c.WorkingHours.Location(); err != nil {
+		return err
 	}
 
 	// Validate SLA rules
@@ -160,6 +788,35 @@ func (c *Config) Validate() error {
 		if rule.Name == "" {
 			return fmt.Errorf("sla_rules[%d].name is required", i)
 		}
+		switch rule.AgeFrom {
+		case "", "updated", "created":
+		case "first_response":
+			if c.Jira.CustomFieldIDs.FirstResponse == "" {
+				return fmt.Errorf("sla_rules[%d].age_from is first_response but jira.custom_fields.first_response is not set", i)
+			}
+		default:
+			return fmt.Errorf("sla_rules[%d].age_from must be one of: updated, created, first_response", i)
+		}
+		if rule.Clock != "" && rule.Clock != ClockCalendar && rule.Clock != ClockWorkingHours {
+			return fmt.Errorf("sla_rules[%d].clock must be %q or %q", i, ClockCalendar, ClockWorkingHours)
+		}
+
+		// Rules with escalation tiers define thresholds per tier instead
+		if len(rule.Tiers) > 0 {
+			for j, tier := range rule.Tiers {
+				if tier.MaxAgeDays < 0 {
+					return fmt.Errorf("sla_rules[%d].tiers[%d].max_age_days must be non-negative", i, j)
+				}
+				if tier.Bucket == "" {
+					return fmt.Errorf("sla_rules[%d].tiers[%d].bucket is required", i, j)
+				}
+				if tier.Severity < 1 {
+					return fmt.Errorf("sla_rules[%d].tiers[%d].severity must be >= 1", i, j)
+				}
+			}
+			continue
+		}
+
 		if rule.MaxAgeDays < 0 {
 			return fmt.Errorf("sla_rules[%d].max_age_days must be non-negative", i)
 		}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config file with the given name and content to a temp dir and returns its path
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
+
+func TestLoadFormats(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+jira:
+  base_url: https://example.atlassian.net
+  email: bot@example.com
+  api_token: secret
+  project_keys: [DEMO, OPS]
+sla_rules:
+  - name: critical
+    priority: Critical
+    status: [Backlog]
+    max_age_days: 2
+    bucket: "🔴 URGENT"
+    severity: 1
+stats:
+  months_to_analyze: 12
+`,
+		"config.json": `{
+  "jira": {
+    "base_url": "https://example.atlassian.net",
+    "email": "bot@example.com",
+    "api_token": "secret",
+    "project_keys": ["DEMO", "OPS"]
+  },
+  "sla_rules": [
+    {"name": "critical", "priority": "Critical", "status": ["Backlog"], "max_age_days": 2, "bucket": "🔴 URGENT", "severity": 1}
+  ],
+  "stats": {"months_to_analyze": 12}
+}`,
+		"config.toml": `
+[jira]
+base_url = "https://example.atlassian.net"
+email = "bot@example.com"
+api_token = "secret"
+project_keys = ["DEMO", "OPS"]
+
+[[sla_rules]]
+name = "critical"
+priority = "Critical"
+status = ["Backlog"]
+max_age_days = 2
+bucket = "🔴 URGENT"
+severity = 1
+
+[stats]
+months_to_analyze = 12
+`,
+	}
+
+	loaded := make(map[string]*Config)
+	for name, content := range files {
+		cfg, err := Load(writeConfig(t, name, content))
+		if err != nil {
+			t.Fatalf("Load(%s): %v", name, err)
+		}
+		loaded[name] = cfg
+	}
+
+	want := loaded["config.yaml"]
+	if want.Stats.MonthsToAnalyze != 12 || len(want.SLARules) != 1 || want.SLARules[0].MaxAgeDays != 2 {
+		t.Fatalf("YAML config not loaded as written: %+v", want)
+	}
+	for _, name := range []string{"config.json", "config.toml"} {
+		if !reflect.DeepEqual(loaded[name], want) {
+			t.Errorf("%s loaded as\n%+v\nwant the YAML result\n%+v", name, loaded[name], want)
+		}
+	}
+}
+
+func TestDuplicateSimilarity(t *testing.T) {
+	base := `
+jira:
+  base_url: https://example.atlassian.net
+  email: bot@example.com
+  api_token: secret
+  project_keys: [DEMO]
+sla_rules:
+  - {name: any, max_age_days: 7, bucket: STALE, severity: 1}
+`
+	tests := []struct {
+		name  string
+		check string
+		want  float64
+	}{
+		{"unset uses the default", "", DefaultDuplicateThreshold},
+		{"explicit zero is kept", "check:\n  duplicate_threshold: 0\n", 0},
+		{"explicit value", "check:\n  duplicate_threshold: 0.8\n", 0.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, "config.yaml", base+tt.check))
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := cfg.Check.DuplicateSimilarity(); got != tt.want {
+				t.Errorf("DuplicateSimilarity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := Load(writeConfig(t, "config.yaml", base+"check:\n  duplicate_threshold: 1.5\n"))
+	if err == nil {
+		t.Error("Load with duplicate_threshold 1.5 = nil error, want a range error")
+	}
+}
+
+func TestValidateImpersonation(t *testing.T) {
+	tests := []struct {
+		baseURL, accountID string
+		wantErr            bool
+	}{
+		{"https://example.atlassian.net", "5b10ac8d82e05b22cc7d4ef5", false},
+		{"http://jira.internal", "5b10ac8d82e05b22cc7d4ef5", true},
+		{"https://example.atlassian.net", "5b10ac8d 82e05b22", true},
+		{"http://jira.internal", "", false},
+	}
+
+	for _, tt := range tests {
+		j := JiraConfig{BaseURL: tt.baseURL, Email: "bot@example.com", APIToken: "token", ProjectKeys: []string{"DEMO"}, ImpersonateAccountID: tt.accountID}
+		if err := j.validate("jira"); (err != nil) != tt.wantErr {
+			t.Errorf("validate(%s, %q) = %v, want error %v", tt.baseURL, tt.accountID, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInterpolateValue(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "jira_token")
+	if err := os.WriteFile(tokenPath, []byte("  s3cret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyPath := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyPath, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BUG_BUTLER_TEST_TOKEN", "env-token")
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr string
+	}{
+		{"literal-token", "literal-token", ""},
+		{"${BUG_BUTLER_TEST_TOKEN}", "env-token", ""},
+		{"${file:" + tokenPath + "}", "s3cret-token", ""},
+		{"${file:" + filepath.Join(dir, "missing") + "}", "", "failed to read secret file"},
+		{"${file:" + emptyPath + "}", "", "is empty"},
+		{"${BUG_BUTLER_TEST_UNSET}", "", "BUG_BUTLER_TEST_UNSET is not set"},
+	}
+
+	for _, tt := range tests {
+		got, err := interpolateValue(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("interpolateValue(%q) = %q, %v, want an error containing %q", tt.value, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("interpolateValue(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestMonthsToAnalyze(t *testing.T) {
+	const base = `
+jira:
+  base_url: https://example.atlassian.net
+  email: bot@example.com
+  api_token: secret
+  project_keys: [DEMO]
+sla_rules:
+  - name: critical
+    priority: Critical
+    max_age_days: 2
+    bucket: "🔴 URGENT"
+    severity: 1
+`
+	tests := []struct {
+		name    string
+		stats   string
+		want    int
+		wantErr bool
+	}{
+		{"default when unset", "", DefaultMonthsToAnalyze, false},
+		{"configured", "stats:\n  months_to_analyze: 6\n", 6, false},
+		{"single month", "stats:\n  months_to_analyze: 1\n", 1, false},
+		{"negative", "stats:\n  months_to_analyze: -3\n", 0, true},
+		{"trimming fewer months", "stats:\n  months_to_analyze: 6\n  trim_leading_months: 1\n", 6, false},
+		{"trimming every month", "stats:\n  months_to_analyze: 6\n  trim_leading_months: 6\n", 0, true},
+	}
+	for _, tt := range tests {
+		cfg, err := Load(writeConfig(t, "config.yaml", base+tt.stats))
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "months_to_analyze") {
+				t.Errorf("%s: Load = %v, want a months_to_analyze error", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Load: %v", tt.name, err)
+		}
+		if cfg.Stats.MonthsToAnalyze != tt.want {
+			t.Errorf("%s: MonthsToAnalyze = %d, want %d", tt.name, cfg.Stats.MonthsToAnalyze, tt.want)
+		}
+	}
+}
+
+func TestStatusPhases(t *testing.T) {
+	base := `
+jira:
+  base_url: https://example.atlassian.net
+  email: bot@example.com
+  api_token: secret
+  project_keys: [DEMO]
+sla_rules:
+  - {name: any, max_age_days: 7, bucket: STALE, severity: 1}
+status_phases:
+`
+	tests := []struct {
+		name    string
+		phases  string
+		wantErr string
+	}{
+		{"valid", "  Triage: [Open, Needs Info]\n  Active: [In Progress]\n", ""},
+		{"status in two phases", "  Triage: [Open]\n  Active: [open]\n", `status "Open" is mapped to both "Active" and "Triage"`},
+		{"empty phase", "  Blocked: []\n", "status_phases.Blocked must list at least one status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, "config.yaml", base+tt.phases))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Load: %v", err)
+				}
+				if got := cfg.StatusPhases["Triage"]; !slices.Equal(got, []string{"Open", "Needs Info"}) {
+					t.Errorf("Triage statuses = %v, want [Open Needs Info]", got)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
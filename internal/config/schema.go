@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// requiredKeys lists required keys per object path (dot-separated koanf paths)
+var requiredKeys = map[string][]string{
+	"":               {"jira", "sla_rules"},
+	"jira":           {"base_url", "email", "api_token"},
+	"jira_instances": {"base_url", "email", "api_token"},
+	"sla_rules":      {"name"},
+	"tags":           {"name", "jql"},
+}
+
+// JSONSchema generates a JSON Schema describing the configuration file format
+// The schema is derived from the koanf struct tags of Config
+func JSONSchema() ([]byte, error) {
+	schema := schemaForType(reflect.TypeOf(Config{}), "")
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Bug Butler configuration"
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaForType builds the schema for a Go type at the given config path
+func schemaForType(t reflect.Type, path string) map[string]any {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaForType(t.Elem(), path)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		items := schemaForType(t.Elem(), path)
+		// A single string is accepted wherever a list of strings is expected
+		if t.Elem().Kind() == reflect.String {
+			return map[string]any{"type": []string{"array", "string"}, "items": items}
+		}
+		return map[string]any{"type": "array", "items": items}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaForType(t.Elem(), path)}
+	case reflect.Struct:
+		properties := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			key := strings.Split(field.Tag.Get("koanf"), ",")[0]
+			if key == "" || key == "-" || !field.IsExported() {
+				continue
+			}
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			properties[key] = schemaForType(field.Type, childPath)
+		}
+
+		schema := map[string]any{
+			"type":       "object",
+			"properties": properties,
+		}
+		if required, ok := requiredKeys[path]; ok {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestJSONSchemaRequiredKeys(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema: %v", err)
+	}
+
+	var schema struct {
+		Schema     string                     `json:"$schema"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if schema.Schema == "" {
+		t.Error("schema has no $schema URI")
+	}
+	for _, key := range []string{"jira", "sla_rules"} {
+		if !slices.Contains(schema.Required, key) {
+			t.Errorf("required = %v, missing %q", schema.Required, key)
+		}
+	}
+	for _, key := range []string{"jira", "sla_rules", "check", "stats", "output", "notify"} {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Errorf("schema has no %q property", key)
+		}
+	}
+
+	var jira struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(schema.Properties["jira"], &jira); err != nil {
+		t.Fatalf("jira property: %v", err)
+	}
+	if want := []string{"base_url", "email", "api_token"}; !slices.Equal(jira.Required, want) {
+		t.Errorf("jira required = %v, want %v", jira.Required, want)
+	}
+}
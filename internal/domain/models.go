@@ -1,22 +1,51 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
 
 // Bug represents a Jira issue with relevant fields for SLA monitoring
 type Bug struct {
-	Key            string     // Jira issue key (e.g., "PROJ-123")
-	Summary        string     // Issue title/summary
-	Priority       string     // Priority level (Critical, High, Medium, Low)
-	Status         string     // Current status (Backlog, Needs Triage, etc.)
-	IssueType      string     // Issue type (Bug, Story, Task, etc.)
-	Created        time.Time  // When the bug was created
-	Updated        time.Time  // When the bug was last updated
-	Resolution     string     // Resolution status (empty if unresolved)
-	ResolutionDate *time.Time // When the bug was resolved (nil if unresolved)
-	SprintID       string     // Sprint ID (empty if not in sprint)
-	SprintName     string     // Sprint name (empty if not in sprint)
-	StoryPoints    float64    // Story points assigned to this issue
-	BaseURL        string     // Jira base URL for building links
+	Key             string         `json:"key"`                     // Jira issue key (e.g., "PROJ-123")
+	Summary         string         `json:"summary"`                 // Issue title/summary
+	Priority        string         `json:"priority"`                // Priority level (Critical, High, Medium, Low)
+	Status          string         `json:"status"`                  // Current status (Backlog, Needs Triage, etc.)
+	IssueType       string         `json:"issue_type"`              // Issue type (Bug, Story, Task, etc.)
+	Created         time.Time      `json:"created"`                 // When the bug was created
+	Updated         time.Time      `json:"updated"`                 // When the bug was last updated
+	Resolution      string         `json:"resolution"`              // Resolution status (empty if unresolved)
+	ResolutionDate  *time.Time     `json:"resolution_date"`         // When the bug was resolved (nil if unresolved)
+	SprintID        string         `json:"sprint_id"`               // Sprint ID (empty if not in sprint)
+	SprintName      string         `json:"sprint_name"`             // Sprint name (empty if not in sprint)
+	SprintState     string         `json:"sprint_state"`            // Sprint state: active, closed, or future (empty if not in sprint)
+	SprintStart     *time.Time     `json:"sprint_start"`            // When the sprint started (nil if not in a sprint or not started)
+	StoryPoints     float64        `json:"story_points"`            // Story points assigned to this issue
+	FixVersions     []string       `json:"fix_versions"`            // Fix version names (empty if none)
+	AffectsVersions []string       `json:"affects_versions"`        // Affects version names (empty if none)
+	Components      []string       `json:"components,omitempty"`    // Component names (only fetched for stats)
+	Labels          []string       `json:"labels,omitempty"`        // Jira labels
+	FirstResponse   *time.Time     `json:"first_response"`          // When the bug first got a response (nil if none yet)
+	Assignee        string         `json:"assignee"`                // Assignee display name (empty if unassigned)
+	EpicKey         string         `json:"epic_key"`                // Key of the linked epic (empty if none or epic link not configured)
+	ParentKey       string         `json:"parent_key"`              // Key of the parent issue (empty if none)
+	ParentStatus    string         `json:"parent_status,omitempty"` // Parent's status, fetched only for check.active_parent_statuses
+	CommentCount    int            `json:"comment_count"`           // Number of comments on the issue
+	BaseURL         string         `json:"base_url"`                // Jira base URL for building links
+	Source          string         `json:"source"`                  // Name of the Jira instance the bug came from
+	CustomFields    map[string]any `json:"custom_fields,omitempty"` // Raw values of configured extra fields, keyed by field ID
+	Tags            []string       `json:"tags,omitempty"`          // Names of tag queries that matched this bug
+	ReopenedAt      []time.Time    `json:"reopened_at,omitempty"`   // When the resolution was cleared, from the changelog (only fetched for sprint stats)
+	Violation       *Violation     `json:"-"`                       // SLA rule breach recorded during evaluation (nil if compliant)
+}
+
+// AddTag adds a tag to the bug unless it already has it
+func (b *Bug) AddTag(tag string) {
+	if !slices.Contains(b.Tags, tag) {
+		b.Tags = append(b.Tags, tag)
+	}
 }
 
 // URL returns the full URL to the bug in Jira
@@ -30,18 +59,98 @@ func (b *Bug) Age() time.Duration {
 }
 
 // AgeDays returns the age of the bug in days
+// Future timestamps (clock skew) are clamped to an age of zero
 func (b *Bug) AgeDays() float64 {
-	return b.Age().Hours() / 24
+	return max(b.Age().Hours()/24, 0)
+}
+
+// CreatedAgeDays returns the time since the bug was created in days (clamped to zero)
+func (b *Bug) CreatedAgeDays() float64 {
+	return max(time.Since(b.Created).Hours()/24, 0)
+}
+
+// FirstResponseDays returns the time to first response in days
+// If the bug has not been responded to yet, the time since creation is used
+func (b *Bug) FirstResponseDays() float64 {
+	if b.FirstResponse == nil {
+		return b.CreatedAgeDays()
+	}
+	return b.FirstResponse.Sub(b.Created).Hours() / 24
+}
+
+// ReopenedSince reports whether the bug was reopened at or after the given time
+func (b *Bug) ReopenedSince(t time.Time) bool {
+	return slices.ContainsFunc(b.ReopenedAt, func(reopened time.Time) bool {
+		return !reopened.Before(t)
+	})
+}
+
+// MissingFields returns which of the named fields have no value on this bug
+// Supported field names: priority, assignee, sprint, story_points, fix_version
+func (b *Bug) MissingFields(fields []string) []string {
+	var missing []string
+	for _, field := range fields {
+		empty := false
+		switch field {
+		case "priority":
+			empty = b.Priority == "" || b.Priority == "Unknown"
+		case "assignee":
+			empty = b.Assignee == ""
+		case "sprint":
+			empty = b.SprintID == ""
+		case "story_points":
+			empty = b.StoryPoints == 0
+		case "fix_version":
+			empty = len(b.FixVersions) == 0
+		}
+		if empty {
+			missing = append(missing, field)
+		}
+	}
+	return missing
 }
 
+// IsBugType checks if the issue type is one of the given bug types (case-insensitive)
+func (b *Bug) IsBugType(bugTypes []string) bool {
+	for _, t := range bugTypes {
+		if strings.EqualFold(b.IssueType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleMatcher is an SLA rule the evaluator applies to each bug; SLARule implements it, and
+// custom matching logic can be registered alongside the configured rules
+type RuleMatcher interface {
+	Matches(bug *Bug) bool  // Bug falls under the rule
+	Violates(bug *Bug) bool // Bug falls under the rule and breaches it
+	BucketName() string     // Bucket violating bugs go to
+	Severity() int          // Bucket display priority (1 = highest)
+}
+
+var _ RuleMatcher = (*SLARule)(nil)
+
 // SLARule defines a threshold for bug age based on priority and status
 type SLARule struct {
-	Name        string   // Descriptive name for the rule
-	Priority    string   // Priority to match (e.g., "Critical")
-	Status      []string // Status(es) to match (e.g., ["Backlog", "Needs Triage"])
-	MaxAgeDays  float64  // Maximum allowed age in days
-	BucketName  string   // Which bucket to assign violations to
-	Severity    int      // Bucket display priority (1 = highest)
+	Name           string        // Descriptive name for the rule
+	Priority       string        // Priority to match (e.g., "Critical")
+	Status         []string      // Status(es) to match (e.g., ["Backlog", "Needs Triage"])
+	MaxAgeDays     float64       // Maximum allowed age in days
+	Bucket         string        // Which bucket to assign violations to
+	BucketSeverity int           // Bucket display priority (1 = highest)
+	FixVersions    []string      // Fix version(s) to match (e.g., ["2.4.0"])
+	Tiers          []SLATier     // Escalation tiers (optional, replaces MaxAgeDays/Bucket/BucketSeverity)
+	AgeFrom        string        // What the age is measured from: "updated" (default), "created", "first_response"
+	Clock          string        // How age accrues: "calendar" (default) or "working_hours"
+	WorkClock      *WorkingHours // Working-hours clock set for rules with Clock "working_hours" (nil = calendar time)
+}
+
+// SLATier is one escalation step of an SLA rule
+type SLATier struct {
+	MaxAgeDays float64 // Age in days after which this tier is breached
+	BucketName string  // Which bucket to assign violations to
+	Severity   int     // Bucket display priority (1 = highest)
 }
 
 // Matches checks if a bug matches this rule's criteria
@@ -65,12 +174,149 @@ func (r *SLARule) Matches(bug *Bug) bool {
 		}
 	}
 
+	// Check fix version match (OR logic for multiple versions)
+	if len(r.FixVersions) > 0 {
+		versionMatch := false
+		for _, version := range r.FixVersions {
+			for _, bugVersion := range bug.FixVersions {
+				if version == bugVersion {
+					versionMatch = true
+					break
+				}
+			}
+		}
+		if !versionMatch {
+			return false
+		}
+	}
+
 	return true
 }
 
 // Violates checks if a bug violates this rule (matches criteria and exceeds age)
 func (r *SLARule) Violates(bug *Bug) bool {
-	return r.Matches(bug) && bug.AgeDays() > r.MaxAgeDays
+	return r.BreachedTier(bug) != nil
+}
+
+// BucketName returns the bucket violations go to; for a tiered rule it is the first tier's bucket,
+// and the evaluator places each bug by the tier it breached instead
+func (r *SLARule) BucketName() string {
+	return r.firstTier().BucketName
+}
+
+// Severity returns the display priority of the rule's bucket (its first tier's if tiered)
+func (r *SLARule) Severity() int {
+	return r.firstTier().Severity
+}
+
+// AgeDays returns the bug's age in days as measured by this rule
+// On the working-hours clock the age is in working days (working time / working day length)
+func (r *SLARule) AgeDays(bug *Bug) float64 {
+	if r.WorkClock != nil {
+		from, to := r.ageSpan(bug)
+		return WorkingHoursAge(r.WorkClock, from, to).Hours() / r.WorkClock.DayLength().Hours()
+	}
+
+	switch r.AgeFrom {
+	case "created":
+		return bug.CreatedAgeDays()
+	case "first_response":
+		return bug.FirstResponseDays()
+	default:
+		return bug.AgeDays()
+	}
+}
+
+// ageSpan returns the start and end of the period this rule measures the bug's age over
+func (r *SLARule) ageSpan(bug *Bug) (from, to time.Time) {
+	now := time.Now()
+	switch r.AgeFrom {
+	case "created":
+		return bug.Created, now
+	case "first_response":
+		if bug.FirstResponse != nil {
+			return bug.Created, *bug.FirstResponse
+		}
+		return bug.Created, now
+	default:
+		return bug.Updated, now
+	}
+}
+
+// BreachedTier returns the highest tier the bug has breached, or nil if within SLA
+// Rules without escalation tiers are treated as a single tier
+func (r *SLARule) BreachedTier(bug *Bug) *SLATier {
+	if !r.Matches(bug) {
+		return nil
+	}
+
+	age := r.AgeDays(bug)
+
+	if len(r.Tiers) == 0 {
+		if age > r.MaxAgeDays {
+			return &SLATier{
+				MaxAgeDays: r.MaxAgeDays,
+				BucketName: r.Bucket,
+				Severity:   r.BucketSeverity,
+			}
+		}
+		return nil
+	}
+
+	// Pick the breached tier with the largest threshold
+	var breached *SLATier
+	for i := range r.Tiers {
+		tier := &r.Tiers[i]
+		if age > tier.MaxAgeDays && (breached == nil || tier.MaxAgeDays > breached.MaxAgeDays) {
+			breached = tier
+		}
+	}
+	return breached
+}
+
+// FirstThreshold returns the smallest age threshold of the rule (its first tier)
+func (r *SLARule) FirstThreshold() float64 {
+	return r.firstTier().MaxAgeDays
+}
+
+// firstTier returns the tier with the smallest threshold, treating an untiered rule as a single tier
+func (r *SLARule) firstTier() SLATier {
+	if len(r.Tiers) == 0 {
+		return SLATier{MaxAgeDays: r.MaxAgeDays, BucketName: r.Bucket, Severity: r.BucketSeverity}
+	}
+
+	first := r.Tiers[0]
+	for _, tier := range r.Tiers[1:] {
+		if tier.MaxAgeDays < first.MaxAgeDays {
+			first = tier
+		}
+	}
+	return first
+}
+
+// RemainingDays returns the days left before the bug breaches this rule (negative once breached)
+func (r *SLARule) RemainingDays(bug *Bug) float64 {
+	return r.FirstThreshold() - r.AgeDays(bug)
+}
+
+// Violation records the SLA rule a bug breached during evaluation
+type Violation struct {
+	RuleName   string  // Rule that was breached
+	MaxAgeDays float64 // Threshold of the breached tier
+	AgeDays    float64 // Bug age as measured by the rule
+}
+
+// OverageDays returns how far past the threshold the bug is
+func (v *Violation) OverageDays() float64 {
+	return v.AgeDays - v.MaxAgeDays
+}
+
+// AtRiskBug is a compliant bug approaching the threshold of the rule it matched
+type AtRiskBug struct {
+	Bug           *Bug
+	RuleName      string  // Rule the bug will breach
+	MaxAgeDays    float64 // Threshold of that rule
+	RemainingDays float64 // Days left before breaching
 }
 
 // Bucket represents a category of bugs based on SLA status
@@ -80,9 +326,16 @@ type Bucket struct {
 	Bugs     []*Bug  // Bugs in this bucket
 }
 
+// Snoozed bugs that would otherwise violate an SLA are grouped into this bucket, listed last
+const (
+	SnoozedBucketName = "💤 Snoozed"
+	SnoozedSeverity   = 99
+)
+
 // BucketGroup is a collection of buckets sorted by severity
 type BucketGroup struct {
 	Buckets []*Bucket
+	AtRisk  []*AtRiskBug // Compliant bugs nearing their SLA, soonest first (empty unless enabled)
 }
 
 // AddToBucket adds a bug to a named bucket, creating it if needed
@@ -116,37 +369,207 @@ func (bg *BucketGroup) Sort() {
 	}
 }
 
+// SortDescending sorts buckets by severity with the least severe first, keeping the Snoozed bucket last
+func (bg *BucketGroup) SortDescending() {
+	slices.SortStableFunc(bg.Buckets, func(a, b *Bucket) int {
+		if (a.Name == SnoozedBucketName) != (b.Name == SnoozedBucketName) {
+			if a.Name == SnoozedBucketName {
+				return 1
+			}
+			return -1
+		}
+		return b.Severity - a.Severity
+	})
+}
+
+// Only returns a group holding just the bucket whose name matches (see BucketNameMatches)
+// At-risk bugs are kept, since they belong to no bucket
+func (bg *BucketGroup) Only(name string) *BucketGroup {
+	filtered := &BucketGroup{AtRisk: bg.AtRisk}
+	for _, bucket := range bg.Buckets {
+		if BucketNameMatches(bucket.Name, name) {
+			filtered.Buckets = append(filtered.Buckets, bucket)
+		}
+	}
+	return filtered
+}
+
+// BucketNameMatches reports whether a bucket name matches a user-supplied name, case-insensitively
+// and with or without its leading emoji (so "urgent" matches "🔴 URGENT")
+func BucketNameMatches(bucketName, name string) bool {
+	bare := strings.TrimLeftFunc(bucketName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.EqualFold(bucketName, name) || strings.EqualFold(bare, name)
+}
+
+// ActiveViolations counts the bugs across all buckets except the Snoozed bucket
+func (bg *BucketGroup) ActiveViolations() int {
+	count := 0
+	for _, bucket := range bg.Buckets {
+		if bucket.Name != SnoozedBucketName {
+			count += len(bucket.Bugs)
+		}
+	}
+	return count
+}
+
+// ActiveBugs returns the bugs across all buckets except the Snoozed bucket
+func (bg *BucketGroup) ActiveBugs() []*Bug {
+	var bugs []*Bug
+	for _, bucket := range bg.Buckets {
+		if bucket.Name != SnoozedBucketName {
+			bugs = append(bugs, bucket.Bugs...)
+		}
+	}
+	return bugs
+}
+
+// CountByStatus counts the bugs across all buckets by status
+func (bg *BucketGroup) CountByStatus() map[string]int {
+	counts := make(map[string]int)
+	for _, bucket := range bg.Buckets {
+		for _, bug := range bucket.Bugs {
+			counts[bug.Status]++
+		}
+	}
+	return counts
+}
+
 // MonthlyBugStats represents bug metrics for a single month
 type MonthlyBugStats struct {
-	Month           time.Time      // First day of the month
-	TotalCreated    int            // Total bugs created in this month
-	TotalResolved   int            // Total bugs resolved in this month
-	TotalUnresolved int            // Total unresolved bugs at end of this month (backlog size)
-	NetChange       int            // Created - Resolved
-	ChangePercent   float64        // % change in created from previous month
-	ByPriority      map[string]int // Created count by priority level
+	Month                   time.Time      // First day of the month
+	TotalCreated            int            // Total bugs created in this month
+	TotalResolved           int            // Total bugs resolved in this month
+	TotalUnresolved         int            // Total unresolved bugs at end of this month (backlog size)
+	TotalUnresolvedWeighted float64        // Unresolved bugs at end of month weighted by priority (equals TotalUnresolved without weights)
+	NetChange               int            // Created - Resolved
+	ChangePercent           float64        // % change in created from previous month
+	ByPriority              map[string]int // Created count by priority level
+	UnresolvedByPriority    map[string]int // Unresolved bugs at end of this month by priority level
+	Partial                 bool           // In-progress current month (counts so far only)
 }
 
 // TrendStats represents complete trend analysis over a time period
 type TrendStats struct {
-	MonthlyData       []MonthlyBugStats // Monthly statistics ordered chronologically
-	CurrentMonth      *MonthlyBugStats  // In-progress month (partial data)
-	LastYearSameMonth *MonthlyBugStats  // Same month from last year (for goal comparison)
-	ReductionGoal     float64           // Target reduction percentage
-	GoalTarget        int               // Calculated bug count target for current month
-	OnTrack           bool              // Whether current month is meeting the goal
-	SprintStats       []SprintStats     // Sprint-level statistics (if enabled)
+	MonthlyData       []MonthlyBugStats    // Monthly statistics ordered chronologically
+	CurrentMonth      *MonthlyBugStats     // In-progress month (partial data)
+	ReductionGoal     float64              // Target reduction percentage
+	GoalMode          string               // Goal comparison mode: calendar_month, trailing_30_days, or prorated
+	Goals             []GoalProgress       // Goal progress per baseline offset with enough history (empty if none)
+	SprintStats       []SprintStats        // Sprint-level statistics (if enabled)
+	RollingCreated    []RollingCount       // Bugs created in trailing day windows (e.g., 30/60/90)
+	ResolutionTimes   []PriorityResolution // Mean resolution time per priority
+	Runway            *BacklogRunway       // Days until the backlog doubles or halves (nil if no backlog)
+	ResolvedAnomalies []ResolvedAnomaly    // Months with zero resolved bugs despite a busy trailing history
+	Phases            []PhaseCount         // Current backlog per status phase (empty unless status_phases is configured)
+	CreatedByWeekday  []WeekdayCount       // Bugs created per day of the week over the window, Monday first
+}
+
+// PhaseCount is the number of bugs whose status falls in a single status phase
+type PhaseCount struct {
+	Phase string // Phase name (e.g., "Triage"), or "Other" for unmapped statuses
+	Bugs  int    // Bugs in the phase
+}
+
+// GoalProgress is the reduction goal progress against one baseline period
+type GoalProgress struct {
+	OffsetMonths  int              // Months between the baseline and the current period (12 = year over year)
+	BaselineMonth *MonthlyBugStats // Month the baseline is taken from
+	Baseline      int              // Baseline count the goal is derived from (prorated if applicable)
+	Current       int              // This period's created count compared against the target
+	Target        int              // Calculated bug count target
+	OnTrack       bool             // Whether the current count meets the target
+	PriorityGoals []PriorityGoal   // Per-priority goal tracking, in priority order (empty unless configured)
+}
+
+// PriorityGoal is the reduction goal progress for a single priority
+type PriorityGoal struct {
+	Priority      string  // Priority level
+	ReductionGoal float64 // Target reduction percentage for this priority
+	Baseline      int     // Baseline count the goal is derived from
+	Current       int     // This period's created count
+	Target        int     // Calculated bug count target
+	OnTrack       bool    // Whether the current count meets the target
+}
+
+// ResolvedAnomaly is a month with no resolved bugs after months that averaged many
+type ResolvedAnomaly struct {
+	Month           time.Time // First day of the month with zero resolved
+	TrailingAverage float64   // Average resolved per month over the preceding window
+	WindowMonths    int       // Number of preceding months averaged
+}
+
+// BacklogRunway estimates how long until the backlog doubles (growing) or halves (shrinking)
+type BacklogRunway struct {
+	WindowDays int     // Trailing window the net velocity is measured over
+	Unresolved int     // Current unresolved bug count
+	NetPerDay  float64 // Average bugs created minus resolved per day over the window
+	Days       float64 // Days until the backlog doubles (growing) or halves (shrinking); +Inf if flat
+}
+
+// Growing reports whether more bugs are being created than resolved
+func (r *BacklogRunway) Growing() bool {
+	return r.NetPerDay > 0
+}
+
+// Stable reports whether creation and resolution are balanced (infinite runway)
+func (r *BacklogRunway) Stable() bool {
+	return r.NetPerDay == 0
+}
+
+// ComponentTrend is the monthly trend of the bugs filed against a single component
+type ComponentTrend struct {
+	Component   string            // Component name (empty for bugs without a component)
+	Bugs        int               // Bugs fetched for the component
+	MonthlyData []MonthlyBugStats // Monthly statistics ordered chronologically
+}
+
+// EpicRollup is the number of bugs linked to a single epic
+type EpicRollup struct {
+	EpicKey    string         // Epic issue key (empty for bugs without an epic)
+	Count      int            // Bugs linked to the epic
+	ByPriority map[string]int // Bug count by priority level
+}
+
+// WeekdayCount is the number of bugs created on a single day of the week
+type WeekdayCount struct {
+	Weekday time.Weekday // Day of the week
+	Created int          // Bugs created on that day
+}
+
+// WeeklyResolvedStats is the count of bugs resolved in a single week (Monday start)
+type WeeklyResolvedStats struct {
+	WeekStart  time.Time      // Monday 00:00 UTC starting the week
+	Resolved   int            // Bugs resolved during the week
+	ByPriority map[string]int // Resolved count by priority level
+}
+
+// PriorityResolution is the mean time to resolve bugs of a single priority
+type PriorityResolution struct {
+	Priority string  // Priority level
+	Count    int     // Number of resolved bugs
+	MeanDays float64 // Mean days from created to resolved
+}
+
+// RollingCount is the number of bugs created within a trailing window of days
+type RollingCount struct {
+	Days  int // Window length in days
+	Count int // Bugs created within the window
 }
 
 // SprintStats represents bug and issue statistics for a single sprint
 type SprintStats struct {
-	SprintID          string  // Sprint ID from Jira
-	SprintName        string  // Sprint name
-	BugCount          int     // Number of bugs completed in this sprint
-	OtherCount        int     // Number of non-bug issues completed
-	TotalCount        int     // Total issues completed
-	BugPercentage     float64 // Percentage of bugs vs total issues
-	BugStoryPoints    float64 // Story points from bugs
-	TotalStoryPoints  float64 // Total story points in sprint
-	PointsPercentage  float64 // Percentage of bug points vs total points
+	SprintID         string    // Sprint ID from Jira
+	SprintName       string    // Sprint name
+	StartDate        time.Time // Sprint start date (zero if unknown or not started)
+	BugCount         int       // Number of bugs completed in this sprint
+	OtherCount       int       // Number of non-bug issues completed
+	TotalCount       int       // Total issues completed
+	BugPercentage    float64   // Percentage of bugs vs total issues
+	BugStoryPoints   float64   // Story points from bugs
+	TotalStoryPoints float64   // Total story points in sprint
+	PointsPercentage float64   // Percentage of bug points vs total points
+	ReopenedInSprint int       // Bugs reopened after the sprint started (zero if the start is unknown)
+	UnestimatedCount int       // Issues without story points (counted at the default estimate, if configured)
 }
@@ -0,0 +1,289 @@
+package domain
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIsBugType(t *testing.T) {
+	bugTypes := []string{"Bug", "Defect"}
+
+	tests := []struct {
+		issueType string
+		want      bool
+	}{
+		{"Bug", true},
+		{"Defect", true},
+		{"defect", true},
+		{"BUG", true},
+		{"Story", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		bug := &Bug{IssueType: tt.issueType}
+		if got := bug.IsBugType(bugTypes); got != tt.want {
+			t.Errorf("IsBugType(%q) = %v, want %v", tt.issueType, got, tt.want)
+		}
+	}
+}
+
+func TestSLARuleMatchesFixVersions(t *testing.T) {
+	rule := &SLARule{Priority: "High", FixVersions: []string{"2.4.0", "2.5.0"}}
+
+	tests := []struct {
+		name     string
+		versions []string
+		want     bool
+	}{
+		{"matching version", []string{"2.4.0"}, true},
+		{"one of several versions", []string{"2.3.0", "2.5.0"}, true},
+		{"other version", []string{"2.3.0"}, false},
+		{"no version", nil, false},
+	}
+
+	for _, tt := range tests {
+		bug := &Bug{Priority: "High", FixVersions: tt.versions}
+		if got := rule.Matches(bug); got != tt.want {
+			t.Errorf("%s: Matches = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Rules without fix versions match any version
+	if !(&SLARule{Priority: "High"}).Matches(&Bug{Priority: "High"}) {
+		t.Error("rule without fix versions should match an unversioned bug")
+	}
+}
+
+func TestSLARuleBreachedTier(t *testing.T) {
+	rule := &SLARule{
+		Priority: "Critical",
+		Tiers: []SLATier{
+			{MaxAgeDays: 7, BucketName: "🔥 ESCALATED", Severity: 1},
+			{MaxAgeDays: 1, BucketName: "🔴 URGENT", Severity: 2},
+			{MaxAgeDays: 30, BucketName: "🚨 EXECUTIVE", Severity: 0},
+		},
+	}
+
+	tests := []struct {
+		ageDays    float64
+		wantBucket string
+	}{
+		{0.5, ""},
+		{2, "🔴 URGENT"},
+		{10, "🔥 ESCALATED"},
+		{45, "🚨 EXECUTIVE"},
+	}
+
+	for _, tt := range tests {
+		bug := &Bug{Priority: "Critical", Updated: time.Now().Add(-time.Duration(tt.ageDays * 24 * float64(time.Hour)))}
+		tier := rule.BreachedTier(bug)
+		got := ""
+		if tier != nil {
+			got = tier.BucketName
+		}
+		if got != tt.wantBucket {
+			t.Errorf("age %.1f days: tier bucket = %q, want %q", tt.ageDays, got, tt.wantBucket)
+		}
+		if violates := rule.Violates(bug); violates != (tt.wantBucket != "") {
+			t.Errorf("age %.1f days: Violates = %v", tt.ageDays, violates)
+		}
+	}
+
+	if got := rule.FirstThreshold(); got != 1 {
+		t.Errorf("FirstThreshold = %v, want 1", got)
+	}
+	// As a RuleMatcher a tiered rule reports its first tier's bucket
+	if got, sev := rule.BucketName(), rule.Severity(); got != "🔴 URGENT" || sev != 2 {
+		t.Errorf("BucketName, Severity = %q, %d, want the 1-day tier", got, sev)
+	}
+	untiered := &SLARule{MaxAgeDays: 3, Bucket: "🟡 STALE", BucketSeverity: 3}
+	if got, sev := untiered.BucketName(), untiered.Severity(); got != "🟡 STALE" || sev != 3 {
+		t.Errorf("untiered BucketName, Severity = %q, %d, want the rule's own bucket", got, sev)
+	}
+}
+
+func TestSLARuleAgeFrom(t *testing.T) {
+	now := time.Now()
+	responded := now.Add(-9 * 24 * time.Hour)
+	bugs := map[string]*Bug{
+		"responded":   {Created: now.Add(-10 * 24 * time.Hour), Updated: now.Add(-24 * time.Hour), FirstResponse: &responded},
+		"unresponded": {Created: now.Add(-10 * 24 * time.Hour), Updated: now.Add(-24 * time.Hour)},
+	}
+
+	tests := []struct {
+		ageFrom string
+		bug     string
+		want    float64
+	}{
+		{"", "responded", 1},
+		{"updated", "unresponded", 1},
+		{"created", "responded", 10},
+		{"created", "unresponded", 10},
+		{"first_response", "responded", 1},
+		{"first_response", "unresponded", 10},
+	}
+
+	for _, tt := range tests {
+		rule := &SLARule{AgeFrom: tt.ageFrom}
+		if got := rule.AgeDays(bugs[tt.bug]); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("age_from %q, %s bug: AgeDays = %.2f, want %.0f", tt.ageFrom, tt.bug, got, tt.want)
+		}
+	}
+
+	// A bug answered within its first-response SLA never violates it, however old it gets
+	rule := &SLARule{AgeFrom: "first_response", MaxAgeDays: 2}
+	if rule.Violates(bugs["responded"]) {
+		t.Error("responded bug violates a 2-day first-response rule, want compliant")
+	}
+	if !rule.Violates(bugs["unresponded"]) {
+		t.Error("unresponded bug after 10 days doesn't violate a 2-day first-response rule")
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	required := []string{"priority", "assignee", "sprint", "story_points", "fix_version"}
+
+	tests := []struct {
+		name string
+		bug  *Bug
+		want []string
+	}{
+		{"complete", &Bug{Priority: "High", Assignee: "Ada", SprintID: "7", StoryPoints: 3, FixVersions: []string{"2.4.0"}}, nil},
+		{"unknown priority", &Bug{Priority: "Unknown", Assignee: "Ada", SprintID: "7", StoryPoints: 3, FixVersions: []string{"2.4.0"}}, []string{"priority"}},
+		{"empty", &Bug{}, required},
+		{"unassigned and unestimated", &Bug{Priority: "Low", SprintID: "7", FixVersions: []string{"2.4.0"}}, []string{"assignee", "story_points"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.bug.MissingFields(required); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: MissingFields = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Only the requested fields are checked
+	if got := (&Bug{}).MissingFields([]string{"assignee"}); !slices.Equal(got, []string{"assignee"}) {
+		t.Errorf("MissingFields(assignee) = %v, want [assignee]", got)
+	}
+}
+
+func TestAgeDaysFutureDated(t *testing.T) {
+	future := time.Now().Add(6 * time.Hour)
+	bug := &Bug{Created: future, Updated: future}
+
+	if age := bug.AgeDays(); age != 0 {
+		t.Errorf("AgeDays = %v, want 0 for a future update time", age)
+	}
+	if age := bug.CreatedAgeDays(); age != 0 {
+		t.Errorf("CreatedAgeDays = %v, want 0 for a future creation time", age)
+	}
+}
+
+func TestCountByStatus(t *testing.T) {
+	bg := &BucketGroup{Buckets: []*Bucket{
+		{Name: "🔴 URGENT", Bugs: []*Bug{{Status: "Backlog"}, {Status: "In Progress"}}},
+		{Name: "🟡 ATTENTION", Bugs: []*Bug{{Status: "Backlog"}, {Status: "Backlog"}, {Status: "Needs Triage"}}},
+		{Name: "📭 EMPTY"},
+	}}
+
+	want := map[string]int{"Backlog": 3, "In Progress": 1, "Needs Triage": 1}
+	if got := bg.CountByStatus(); !maps.Equal(got, want) {
+		t.Errorf("CountByStatus = %v, want %v", got, want)
+	}
+}
+
+func TestBucketNameMatches(t *testing.T) {
+	tests := []struct {
+		bucket, name string
+		want         bool
+	}{
+		{"🔴 URGENT", "🔴 URGENT", true},
+		{"🔴 URGENT", "urgent", true},
+		{"🔴 URGENT", "Urgent", true},
+		{"🔴 URGENT", "URG", false},
+		{"🟡 WARNING", "urgent", false},
+		{"P1 breach", "p1 breach", true},
+	}
+	for _, tt := range tests {
+		if got := BucketNameMatches(tt.bucket, tt.name); got != tt.want {
+			t.Errorf("BucketNameMatches(%q, %q) = %v, want %v", tt.bucket, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBucketGroupOnly(t *testing.T) {
+	urgent := &Bucket{Name: "🔴 URGENT", Severity: 1, Bugs: []*Bug{{Key: "DEMO-1"}, {Key: "DEMO-2"}}}
+	warning := &Bucket{Name: "🟡 WARNING", Severity: 2, Bugs: []*Bug{{Key: "DEMO-3"}}}
+	atRisk := []*AtRiskBug{{Bug: &Bug{Key: "DEMO-4"}}}
+	bg := &BucketGroup{Buckets: []*Bucket{urgent, warning}, AtRisk: atRisk}
+
+	only := bg.Only("urgent")
+	if len(only.Buckets) != 1 || only.Buckets[0] != urgent {
+		t.Fatalf("Only(urgent) buckets = %v, want just URGENT", only.Buckets)
+	}
+	if len(only.AtRisk) != 1 {
+		t.Errorf("Only(urgent) AtRisk = %v, want the at-risk bugs kept", only.AtRisk)
+	}
+	if got := only.ActiveViolations(); got != 2 {
+		t.Errorf("Only(urgent).ActiveViolations() = %d, want 2", got)
+	}
+	// The full group still counts every bucket for the exit code
+	if got := bg.ActiveViolations(); got != 3 {
+		t.Errorf("ActiveViolations() = %d, want 3", got)
+	}
+
+	if none := bg.Only("hotfix"); len(none.Buckets) != 0 {
+		t.Errorf("Only(hotfix) buckets = %v, want none", none.Buckets)
+	}
+}
+
+func TestBucketGroupSortOrder(t *testing.T) {
+	// newGroup returns buckets in neither severity order
+	newGroup := func() *BucketGroup {
+		return &BucketGroup{Buckets: []*Bucket{
+			{Name: "🟡 ATTENTION", Severity: 2},
+			{Name: SnoozedBucketName, Severity: SnoozedSeverity},
+			{Name: "🔵 REVIEW", Severity: 3},
+			{Name: "🔴 URGENT", Severity: 1},
+		}}
+	}
+	names := func(bg *BucketGroup) []string {
+		var names []string
+		for _, bucket := range bg.Buckets {
+			names = append(names, bucket.Name)
+		}
+		return names
+	}
+
+	asc := newGroup()
+	asc.Sort()
+	if want := []string{"🔴 URGENT", "🟡 ATTENTION", "🔵 REVIEW", SnoozedBucketName}; !slices.Equal(names(asc), want) {
+		t.Errorf("Sort = %v, want %v", names(asc), want)
+	}
+
+	desc := newGroup()
+	desc.SortDescending()
+	if want := []string{"🔵 REVIEW", "🟡 ATTENTION", "🔴 URGENT", SnoozedBucketName}; !slices.Equal(names(desc), want) {
+		t.Errorf("SortDescending = %v, want %v", names(desc), want)
+	}
+}
+
+func TestCreatedAgeDays(t *testing.T) {
+	now := time.Now()
+	bug := &Bug{Created: now.Add(-40 * 24 * time.Hour), Updated: now.Add(-2 * 24 * time.Hour)}
+
+	if got := bug.AgeDays(); math.Abs(got-2) > 0.01 {
+		t.Errorf("AgeDays = %v, want 2 (since the last update)", got)
+	}
+	if got := bug.CreatedAgeDays(); math.Abs(got-40) > 0.01 {
+		t.Errorf("CreatedAgeDays = %v, want 40 (since creation)", got)
+	}
+
+	future := &Bug{Created: now.Add(time.Hour)}
+	if got := future.CreatedAgeDays(); got != 0 {
+		t.Errorf("CreatedAgeDays for a future creation time = %v, want 0", got)
+	}
+}
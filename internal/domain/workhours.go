@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// WorkingHours is a working-time clock: a daily window on working weekdays, excluding holidays
+type WorkingHours struct {
+	StartMinute int                   // Start of the working day, in minutes after midnight
+	EndMinute   int                   // End of the working day, in minutes after midnight
+	Days        map[time.Weekday]bool // Working weekdays
+	Holidays    map[string]bool       // Non-working dates as YYYY-MM-DD
+	Location    *time.Location        // Time zone the window applies in
+}
+
+// DayLength returns the working time in one full working day
+func (w *WorkingHours) DayLength() time.Duration {
+	return time.Duration(w.EndMinute-w.StartMinute) * time.Minute
+}
+
+// isWorkingDay reports whether a date (midnight in w.Location) is a working weekday and not a holiday
+func (w *WorkingHours) isWorkingDay(day time.Time) bool {
+	return w.Days[day.Weekday()] && !w.Holidays[day.Format("2006-01-02")]
+}
+
+// WorkingHoursAge returns the working time between from and to: only time inside the daily
+// window on working days that are not holidays counts (zero if to is not after from)
+func WorkingHoursAge(w *WorkingHours, from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	from, to = from.In(w.Location), to.In(w.Location)
+
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, w.Location)
+	for day.Before(to) {
+		if w.isWorkingDay(day) {
+			// Build the window from the date rather than adding durations, so DST changes don't shift it
+			opening := time.Date(day.Year(), day.Month(), day.Day(), 0, w.StartMinute, 0, 0, w.Location)
+			closing := time.Date(day.Year(), day.Month(), day.Day(), 0, w.EndMinute, 0, 0, w.Location)
+			if start, end := later(from, opening), earlier(to, closing); end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, w.Location)
+	}
+	return total
+}
+
+// later returns the later of two times
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// earlier returns the earlier of two times
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkingHoursAge(t *testing.T) {
+	// 9:00-17:00 Monday to Friday, with Monday 13 January 2025 a holiday
+	w := &WorkingHours{
+		StartMinute: 9 * 60,
+		EndMinute:   17 * 60,
+		Days: map[time.Weekday]bool{
+			time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true,
+		},
+		Holidays: map[string]bool{"2025-01-13": true},
+		Location: time.UTC,
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     time.Duration
+	}{
+		{"within one day", at(7, 10), at(7, 12), 2 * time.Hour},
+		{"overnight", at(7, 16), at(8, 10), 2 * time.Hour},
+		{"outside the window", at(7, 18), at(8, 8), 0},
+		{"across a weekend", at(3, 16), at(6, 10), 2 * time.Hour},
+		{"across a weekend and holiday", at(10, 16), at(14, 10), 2 * time.Hour},
+		{"full week", at(6, 0), at(13, 0), 40 * time.Hour},
+		{"reversed", at(8, 10), at(7, 10), 0},
+	}
+	for _, tt := range tests {
+		if got := WorkingHoursAge(w, tt.from, tt.to); got != tt.want {
+			t.Errorf("%s: WorkingHoursAge(%v, %v) = %v, want %v", tt.name, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -2,29 +2,66 @@ package jira
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/andygrunwald/go-jira"
 	"github.com/neilmpatterson/bug-butler/internal/config"
 	"github.com/neilmpatterson/bug-butler/internal/domain"
+	"golang.org/x/time/rate"
 )
 
 // Client wraps the Jira API client
 type Client struct {
-	client             *jira.Client
-	projectKeys        []string
-	baseURL            string
-	additionalJQL      string
-	sprintBoardFilter  string
-	sprintFieldID      string
-	storyPointsFieldID string
+	client            *jira.Client
+	name              string // Instance display name, recorded as each bug's Source
+	projectKeys       []string
+	baseURL           string
+	additionalJQL     string
+	sprintBoardFilter string
+	fieldIDs          FieldIDs
+	bugIssueTypes     []string
+	limiter           *rate.Limiter // Optional outbound request throttle (nil = unlimited)
+	allFields         bool          // Request every navigable field instead of the needed ones
+	fetchComments     bool          // Request comments with bugs, for comment counts
+	rateLimitWarning  int           // Warn when the remaining rate limit drops below this (0 = disabled)
+	rateLimitWarned   atomic.Bool   // Whether the low rate limit warning was already logged
+	sprintBatchSize   int           // Sprint IDs per sprint issue query
+	sprintConcurrency int           // Max sprint batch queries in flight at once
+	logRequests       bool          // Log each request's JQL and fields at info level
+	pageDelay         time.Duration // Pause between the pages of a search (0 = none)
+	searchPath        string        // API path for JQL searches (DefaultSearchPath unless configured)
 }
 
+// sleep pauses between search pages (replaceable for testing)
+var sleep = time.Sleep
+
+// Jira Cloud REST API v3 paths, relative to the base URL
+// A fixture server standing in for Jira must serve these (the search path is configurable)
+const (
+	MyselfPath        = "/rest/api/3/myself"     // Current user, fetched to verify authentication
+	ProjectPath       = "/rest/api/3/project/"   // Project lookup, followed by the project key
+	DefaultSearchPath = "/rest/api/3/search/jql" // JQL search with nextPageToken pagination
+)
+
+// Sprint issue fetch defaults (see SetSprintBatching)
+const (
+	defaultSprintBatchSize   = 50
+	defaultSprintConcurrency = 4
+)
+
 // NewClient creates a new Jira client with authentication
 func NewClient(cfg config.JiraConfig) (*Client, error) {
 	// Create basic auth transport
@@ -33,41 +70,251 @@ func NewClient(cfg config.JiraConfig) (*Client, error) {
 		Password: cfg.APIToken,
 	}
 
+	// Send configured extra headers (e.g., a gateway token) and impersonation on every request
+	headers := maps.Clone(cfg.ExtraHeaders)
+	if cfg.ImpersonateAccountID != "" {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[ImpersonateHeader] = cfg.ImpersonateAccountID
+		slog.Debug("Impersonation enabled", "account_id", cfg.ImpersonateAccountID)
+	}
+	if len(headers) > 0 {
+		tp.Transport = &headerTransport{headers: headers}
+		slog.Debug("Adding headers to Jira requests", "headers", slices.Sorted(maps.Keys(headers)))
+	}
+
 	// Create Jira client
 	client, err := jira.NewClient(tp.Client(), cfg.BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Jira client: %w", err)
 	}
 
+	c := &Client{
+		client:            client,
+		name:              cfg.Name,
+		projectKeys:       cfg.ProjectKeys,
+		baseURL:           cfg.BaseURL,
+		additionalJQL:     cfg.AdditionalJQL,
+		sprintBoardFilter: "", // Will be set by SetSprintBoardFilter if needed
+		fieldIDs: FieldIDs{
+			Sprint:        cfg.CustomFieldIDs.Sprint,
+			StoryPoints:   cfg.CustomFieldIDs.StoryPoints,
+			FirstResponse: cfg.CustomFieldIDs.FirstResponse,
+			EpicLink:      cfg.CustomFieldIDs.EpicLink,
+			Priority:      cfg.PriorityFallback,
+			Resolved:      cfg.ResolutionDateField,
+			Extra:         cfg.ExtraFields,
+			Aliases:       cfg.PriorityAliases,
+		},
+		bugIssueTypes:     cfg.BugIssueTypes,
+		rateLimitWarning:  cfg.RateLimitWarning,
+		pageDelay:         time.Duration(cfg.PageDelayMS) * time.Millisecond,
+		searchPath:        DefaultSearchPath,
+		sprintBatchSize:   defaultSprintBatchSize,
+		sprintConcurrency: defaultSprintConcurrency,
+	}
+
+	if cfg.SearchPath != "" {
+		c.searchPath = cfg.SearchPath
+		slog.Debug("Using custom search path", "path", cfg.SearchPath)
+	}
+
+	// Throttle outbound requests if a rate is configured (unlimited by default)
+	if cfg.RequestsPerSecond > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), 1)
+		slog.Debug("Request rate limiting enabled", "requests_per_second", cfg.RequestsPerSecond)
+	}
+
 	// Verify authentication by fetching current user using API v3
-	req, err := client.NewRequest("GET", "/rest/api/3/myself", nil)
+	req, err := client.NewRequest("GET", MyselfPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth request: %w", err)
 	}
 
-	_, err = client.Do(req, nil)
+	_, err = c.do(req, nil)
 	if err != nil {
 		return nil, fmt.Errorf("authentication failed (check email and API token): %w", err)
 	}
 
 	slog.Debug("Successfully authenticated with Jira", "base_url", cfg.BaseURL, "email", cfg.Email)
 
-	c := &Client{
-		client:             client,
-		projectKeys:        cfg.ProjectKeys,
-		baseURL:            cfg.BaseURL,
-		additionalJQL:      cfg.AdditionalJQL,
-		sprintBoardFilter:  "", // Will be set by SetSprintBoardFilter if needed
-		sprintFieldID:      cfg.CustomFieldIDs.Sprint,
-		storyPointsFieldID: cfg.CustomFieldIDs.StoryPoints,
+	return c, nil
+}
+
+// ImpersonateHeader carries the account ID the client acts as when impersonation is configured
+const ImpersonateHeader = "X-Atlassian-Impersonate"
+
+// headerTransport sets fixed headers on every outgoing request
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper // nil = http.DefaultTransport
+}
+
+// RoundTrip clones the request, sets the headers and delegates to the base transport
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
 	}
 
-	return c, nil
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// do executes a Jira API request, waiting for the rate limiter first if one is configured
+func (c *Client) do(req *http.Request, v interface{}) (*jira.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+		}
+	}
+	if c.logRequests {
+		logRequest(c.name, req)
+	}
+	resp, err := c.client.Do(req, v)
+	if resp != nil {
+		c.checkRateLimit(resp.Header)
+	}
+	return resp, err
+}
+
+// SetLogRequests enables logging each request's method, path, JQL, and fields at info level
+func (c *Client) SetLogRequests(enabled bool) {
+	c.logRequests = enabled
 }
 
+// logRequest logs a request's query parameters for support tickets
+// Only the URL is logged: credentials travel in headers, which are never logged
+func logRequest(instance string, req *http.Request) {
+	attrs := []any{"instance", instance, "method", req.Method, "path", req.URL.Path}
+	query := req.URL.Query()
+	for _, param := range []string{"jql", "fields", "expand", "nextPageToken"} {
+		if value := query.Get(param); value != "" {
+			attrs = append(attrs, param, value)
+		}
+	}
+	slog.Info("Jira request", attrs...)
+}
+
+// RateLimitRemainingHeader reports how many requests are left in Jira's current rate limit window
+const RateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+// checkRateLimit warns once when the remaining rate limit drops below the configured threshold
+func (c *Client) checkRateLimit(header http.Header) {
+	if c.rateLimitWarning <= 0 {
+		return
+	}
+
+	value := header.Get(RateLimitRemainingHeader)
+	if value == "" {
+		return
+	}
+	remaining, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Debug("Ignoring unparseable rate limit header", "header", RateLimitRemainingHeader, "value", value)
+		return
+	}
+	if remaining >= c.rateLimitWarning {
+		return
+	}
+
+	// Paginated fetches would otherwise repeat the warning on every page
+	if !c.rateLimitWarned.CompareAndSwap(false, true) {
+		slog.Debug("Jira rate limit still low", "instance", c.name, "remaining", remaining)
+		return
+	}
+	slog.Warn("Approaching Jira rate limit; consider spacing out scheduled runs or lowering requests_per_second",
+		"instance", c.name,
+		"remaining", remaining,
+		"threshold", c.rateLimitWarning,
+	)
+}
+
+// Name returns the display name of the Jira instance
+func (c *Client) Name() string {
+	return c.name
+}
+
+// ValidateProjects checks that every configured project exists and is visible to the user
+// Jira returns zero results for unknown project keys in some queries, masking typos
+func (c *Client) ValidateProjects() error {
+	var missing []string
+	for _, key := range c.projectKeys {
+		req, err := c.client.NewRequest("GET", ProjectPath+url.PathEscape(key), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create project request: %w", err)
+		}
+
+		resp, err := c.do(req, nil)
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				missing = append(missing, key)
+				continue
+			}
+			return fmt.Errorf("failed to check project %s: %w", key, err)
+		}
+
+		slog.Debug("Verified project exists", "project", key)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("projects not found or not accessible: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// orderByClause matches a trailing ORDER BY clause, as board filters copied from Jira usually have
+var orderByClause = regexp.MustCompile(`(?is)\s*\bORDER\s+BY\b.*$`)
+
 // SetSprintBoardFilter sets the board filter for sprint queries
+// A trailing ORDER BY clause is dropped so the filter can be combined with the sprint JQL
 func (c *Client) SetSprintBoardFilter(filter string) {
-	c.sprintBoardFilter = filter
+	c.sprintBoardFilter = strings.TrimSpace(orderByClause.ReplaceAllString(filter, ""))
+}
+
+// SetSprintBatching splits sprint issue fetches into queries of batchSize sprint IDs, running up to
+// concurrency of them at once (values <= 0 keep the defaults)
+func (c *Client) SetSprintBatching(batchSize, concurrency int) {
+	if batchSize > 0 {
+		c.sprintBatchSize = batchSize
+	}
+	if concurrency > 0 {
+		c.sprintConcurrency = concurrency
+	}
+}
+
+// bugTypeClause builds the JQL clause matching the configured bug issue types
+func (c *Client) bugTypeClause() string {
+	if len(c.bugIssueTypes) == 0 {
+		return "type = Bug"
+	}
+
+	return fmt.Sprintf("type in (%s)", jqlList(c.bugIssueTypes))
+}
+
+// jqlQuote quotes a value as a JQL string literal, escaping quotes and backslashes
+// Quoting keeps keys and names with spaces or reserved words (e.g., "AND") valid
+func jqlQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// jqlList quotes each value and joins them for use in a JQL "in (...)" clause
+func jqlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = jqlQuote(value)
+	}
+	return strings.Join(quoted, ", ")
 }
 
 // searchResponse represents the API v3 search/jql response with cursor pagination
@@ -77,51 +324,80 @@ type searchResponse struct {
 	Total         int          `json:"total"`
 }
 
+// ProgressFunc is called after each page of search results is fetched
+type ProgressFunc func(page, fetched int)
+
+// Standard Jira fields requested by each fetch (custom and extra fields are appended by requestFields)
+var (
+	bugFields         = []string{"summary", "priority", "status", "created", "updated", "fixVersions", "versions", "assignee", "parent", "labels"}
+	dateRangeFields   = []string{"priority", "status", "created", "updated", "resolution", "resolutiondate", "issuetype", "fixVersions", "versions", "components"}
+	sprintIssueFields = []string{"issuetype", "resolution", "resolutiondate"}
+	resolvedFields    = []string{"summary", "priority", "status", "created", "resolution", "resolutiondate", "issuetype", "fixVersions"}
+)
+
+// allFieldsParam requests every field shown in the Jira UI (much larger responses)
+const allFieldsParam = "*navigable"
+
+// SetAllFields requests every navigable field and keeps all custom fields on the bugs (for raw dumps)
+func (c *Client) SetAllFields(all bool) {
+	c.allFields = all
+	c.fieldIDs.AllCustom = all
+}
+
+// SetFetchComments requests each bug's comments so CommentCount is populated
+// Comments make responses much larger, so they're only fetched when something uses the count
+func (c *Client) SetFetchComments(fetch bool) {
+	c.fetchComments = fetch
+}
+
+// bugFieldList returns the standard fields requested for bugs, with comments when enabled
+func (c *Client) bugFieldList() []string {
+	fields := slices.Clone(bugFields)
+	if c.fetchComments {
+		fields = append(fields, "comment")
+	}
+	return fields
+}
+
+// requestFields returns the comma-separated field list for a search: the given
+// standard fields plus the configured custom field IDs and extra fields
+func (c *Client) requestFields(base []string) string {
+	if c.allFields {
+		return allFieldsParam
+	}
+
+	fields := slices.Clone(base)
+	for _, id := range append([]string{c.fieldIDs.Sprint, c.fieldIDs.StoryPoints, c.fieldIDs.FirstResponse, c.fieldIDs.EpicLink, c.fieldIDs.Priority, c.fieldIDs.Resolved}, c.fieldIDs.Extra...) {
+		if id != "" && !slices.Contains(fields, id) {
+			fields = append(fields, id)
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
 // FetchBugs retrieves all unresolved bugs from the configured project(s) using API v3
 func (c *Client) FetchBugs() ([]*domain.Bug, error) {
-	return c.FetchBugsWithFilters(nil, nil)
+	return c.FetchBugsWithFilters(nil, nil, nil, nil)
 }
 
-// FetchBugsWithFilters retrieves unresolved bugs with optional priority and status filters
-func (c *Client) FetchBugsWithFilters(priorities, statuses []string) ([]*domain.Bug, error) {
+// FetchBugsWithFilters retrieves unresolved bugs with optional priority, status, and fix version filters
+func (c *Client) FetchBugsWithFilters(priorities, statuses, fixVersions []string, progress ProgressFunc) ([]*domain.Bug, error) {
 	// Build JQL query to fetch unresolved bugs
-	var jql string
-	if len(c.projectKeys) == 1 {
-		jql = fmt.Sprintf("project = %s AND statusCategory != done AND type = Bug", c.projectKeys[0])
-	} else {
-		// Multiple projects - use "project in (...)" syntax
-		projects := ""
-		for i, key := range c.projectKeys {
-			if i > 0 {
-				projects += ", "
-			}
-			projects += fmt.Sprintf("\"%s\"", key)
-		}
-		jql = fmt.Sprintf("project in (%s) AND statusCategory != done AND type = Bug", projects)
-	}
+	jql := fmt.Sprintf("%s AND statusCategory != done AND %s", c.projectClause(), c.bugTypeClause())
 
 	// Add priority filter if specified
 	if len(priorities) > 0 {
-		priorityList := ""
-		for i, p := range priorities {
-			if i > 0 {
-				priorityList += ", "
-			}
-			priorityList += fmt.Sprintf("\"%s\"", p)
-		}
-		jql += fmt.Sprintf(" AND priority in (%s)", priorityList)
+		jql += fmt.Sprintf(" AND priority in (%s)", jqlList(priorities))
 	}
 
 	// Add status filter if specified
 	if len(statuses) > 0 {
-		statusList := ""
-		for i, s := range statuses {
-			if i > 0 {
-				statusList += ", "
-			}
-			statusList += fmt.Sprintf("\"%s\"", s)
-		}
-		jql += fmt.Sprintf(" AND status in (%s)", statusList)
+		jql += fmt.Sprintf(" AND status in (%s)", jqlList(statuses))
+	}
+
+	// Add fix version filter if specified
+	if len(fixVersions) > 0 {
+		jql += fmt.Sprintf(" AND fixVersion in (%s)", jqlList(fixVersions))
 	}
 
 	// Append additional JQL filters if configured
@@ -133,105 +409,135 @@ func (c *Client) FetchBugsWithFilters(priorities, statuses []string) ([]*domain.
 
 	slog.Debug("Fetching bugs from Jira", "jql", jql, "projects", c.projectKeys)
 
-	var allBugs []*domain.Bug
-	maxResults := 100 // Fetch in batches of 100
-	var nextPageToken string
-	pageNumber := 0
+	bugs, err := c.searchIssues(jql, c.requestFields(c.bugFieldList()), progress)
+	if err != nil {
+		return bugs, err // Partial results are kept (see PartialResultsError)
+	}
 
-	for {
-		pageNumber++
+	slog.Debug("Successfully fetched bugs", "count", len(bugs))
+	return bugs, nil
+}
 
-		// Build GET request URL with cursor-based pagination
-		params := url.Values{}
-		params.Set("jql", jql)
-		params.Set("maxResults", strconv.Itoa(maxResults))
-		params.Set("fields", "summary,priority,status,created,updated")
+// FetchBugsByJQL retrieves issues matching a user-supplied JQL query, used verbatim
+// Issues that aren't of a configured bug type are kept but logged as a warning
+func (c *Client) FetchBugsByJQL(jql string, progress ProgressFunc) ([]*domain.Bug, error) {
+	slog.Debug("Fetching bugs from Jira with custom JQL", "jql", jql)
 
-		// Add nextPageToken if we have one (not the first page)
-		if nextPageToken != "" {
-			params.Set("nextPageToken", nextPageToken)
+	// The issue type is needed to check that the query really returns bugs
+	fields := append(c.bugFieldList(), "issuetype")
+	bugs, err := c.searchIssues(jql, c.requestFields(fields), progress)
+	if err != nil {
+		return bugs, err // Partial results are kept (see PartialResultsError)
+	}
+
+	bugTypes := c.bugIssueTypes
+	if len(bugTypes) == 0 {
+		bugTypes = []string{"Bug"}
+	}
+	var nonBugs []string
+	for _, bug := range bugs {
+		if !bug.IsBugType(bugTypes) {
+			nonBugs = append(nonBugs, bug.Key)
 		}
+	}
+	if len(nonBugs) > 0 {
+		slog.Warn("Custom JQL returned issues that are not bugs; they are evaluated anyway",
+			"count", len(nonBugs),
+			"bug_issue_types", bugTypes,
+			"examples", nonBugs[:min(len(nonBugs), 5)],
+		)
+	}
 
-		apiURL := "/rest/api/3/search/jql?" + params.Encode()
+	slog.Debug("Successfully fetched bugs", "count", len(bugs))
+	return bugs, nil
+}
 
-		// Create GET request with cursor pagination
-		req, err := c.client.NewRequest("GET", apiURL, nil)
+// projectClause returns the JQL condition matching the configured project(s), with keys quoted
+func (c *Client) projectClause() string {
+	if len(c.projectKeys) == 1 {
+		return fmt.Sprintf("project = %s", jqlQuote(c.projectKeys[0]))
+	}
+	return fmt.Sprintf("project in (%s)", jqlList(c.projectKeys))
+}
+
+// FetchMatchingKeys returns the keys of unresolved bugs in the configured projects matching a JQL condition
+func (c *Client) FetchMatchingKeys(condition string) (map[string]bool, error) {
+	jql := fmt.Sprintf("%s AND statusCategory != done AND %s AND (%s)", c.projectClause(), c.bugTypeClause(), condition)
+
+	slog.Debug("Fetching keys matching JQL", "jql", jql)
+
+	issues, err := c.searchIssues(jql, "summary", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		keys[issue.Key] = true
+	}
+	return keys, nil
+}
+
+// TagBugs runs each tag query and tags the bugs whose keys it matched
+func (c *Client) TagBugs(bugs []*domain.Bug, tags []config.TagQuery) error {
+	for _, tag := range tags {
+		keys, err := c.FetchMatchingKeys(tag.JQL)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create search request: %w", err)
+			return fmt.Errorf("failed to run tag query %q: %w", tag.Name, err)
 		}
 
-		// Execute request and read response body
-		var searchResp searchResponse
-		resp, err := c.client.Do(req, &searchResp)
-		if err != nil {
-			// Try to read response body for more details
-			if resp != nil && resp.Body != nil {
-				bodyBytes, readErr := io.ReadAll(resp.Body)
-				resp.Body.Close()
-				if readErr == nil {
-					slog.Error("API request failed",
-						"status_code", resp.StatusCode,
-						"response_body", string(bodyBytes),
-						"request_url", req.URL.String(),
-					)
-					return nil, fmt.Errorf("failed to search for issues (status %d): %s", resp.StatusCode, string(bodyBytes))
-				}
+		tagged := 0
+		for _, bug := range bugs {
+			if keys[bug.Key] {
+				bug.AddTag(tag.Name)
+				tagged++
 			}
-			return nil, fmt.Errorf("failed to search for issues: %w", err)
 		}
-		defer resp.Body.Close()
+		slog.Debug("Applied tag", "tag", tag.Name, "matched", len(keys), "tagged", tagged)
+	}
 
-		slog.Debug("Fetched page",
-			"page", pageNumber,
-			"count", len(searchResp.Issues),
-		)
+	return nil
+}
 
-		// Convert Jira issues to domain bugs
-		for _, issue := range searchResp.Issues {
-			bug, err := MapIssueToBug(&issue, c.baseURL, c.sprintFieldID, c.storyPointsFieldID)
-			if err != nil {
-				slog.Warn("Failed to map issue to bug", "issue_key", issue.Key, "error", err)
-				continue
-			}
-			allBugs = append(allBugs, bug)
-		}
+// parentBatchSize is how many parent keys are looked up per query, to stay under JQL length limits
+const parentBatchSize = 50
 
-		// Check if there are more pages using nextPageToken
-		if searchResp.NextPageToken == "" {
-			break
+// AnnotateParentStatuses fetches the current status of each bug's parent issue into ParentStatus
+func (c *Client) AnnotateParentStatuses(bugs []*domain.Bug) error {
+	var keys []string
+	for _, bug := range bugs {
+		if bug.ParentKey != "" && !slices.Contains(keys, bug.ParentKey) {
+			keys = append(keys, bug.ParentKey)
 		}
+	}
 
-		// Update token for next iteration
-		nextPageToken = searchResp.NextPageToken
+	statuses := make(map[string]string, len(keys))
+	for batch := range slices.Chunk(keys, parentBatchSize) {
+		parents, err := c.searchIssues(fmt.Sprintf("key in (%s)", jqlList(batch)), "status", nil)
+		if err != nil {
+			return err
+		}
+		for _, parent := range parents {
+			statuses[parent.Key] = parent.Status
+		}
 	}
 
-	slog.Debug("Successfully fetched bugs", "count", len(allBugs))
-	return allBugs, nil
+	for _, bug := range bugs {
+		bug.ParentStatus = statuses[bug.ParentKey]
+	}
+	slog.Debug("Fetched parent statuses", "parents", len(keys), "found", len(statuses))
+	return nil
 }
 
 // FetchBugsByDateRange retrieves all bugs created within a date range (including resolved bugs)
-func (c *Client) FetchBugsByDateRange(startDate, endDate time.Time) ([]*domain.Bug, error) {
+func (c *Client) FetchBugsByDateRange(startDate, endDate time.Time, progress ProgressFunc) ([]*domain.Bug, error) {
 	// Format dates for JQL: YYYY-MM-DD
 	start := startDate.Format("2006-01-02")
 	end := endDate.Format("2006-01-02")
 
 	// Build JQL query to fetch ALL bugs in date range (no status filter)
-	var jql string
-	if len(c.projectKeys) == 1 {
-		jql = fmt.Sprintf("project = %s AND type = Bug AND created >= %s AND created < %s",
-			c.projectKeys[0], start, end)
-	} else {
-		// Multiple projects - use "project in (...)" syntax
-		projects := ""
-		for i, key := range c.projectKeys {
-			if i > 0 {
-				projects += ", "
-			}
-			projects += fmt.Sprintf("\"%s\"", key)
-		}
-		jql = fmt.Sprintf("project in (%s) AND type = Bug AND created >= %s AND created < %s",
-			projects, start, end)
-	}
+	jql := fmt.Sprintf("%s AND %s AND created >= %s AND created < %s",
+		c.projectClause(), c.bugTypeClause(), start, end)
 
 	// Append additional JQL filters if configured
 	if c.additionalJQL != "" {
@@ -242,115 +548,116 @@ func (c *Client) FetchBugsByDateRange(startDate, endDate time.Time) ([]*domain.B
 
 	slog.Debug("Fetching bugs by date range", "jql", jql, "start", start, "end", end)
 
-	var allBugs []*domain.Bug
-	maxResults := 100
-	var nextPageToken string
-	pageNumber := 0
-
-	for {
-		pageNumber++
-
-		// Build GET request URL with cursor-based pagination
-		params := url.Values{}
-		params.Set("jql", jql)
-		params.Set("maxResults", strconv.Itoa(maxResults))
-		params.Set("fields", fmt.Sprintf("priority,created,resolution,resolutiondate,issuetype,%s,%s", c.sprintFieldID, c.storyPointsFieldID))
+	bugs, err := c.searchIssues(jql, c.requestFields(dateRangeFields), progress)
+	if err != nil {
+		return bugs, err // Partial results are kept (see PartialResultsError)
+	}
 
-		// Add nextPageToken if we have one (not the first page)
-		if nextPageToken != "" {
-			params.Set("nextPageToken", nextPageToken)
-		}
+	slog.Debug("Successfully fetched bugs by date range", "count", len(bugs))
+	return bugs, nil
+}
 
-		apiURL := "/rest/api/3/search/jql?" + params.Encode()
+// FetchResolvedBugs retrieves bugs resolved within a date range (end exclusive)
+func (c *Client) FetchResolvedBugs(startDate, endDate time.Time, progress ProgressFunc) ([]*domain.Bug, error) {
+	start := startDate.Format("2006-01-02")
+	end := endDate.Format("2006-01-02")
 
-		// Create GET request with cursor pagination
-		req, err := c.client.NewRequest("GET", apiURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create search request: %w", err)
-		}
+	jql := fmt.Sprintf("%s AND %s AND resolutiondate >= %s AND resolutiondate < %s",
+		c.projectClause(), c.bugTypeClause(), start, end)
 
-		// Execute request and read response body
-		var searchResp searchResponse
-		resp, err := c.client.Do(req, &searchResp)
-		if err != nil {
-			// Try to read response body for more details
-			if resp != nil && resp.Body != nil {
-				bodyBytes, readErr := io.ReadAll(resp.Body)
-				resp.Body.Close()
-				if readErr == nil {
-					slog.Error("API request failed",
-						"status_code", resp.StatusCode,
-						"response_body", string(bodyBytes),
-						"request_url", req.URL.String(),
-					)
-					return nil, fmt.Errorf("failed to search for issues (status %d): %s", resp.StatusCode, string(bodyBytes))
-				}
-			}
-			return nil, fmt.Errorf("failed to search for issues: %w", err)
-		}
-		defer resp.Body.Close()
+	// Append additional JQL filters if configured
+	if c.additionalJQL != "" {
+		jql += " " + c.additionalJQL
+	}
 
-		slog.Debug("Fetched page",
-			"page", pageNumber,
-			"count", len(searchResp.Issues),
-		)
+	jql += " ORDER BY resolutiondate ASC"
 
-		// Convert Jira issues to domain bugs
-		for _, issue := range searchResp.Issues {
-			bug, err := MapIssueToBug(&issue, c.baseURL, c.sprintFieldID, c.storyPointsFieldID)
-			if err != nil {
-				slog.Warn("Failed to map issue to bug", "issue_key", issue.Key, "error", err)
-				continue
-			}
-			allBugs = append(allBugs, bug)
-		}
+	slog.Debug("Fetching resolved bugs", "jql", jql, "start", start, "end", end)
 
-		// Check if there are more pages using nextPageToken
-		if searchResp.NextPageToken == "" {
-			break
-		}
-
-		// Update token for next iteration
-		nextPageToken = searchResp.NextPageToken
+	bugs, err := c.searchIssues(jql, c.requestFields(resolvedFields), progress)
+	if err != nil {
+		return bugs, err // Partial results are kept (see PartialResultsError)
 	}
 
-	slog.Debug("Successfully fetched bugs by date range", "count", len(allBugs))
-	return allBugs, nil
+	slog.Debug("Successfully fetched resolved bugs", "count", len(bugs))
+	return bugs, nil
 }
 
 // FetchIssuesBySprints retrieves all done issues for the specified sprint IDs
-func (c *Client) FetchIssuesBySprints(sprintIDs []string) ([]*domain.Bug, error) {
+// The IDs are queried in concurrent batches (see SetSprintBatching) and the results merged
+// If any batch fails the whole fetch fails, naming the sprints that could not be fetched
+func (c *Client) FetchIssuesBySprints(sprintIDs []string, progress ProgressFunc) ([]*domain.Bug, error) {
 	if len(sprintIDs) == 0 {
 		return []*domain.Bug{}, nil
 	}
 
-	// Build JQL query to fetch done issues for specific sprints
-	// Sprint custom field is typically customfield_10020
-	sprintList := ""
-	for i, id := range sprintIDs {
-		if i > 0 {
-			sprintList += ", "
+	// Long sprint lists can exceed JQL length limits, so query them in batches
+	batches := slices.Collect(slices.Chunk(sprintIDs, c.sprintBatchSize))
+	slog.Debug("Fetching issues by sprints",
+		"sprint_count", len(sprintIDs),
+		"batches", len(batches),
+		"concurrency", c.sprintConcurrency,
+	)
+
+	results := make([][]*domain.Bug, len(batches))
+	errs := make([]error, len(batches))
+	tracker := newBatchProgress(len(batches), progress)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.sprintConcurrency)
+	for i, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			// The changelog shows which issues were reopened during the sprint
+			results[i], errs[i] = c.searchExpanded(c.sprintJQL(batch), c.requestFields(sprintIssueFields), "changelog", tracker.forBatch(i))
+		}()
+	}
+	wg.Wait()
+
+	// Stats missing whole sprints would be silently wrong, so any failed batch fails the fetch
+	var failed []string
+	var firstErr error
+	for i, batch := range batches {
+		if errs[i] != nil {
+			failed = append(failed, batch...)
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+		}
+	}
+	if firstErr != nil {
+		// A batch cut short mid-pagination is not a usable partial result either (see checkPartial)
+		var partial *PartialResultsError
+		if errors.As(firstErr, &partial) {
+			firstErr = partial.Err
 		}
-		sprintList += id
+		return nil, fmt.Errorf("failed to fetch issues for sprints %s: %w", strings.Join(failed, ", "), firstErr)
 	}
 
-	var jql string
-	if len(c.projectKeys) == 1 {
-		jql = fmt.Sprintf("project = %s AND sprint in (%s) AND statusCategory = done",
-			c.projectKeys[0], sprintList)
-	} else {
-		// Multiple projects - use "project in (...)" syntax
-		projects := ""
-		for i, key := range c.projectKeys {
-			if i > 0 {
-				projects += ", "
+	// Merge in batch order; issues in several sprints can come back from more than one batch
+	var issues []*domain.Bug
+	seen := make(map[string]bool)
+	for i := range batches {
+		for _, issue := range results[i] {
+			if !seen[issue.Key] {
+				seen[issue.Key] = true
+				issues = append(issues, issue)
 			}
-			projects += fmt.Sprintf("\"%s\"", key)
 		}
-		jql = fmt.Sprintf("project in (%s) AND sprint in (%s) AND statusCategory = done",
-			projects, sprintList)
 	}
 
+	slog.Debug("Successfully fetched issues by sprints", "count", len(issues))
+	return issues, nil
+}
+
+// sprintJQL builds the query for done issues in the given sprints
+func (c *Client) sprintJQL(sprintIDs []string) string {
+	jql := fmt.Sprintf("%s AND sprint in (%s) AND statusCategory = done",
+		c.projectClause(), strings.Join(sprintIDs, ", "))
+
 	// NOTE: We do NOT apply additional_jql here because sprint stats need ALL issues
 	// (bugs + other types), not just filtered bugs. The additional_jql is meant for
 	// bug-specific filtering and would incorrectly exclude Stories/Tasks/etc.
@@ -363,10 +670,66 @@ func (c *Client) FetchIssuesBySprints(sprintIDs []string) ([]*domain.Bug, error)
 
 	jql += " ORDER BY resolutiondate DESC"
 
-	slog.Debug("Fetching issues by sprints", "jql", jql, "sprint_count", len(sprintIDs))
+	slog.Debug("Sprint batch query", "jql", jql, "sprint_count", len(sprintIDs))
+	return jql
+}
+
+// batchProgress combines page progress from concurrent batch searches into one ProgressFunc
+type batchProgress struct {
+	mu       sync.Mutex
+	progress ProgressFunc
+	pages    int   // Pages fetched across all batches
+	fetched  []int // Issues fetched so far per batch
+}
+
+// newBatchProgress tracks n batches, reporting combined totals to progress (which may be nil)
+func newBatchProgress(n int, progress ProgressFunc) *batchProgress {
+	return &batchProgress{progress: progress, fetched: make([]int, n)}
+}
+
+// forBatch returns the ProgressFunc for a single batch's search
+func (b *batchProgress) forBatch(i int) ProgressFunc {
+	return func(_, fetched int) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.pages++
+		b.fetched[i] = fetched
+		if b.progress != nil {
+			total := 0
+			for _, n := range b.fetched {
+				total += n
+			}
+			b.progress(b.pages, total)
+		}
+	}
+}
+
+// PartialResultsError reports a search that failed after some pages were already fetched
+// The search returns the issues fetched so far alongside it, so callers can choose to proceed
+type PartialResultsError struct {
+	Pages   int   // Pages fetched successfully before the failure
+	Fetched int   // Issues fetched successfully before the failure
+	Err     error // The error that stopped pagination
+}
+
+func (e *PartialResultsError) Error() string {
+	return fmt.Sprintf("search failed on page %d after fetching %d issues: %v", e.Pages+1, e.Fetched, e.Err)
+}
+
+func (e *PartialResultsError) Unwrap() error {
+	return e.Err
+}
 
+// searchIssues runs a paginated JQL search and maps every result to a domain bug
+// If a page after the first fails, the issues fetched so far are returned with a *PartialResultsError
+func (c *Client) searchIssues(jql string, fields string, progress ProgressFunc) ([]*domain.Bug, error) {
+	return c.searchExpanded(jql, fields, "", progress)
+}
+
+// searchExpanded is searchIssues with an expand parameter (e.g., "changelog"; empty for none)
+func (c *Client) searchExpanded(jql, fields, expand string, progress ProgressFunc) ([]*domain.Bug, error) {
 	var allIssues []*domain.Bug
-	maxResults := 100
+	maxResults := 100 // Fetch in batches of 100
 	var nextPageToken string
 	pageNumber := 0
 
@@ -377,14 +740,17 @@ func (c *Client) FetchIssuesBySprints(sprintIDs []string) ([]*domain.Bug, error)
 		params := url.Values{}
 		params.Set("jql", jql)
 		params.Set("maxResults", strconv.Itoa(maxResults))
-		params.Set("fields", fmt.Sprintf("issuetype,resolution,resolutiondate,%s,%s", c.sprintFieldID, c.storyPointsFieldID))
+		params.Set("fields", fields)
+		if expand != "" {
+			params.Set("expand", expand)
+		}
 
 		// Add nextPageToken if we have one (not the first page)
 		if nextPageToken != "" {
 			params.Set("nextPageToken", nextPageToken)
 		}
 
-		apiURL := "/rest/api/3/search/jql?" + params.Encode()
+		apiURL := c.searchPath + "?" + params.Encode()
 
 		// Create GET request with cursor pagination
 		req, err := c.client.NewRequest("GET", apiURL, nil)
@@ -394,24 +760,15 @@ func (c *Client) FetchIssuesBySprints(sprintIDs []string) ([]*domain.Bug, error)
 
 		// Execute request and read response body
 		var searchResp searchResponse
-		resp, err := c.client.Do(req, &searchResp)
+		resp, err := c.do(req, &searchResp)
 		if err != nil {
-			// Try to read response body for more details
-			if resp != nil && resp.Body != nil {
-				bodyBytes, readErr := io.ReadAll(resp.Body)
-				resp.Body.Close()
-				if readErr == nil {
-					slog.Error("API request failed",
-						"status_code", resp.StatusCode,
-						"response_body", string(bodyBytes),
-						"request_url", req.URL.String(),
-					)
-					return nil, fmt.Errorf("failed to search for issues (status %d): %s", resp.StatusCode, string(bodyBytes))
-				}
+			err = searchError(req, resp, err)
+			if pageNumber == 1 {
+				return nil, err
 			}
-			return nil, fmt.Errorf("failed to search for issues: %w", err)
+			return allIssues, &PartialResultsError{Pages: pageNumber - 1, Fetched: len(allIssues), Err: err}
 		}
-		defer resp.Body.Close()
+		resp.Body.Close()
 
 		slog.Debug("Fetched page",
 			"page", pageNumber,
@@ -420,14 +777,20 @@ func (c *Client) FetchIssuesBySprints(sprintIDs []string) ([]*domain.Bug, error)
 
 		// Convert Jira issues to domain bugs (reusing the same struct for all issue types)
 		for _, issue := range searchResp.Issues {
-			bug, err := MapIssueToBug(&issue, c.baseURL, c.sprintFieldID, c.storyPointsFieldID)
+			bug, err := MapIssueToBug(&issue, c.baseURL, c.fieldIDs)
 			if err != nil {
 				slog.Warn("Failed to map issue", "issue_key", issue.Key, "error", err)
 				continue
 			}
+			bug.Source = c.name
 			allIssues = append(allIssues, bug)
 		}
 
+		// Report pagination progress
+		if progress != nil {
+			progress(pageNumber, len(allIssues))
+		}
+
 		// Check if there are more pages using nextPageToken
 		if searchResp.NextPageToken == "" {
 			break
@@ -435,12 +798,34 @@ func (c *Client) FetchIssuesBySprints(sprintIDs []string) ([]*domain.Bug, error)
 
 		// Update token for next iteration
 		nextPageToken = searchResp.NextPageToken
+
+		// Spread page requests out to avoid bursts if configured
+		if c.pageDelay > 0 {
+			sleep(c.pageDelay)
+		}
 	}
 
-	slog.Debug("Successfully fetched issues by sprints", "count", len(allIssues))
 	return allIssues, nil
 }
 
+// searchError builds a search failure error, including the response body when available
+func searchError(req *http.Request, resp *jira.Response, err error) error {
+	// Try to read response body for more details
+	if resp != nil && resp.Body != nil {
+		bodyBytes, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr == nil {
+			slog.Error("API request failed",
+				"status_code", resp.StatusCode,
+				"response_body", string(bodyBytes),
+				"request_url", req.URL.String(),
+			)
+			return fmt.Errorf("failed to search for issues (status %d): %s", resp.StatusCode, string(bodyBytes))
+		}
+	}
+	return fmt.Errorf("failed to search for issues: %w", err)
+}
+
 // parseSearchResponse parses the JSON response from API v3
 func parseSearchResponse(data []byte) (*searchResponse, error) {
 	var resp searchResponse
@@ -449,17 +834,3 @@ func parseSearchResponse(data []byte) (*searchResponse, error) {
 	}
 	return &resp, nil
 }
-
-// buildSearchURL builds the API v3 search URL with parameters
-func buildSearchURL(jql string, startAt, maxResults int, fields []string) string {
-	v := url.Values{}
-	v.Set("jql", jql)
-	v.Set("startAt", strconv.Itoa(startAt))
-	v.Set("maxResults", strconv.Itoa(maxResults))
-	if len(fields) > 0 {
-		for _, field := range fields {
-			v.Add("fields", field)
-		}
-	}
-	return "/rest/api/3/search?" + v.Encode()
-}
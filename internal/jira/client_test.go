@@ -0,0 +1,947 @@
+package jira
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestFetchBugsByDateRangeRequestsStatusAndUpdated(t *testing.T) {
+	var fields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		fmt.Fprint(w, `{"issues":[{"key":"DEMO-1","fields":{
+			"summary":"Done without resolution",
+			"priority":{"name":"High"},
+			"status":{"name":"Done"},
+			"created":"2025-01-06T09:00:00.000+0000",
+			"updated":"2025-01-20T09:00:00.000+0000"
+		}}]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	bugs, err := c.FetchBugsByDateRange(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), nil)
+	if err != nil {
+		t.Fatalf("FetchBugsByDateRange: %v", err)
+	}
+
+	requested := strings.Split(fields, ",")
+	for _, want := range []string{"status", "updated"} {
+		if !slices.Contains(requested, want) {
+			t.Errorf("date range fetch fields %v missing %q", requested, want)
+		}
+	}
+
+	if len(bugs) != 1 {
+		t.Fatalf("got %d bugs, want 1", len(bugs))
+	}
+	if bugs[0].Status != "Done" {
+		t.Errorf("Status = %q, want Done", bugs[0].Status)
+	}
+	if bugs[0].Updated.IsZero() {
+		t.Error("Updated is zero, want the fetched update time")
+	}
+}
+
+func TestBugTypeClause(t *testing.T) {
+	tests := []struct {
+		types []string
+		want  string
+	}{
+		{nil, "type = Bug"},
+		{[]string{"Bug", "Defect"}, `type in ("Bug", "Defect")`},
+	}
+
+	for _, tt := range tests {
+		c := &Client{bugIssueTypes: tt.types}
+		if got := c.bugTypeClause(); got != tt.want {
+			t.Errorf("bugTypeClause(%v) = %q, want %q", tt.types, got, tt.want)
+		}
+	}
+}
+
+func TestFetchBugsWithFiltersFixVersion(t *testing.T) {
+	var jql string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql = r.URL.Query().Get("jql")
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	if _, err := c.FetchBugsWithFilters(nil, nil, []string{"2.4.0", "2.5.0"}, nil); err != nil {
+		t.Fatalf("FetchBugsWithFilters: %v", err)
+	}
+	if want := `fixVersion in ("2.4.0", "2.5.0")`; !strings.Contains(jql, want) {
+		t.Errorf("jql = %q, want it to contain %q", jql, want)
+	}
+}
+
+func TestSearchIssuesReportsProgressPerPage(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"issues":[{"key":"DEMO-1","fields":{}},{"key":"DEMO-2","fields":{}}],"nextPageToken":"p2"}`,
+		"p2": `{"issues":[{"key":"DEMO-3","fields":{}}],"nextPageToken":"p3"}`,
+		"p3": `{"issues":[{"key":"DEMO-4","fields":{}}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pages[r.URL.Query().Get("nextPageToken")])
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	type call struct{ page, fetched int }
+	var calls []call
+	bugs, err := c.searchIssues("project = DEMO", "summary", func(page, fetched int) {
+		calls = append(calls, call{page, fetched})
+	})
+	if err != nil {
+		t.Fatalf("searchIssues: %v", err)
+	}
+
+	if len(bugs) != 4 {
+		t.Errorf("got %d bugs, want 4", len(bugs))
+	}
+	if want := []call{{1, 2}, {2, 3}, {3, 4}}; !slices.Equal(calls, want) {
+		t.Errorf("progress calls = %v, want %v", calls, want)
+	}
+}
+
+func TestRequestsPerSecondSpacesRequests(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.JiraConfig{
+		BaseURL:           srv.URL,
+		Email:             "bot@example.com",
+		APIToken:          "token",
+		ProjectKeys:       []string{"DEMO"},
+		RequestsPerSecond: 20,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	for range 3 {
+		if _, err := client.FetchBugs(); err != nil {
+			t.Fatalf("FetchBugs: %v", err)
+		}
+	}
+
+	// The authentication check plus three searches at 20/s are spaced ~50ms apart
+	if len(times) != 4 {
+		t.Fatalf("got %d requests, want 4", len(times))
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < 40*time.Millisecond {
+			t.Errorf("request %d came %v after the previous one, want at least ~50ms", i+1, gap)
+		}
+	}
+}
+
+func TestExtraFieldsRequestedAndMapped(t *testing.T) {
+	var fields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		fmt.Fprint(w, `{"issues":[{"key":"DEMO-1","fields":{
+			"summary":"Crash",
+			"customfield_10100":{"value":"Checkout"},
+			"customfield_10200":7,
+			"customfield_10300":"not requested"
+		}}]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{
+		client:      jc,
+		projectKeys: []string{"DEMO"},
+		searchPath:  DefaultSearchPath,
+		fieldIDs:    FieldIDs{Extra: []string{"customfield_10100", "customfield_10200"}},
+	}
+
+	bugs, err := c.FetchBugs()
+	if err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+
+	requested := strings.Split(fields, ",")
+	for _, want := range []string{"summary", "customfield_10100", "customfield_10200"} {
+		if !slices.Contains(requested, want) {
+			t.Errorf("fields %v missing %q", requested, want)
+		}
+	}
+
+	if len(bugs) != 1 {
+		t.Fatalf("got %d bugs, want 1", len(bugs))
+	}
+	custom := bugs[0].CustomFields
+	if option, ok := custom["customfield_10100"].(map[string]any); !ok || option["value"] != "Checkout" {
+		t.Errorf("customfield_10100 = %v, want the raw option", custom["customfield_10100"])
+	}
+	if custom["customfield_10200"] != float64(7) {
+		t.Errorf("customfield_10200 = %v, want 7", custom["customfield_10200"])
+	}
+	if _, ok := custom["customfield_10300"]; ok {
+		t.Error("unconfigured customfield_10300 copied into CustomFields")
+	}
+}
+
+func TestValidateProjects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ProjectPath + "DEMO", ProjectPath + "OPS":
+			fmt.Fprint(w, `{"key":"DEMO"}`)
+		case ProjectPath + "BROKEN":
+			http.Error(w, `{"errorMessages":["internal"]}`, http.StatusInternalServerError)
+		default:
+			http.Error(w, `{"errorMessages":["No project could be found"]}`, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+
+	tests := []struct {
+		keys    []string
+		wantErr string
+	}{
+		{[]string{"DEMO", "OPS"}, ""},
+		{[]string{"DEMO", "DEOM", "OPZ"}, "projects not found or not accessible: DEOM, OPZ"},
+		{[]string{"BROKEN"}, "failed to check project BROKEN"},
+	}
+
+	for _, tt := range tests {
+		c := &Client{client: jc, projectKeys: tt.keys}
+		err := c.ValidateProjects()
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("ValidateProjects(%v) = %v, want nil", tt.keys, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("ValidateProjects(%v) = %v, want an error containing %q", tt.keys, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTagBugsOverlappingQueries(t *testing.T) {
+	// Each tag query's condition selects the keys it matches
+	matches := map[string]string{
+		"labels = customer": `[{"key":"DEMO-1","fields":{}},{"key":"DEMO-2","fields":{}},{"key":"DEMO-9","fields":{}}]`,
+		"labels = internal": `[{"key":"DEMO-2","fields":{}},{"key":"DEMO-3","fields":{}}]`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		for condition, issues := range matches {
+			if strings.HasSuffix(jql, "("+condition+")") {
+				fmt.Fprintf(w, `{"issues":%s}`, issues)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	bugs := []*domain.Bug{{Key: "DEMO-1"}, {Key: "DEMO-2"}, {Key: "DEMO-3"}, {Key: "DEMO-4"}}
+	tags := []config.TagQuery{
+		{Name: "customer", JQL: "labels = customer"},
+		{Name: "internal", JQL: "labels = internal"},
+		{Name: "customer", JQL: "labels = customer"},
+	}
+	if err := c.TagBugs(bugs, tags); err != nil {
+		t.Fatalf("TagBugs: %v", err)
+	}
+
+	want := map[string][]string{
+		"DEMO-1": {"customer"},
+		"DEMO-2": {"customer", "internal"},
+		"DEMO-3": {"internal"},
+		"DEMO-4": nil,
+	}
+	for _, bug := range bugs {
+		if !slices.Equal(bug.Tags, want[bug.Key]) {
+			t.Errorf("%s tags = %v, want %v", bug.Key, bug.Tags, want[bug.Key])
+		}
+	}
+}
+
+func TestImpersonateHeaderOnEveryRequest(t *testing.T) {
+	var mu sync.Mutex
+	var impersonated []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		impersonated = append(impersonated, r.Header.Get(ImpersonateHeader))
+		mu.Unlock()
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Errorf("%s request without basic auth", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.JiraConfig{
+		BaseURL:              srv.URL,
+		Email:                "bot@example.com",
+		APIToken:             "token",
+		ProjectKeys:          []string{"DEMO"},
+		ImpersonateAccountID: "5b10ac8d82e05b22cc7d4ef5",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.FetchBugs(); err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+
+	// The authentication check and the search both act as the account
+	if want := []string{"5b10ac8d82e05b22cc7d4ef5", "5b10ac8d82e05b22cc7d4ef5"}; !slices.Equal(impersonated, want) {
+		t.Errorf("%s headers = %q, want %q", ImpersonateHeader, impersonated, want)
+	}
+}
+
+func TestFetchBugsRequestsCommentsOnlyWhenEnabled(t *testing.T) {
+	var fields []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = strings.Split(r.URL.Query().Get("fields"), ",")
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	for _, fetch := range []bool{false, true} {
+		c.SetFetchComments(fetch)
+		if _, err := c.FetchBugs(); err != nil {
+			t.Fatalf("FetchBugs: %v", err)
+		}
+		if got := slices.Contains(fields, "comment"); got != fetch {
+			t.Errorf("with comments %v, fields %v include comment = %v", fetch, fields, got)
+		}
+	}
+}
+
+func TestSearchIssuesMidPaginationFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("nextPageToken") {
+		case "":
+			fmt.Fprint(w, `{"issues":[{"key":"DEMO-1","fields":{}},{"key":"DEMO-2","fields":{}}],"nextPageToken":"p2"}`)
+		default:
+			http.Error(w, `{"errorMessages":["upstream timeout"]}`, http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	bugs, err := c.searchIssues("project = DEMO", "summary", nil)
+
+	var partial *PartialResultsError
+	if !errors.As(err, &partial) {
+		t.Fatalf("searchIssues error = %v, want a *PartialResultsError", err)
+	}
+	if partial.Pages != 1 || partial.Fetched != 2 {
+		t.Errorf("partial = %d pages, %d issues, want 1 page, 2 issues", partial.Pages, partial.Fetched)
+	}
+	if len(bugs) != 2 || bugs[0].Key != "DEMO-1" || bugs[1].Key != "DEMO-2" {
+		t.Errorf("got %d bugs, want DEMO-1 and DEMO-2 from the first page", len(bugs))
+	}
+}
+
+func TestSearchIssuesFirstPageFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errorMessages":["bad JQL"]}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	bugs, err := c.searchIssues("project = DEMO", "summary", nil)
+	var partial *PartialResultsError
+	if err == nil || errors.As(err, &partial) {
+		t.Errorf("searchIssues error = %v, want a plain error when nothing was fetched", err)
+	}
+	if bugs != nil {
+		t.Errorf("got %d bugs, want none", len(bugs))
+	}
+}
+
+func TestProjectClauseQuotesKeys(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"DEMO"}, `project = "DEMO"`},
+		{[]string{"MY PROJ"}, `project = "MY PROJ"`},
+		{[]string{"ORDER"}, `project = "ORDER"`},
+		{[]string{"DEMO", `ODD"KEY`}, `project in ("DEMO", "ODD\"KEY")`},
+	}
+
+	for _, tt := range tests {
+		c := &Client{projectKeys: tt.keys}
+		if got := c.projectClause(); got != tt.want {
+			t.Errorf("projectClause(%q) = %s, want %s", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestFetchesQuoteProjectKey(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("jql"))
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"MY PROJ"}, searchPath: DefaultSearchPath}
+
+	start, end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := c.FetchBugs(); err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+	if _, err := c.FetchBugsByDateRange(start, end, nil); err != nil {
+		t.Fatalf("FetchBugsByDateRange: %v", err)
+	}
+	if _, err := c.FetchMatchingKeys("labels = customer"); err != nil {
+		t.Fatalf("FetchMatchingKeys: %v", err)
+	}
+
+	for _, jql := range queries {
+		if !strings.HasPrefix(jql, `project = "MY PROJ" AND `) {
+			t.Errorf("jql = %q, want it to start with the quoted project", jql)
+		}
+	}
+}
+
+func TestExtraHeadersOnEveryRequest(t *testing.T) {
+	var mu sync.Mutex
+	var tokens, teams []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.Header.Get("X-Gateway-Token"))
+		teams = append(teams, r.Header.Get("X-Team"))
+		mu.Unlock()
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(config.JiraConfig{
+		BaseURL:      srv.URL,
+		Email:        "bot@example.com",
+		APIToken:     "token",
+		ProjectKeys:  []string{"DEMO"},
+		ExtraHeaders: map[string]string{"X-Gateway-Token": "gw-123", "X-Team": "platform"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.FetchBugs(); err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+
+	// Both the authentication check and the search carry the headers
+	if want := []string{"gw-123", "gw-123"}; !slices.Equal(tokens, want) {
+		t.Errorf("X-Gateway-Token headers = %q, want %q", tokens, want)
+	}
+	if want := []string{"platform", "platform"}; !slices.Equal(teams, want) {
+		t.Errorf("X-Team headers = %q, want %q", teams, want)
+	}
+}
+
+func TestAllFieldsRequestsNavigable(t *testing.T) {
+	var fields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		fmt.Fprint(w, `{"issues":[{"key":"DEMO-1","fields":{"summary":"Crash","customfield_10099":"kept"}}]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	if _, err := c.FetchBugs(); err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+	if fields == allFieldsParam || !strings.Contains(fields, "summary") {
+		t.Errorf("default fields = %q, want the needed fields listed", fields)
+	}
+
+	c.SetAllFields(true)
+	bugs, err := c.FetchBugs()
+	if err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+	if fields != "*navigable" {
+		t.Errorf("fields with all fields = %q, want *navigable", fields)
+	}
+	if len(bugs) != 1 || bugs[0].CustomFields["customfield_10099"] != "kept" {
+		t.Errorf("custom fields = %v, want customfield_10099 kept", bugs[0].CustomFields)
+	}
+}
+
+func TestRateLimitWarning(t *testing.T) {
+	var remaining []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(remaining) > 0 {
+			w.Header().Set(RateLimitRemainingHeader, remaining[0])
+			remaining = remaining[1:]
+		}
+		fmt.Fprint(w, `{"key":"DEMO"}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	tests := []struct {
+		name      string
+		threshold int
+		remaining []string
+		wantWarns int
+	}{
+		{"above threshold", 10, []string{"50", "10"}, 0},
+		{"below threshold warns once", 10, []string{"9", "3", "1"}, 1},
+		{"disabled", 0, []string{"1"}, 0},
+		{"unparseable", 10, []string{"soon"}, 0},
+		{"missing header", 10, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			remaining = tt.remaining
+			keys := make([]string, max(len(tt.remaining), 1))
+			for i := range keys {
+				keys[i] = "DEMO"
+			}
+			c := &Client{client: jc, projectKeys: keys, rateLimitWarning: tt.threshold}
+			if err := c.ValidateProjects(); err != nil {
+				t.Fatalf("ValidateProjects: %v", err)
+			}
+			if got := strings.Count(logs.String(), "Approaching Jira rate limit"); got != tt.wantWarns {
+				t.Errorf("logged %d warnings, want %d:\n%s", got, tt.wantWarns, logs.String())
+			}
+		})
+	}
+}
+
+func TestFetchIssuesBySprintsChunksBatches(t *testing.T) {
+	// Each sprint holds one issue; sprint 104 also holds sprint 103's issue, as carried-over issues do
+	sprintIssues := map[string][]string{
+		"101": {"DEMO-1"}, "102": {"DEMO-2"}, "103": {"DEMO-3"}, "104": {"DEMO-3", "DEMO-4"}, "105": {"DEMO-5"},
+	}
+	sprintList := regexp.MustCompile(`sprint in \(([^)]*)\)`)
+
+	var mu sync.Mutex
+	var queried [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(sprintList.FindStringSubmatch(r.URL.Query().Get("jql"))[1], ", ")
+		mu.Lock()
+		queried = append(queried, ids)
+		mu.Unlock()
+
+		var issues []string
+		for _, id := range ids {
+			for _, key := range sprintIssues[id] {
+				issues = append(issues, fmt.Sprintf(`{"key":%q,"fields":{}}`, key))
+			}
+		}
+		fmt.Fprintf(w, `{"issues":[%s]}`, strings.Join(issues, ","))
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+	c.SetSprintBatching(2, 2)
+
+	var lastFetched int
+	issues, err := c.FetchIssuesBySprints([]string{"101", "102", "103", "104", "105"}, func(_, fetched int) {
+		mu.Lock()
+		lastFetched = max(lastFetched, fetched)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("FetchIssuesBySprints: %v", err)
+	}
+
+	slices.SortFunc(queried, slices.Compare)
+	if want := [][]string{{"101", "102"}, {"103", "104"}, {"105"}}; !reflect.DeepEqual(queried, want) {
+		t.Errorf("queried sprint batches %v, want %v", queried, want)
+	}
+
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	if want := []string{"DEMO-1", "DEMO-2", "DEMO-3", "DEMO-4", "DEMO-5"}; !slices.Equal(keys, want) {
+		t.Errorf("issues = %v, want %v (merged in batch order without duplicates)", keys, want)
+	}
+	if lastFetched != 6 {
+		t.Errorf("progress reached %d fetched issues, want 6 across all batches", lastFetched)
+	}
+}
+
+func TestFetchIssuesBySprintsFailedBatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("jql"), "103") {
+			http.Error(w, `{"errorMessages":["internal"]}`, http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"issues":[{"key":"DEMO-1","fields":{}}]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+	c.SetSprintBatching(2, 1)
+
+	issues, err := c.FetchIssuesBySprints([]string{"101", "102", "103", "104"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "sprints 103, 104") {
+		t.Fatalf("FetchIssuesBySprints error = %v, want one naming sprints 103, 104", err)
+	}
+	// Stats missing sprints would mislead, so this is never a partial result --allow-partial accepts
+	var partial *PartialResultsError
+	if errors.As(err, &partial) {
+		t.Errorf("FetchIssuesBySprints error = %v, want a hard error rather than partial results", err)
+	}
+	if issues != nil {
+		t.Errorf("issues = %v, want none", issues)
+	}
+}
+
+func TestFetchBugsByJQL(t *testing.T) {
+	var jql, fields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql = r.URL.Query().Get("jql")
+		fields = r.URL.Query().Get("fields")
+		fmt.Fprint(w, `{"issues":[
+			{"key":"FOO-1","fields":{"summary":"Crash","issuetype":{"name":"Bug"}}},
+			{"key":"FOO-2","fields":{"summary":"New login page","issuetype":{"name":"Story"}}}
+		]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	custom := `project = FOO AND labels = "checkout" ORDER BY created`
+	bugs, err := c.FetchBugsByJQL(custom, nil)
+	if err != nil {
+		t.Fatalf("FetchBugsByJQL: %v", err)
+	}
+
+	if jql != custom {
+		t.Errorf("jql = %q, want the custom JQL verbatim", jql)
+	}
+	if !slices.Contains(strings.Split(fields, ","), "issuetype") {
+		t.Errorf("fields %q missing issuetype", fields)
+	}
+	if len(bugs) != 2 {
+		t.Fatalf("got %d bugs, want both issues mapped", len(bugs))
+	}
+	if log := logs.String(); !strings.Contains(log, "not bugs") || !strings.Contains(log, "FOO-2") || strings.Contains(log, "FOO-1") {
+		t.Errorf("log = %q, want a warning naming only FOO-2", log)
+	}
+}
+
+func TestLogRequestsOmitsCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"issues":[]}`)
+	}))
+	defer srv.Close()
+
+	const token = "s3cret-api-token"
+	client, err := NewClient(config.JiraConfig{
+		BaseURL:     srv.URL,
+		Email:       "bot@example.com",
+		APIToken:    token,
+		ProjectKeys: []string{"DEMO"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	client.SetLogRequests(true)
+	if _, err := client.FetchBugsByJQL("project = DEMO AND labels = checkout", nil); err != nil {
+		t.Fatalf("FetchBugsByJQL: %v", err)
+	}
+
+	log := logs.String()
+	for _, want := range []string{"Jira request", "project = DEMO AND labels = checkout", "fields=", DefaultSearchPath} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+	for _, secret := range []string{token, "Authorization", "Basic "} {
+		if strings.Contains(log, secret) {
+			t.Errorf("log leaks %q:\n%s", secret, log)
+		}
+	}
+}
+
+func TestAnnotateParentStatuses(t *testing.T) {
+	var jqls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jqls = append(jqls, r.URL.Query().Get("jql"))
+		fmt.Fprint(w, `{"issues":[
+			{"key":"DEMO-10","fields":{"status":{"name":"In Progress"}}},
+			{"key":"DEMO-11","fields":{"status":{"name":"Backlog"}}}
+		]}`)
+	}))
+	defer srv.Close()
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+	c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath}
+
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", ParentKey: "DEMO-10"},
+		{Key: "DEMO-2", ParentKey: "DEMO-11"},
+		{Key: "DEMO-3", ParentKey: "DEMO-10"},
+		{Key: "DEMO-4"},
+	}
+	if err := c.AnnotateParentStatuses(bugs); err != nil {
+		t.Fatalf("AnnotateParentStatuses: %v", err)
+	}
+
+	if want := []string{`key in ("DEMO-10", "DEMO-11")`}; !slices.Equal(jqls, want) {
+		t.Errorf("jql = %q, want %q (each parent once, quoted)", jqls, want)
+	}
+	for i, want := range []string{"In Progress", "Backlog", "In Progress", ""} {
+		if bugs[i].ParentStatus != want {
+			t.Errorf("%s ParentStatus = %q, want %q", bugs[i].Key, bugs[i].ParentStatus, want)
+		}
+	}
+}
+
+func TestSprintJQLBoardFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"no filter", "", `project = "DEMO" AND sprint in (101, 102) AND statusCategory = done ORDER BY resolutiondate DESC`},
+		{
+			"filter appended",
+			`component = "Checkout"`,
+			`project = "DEMO" AND sprint in (101, 102) AND statusCategory = done AND (component = "Checkout") ORDER BY resolutiondate DESC`,
+		},
+		{
+			"board ORDER BY dropped",
+			`labels = web order by Rank ASC`,
+			`project = "DEMO" AND sprint in (101, 102) AND statusCategory = done AND (labels = web) ORDER BY resolutiondate DESC`,
+		},
+	}
+	for _, tt := range tests {
+		c := &Client{projectKeys: []string{"DEMO"}}
+		c.SetSprintBoardFilter(tt.filter)
+		if got := c.sprintJQL([]string{"101", "102"}); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSearchIssuesPageDelay(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"issues":[{"key":"DEMO-1","fields":{}}],"nextPageToken":"p2"}`,
+		"p2": `{"issues":[{"key":"DEMO-2","fields":{}}],"nextPageToken":"p3"}`,
+		"p3": `{"issues":[{"key":"DEMO-3","fields":{}}]}`,
+	}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, pages[r.URL.Query().Get("nextPageToken")])
+	}))
+	defer srv.Close()
+
+	type pause struct {
+		afterRequests int
+		d             time.Duration
+	}
+	var pauses []pause
+	defer func(saved func(time.Duration)) { sleep = saved }(sleep)
+	sleep = func(d time.Duration) { pauses = append(pauses, pause{requests, d}) }
+
+	jc, err := jira.NewClient(nil, srv.URL)
+	if err != nil {
+		t.Fatalf("jira.NewClient: %v", err)
+	}
+
+	for _, tt := range []struct {
+		delay time.Duration
+		want  []pause
+	}{
+		{0, nil},
+		{250 * time.Millisecond, []pause{{1, 250 * time.Millisecond}, {2, 250 * time.Millisecond}}},
+	} {
+		requests, pauses = 0, nil
+		c := &Client{client: jc, projectKeys: []string{"DEMO"}, searchPath: DefaultSearchPath, pageDelay: tt.delay}
+		if _, err := c.searchIssues("project = DEMO", "summary", nil); err != nil {
+			t.Fatalf("searchIssues: %v", err)
+		}
+		// One pause between each pair of pages, none after the last
+		if !slices.Equal(pauses, tt.want) {
+			t.Errorf("delay %v: pauses = %v, want %v", tt.delay, pauses, tt.want)
+		}
+	}
+}
+
+func TestFetchBugsFromFixtureServer(t *testing.T) {
+	const searchPath = "/fixture/search/jql"
+	pages := map[string]string{
+		"": `{"issues":[{"key":"DEMO-1","fields":{
+			"summary":"Checkout fails",
+			"issuetype":{"name":"Bug"},
+			"priority":{"name":"Critical"},
+			"status":{"name":"Open"},
+			"created":"2025-03-03T09:00:00.000+0000",
+			"updated":"2025-03-04T09:00:00.000+0000"
+		}}],"nextPageToken":"page-2"}`,
+		"page-2": `{"issues":[{"key":"DEMO-2","fields":{
+			"summary":"Search is slow",
+			"issuetype":{"name":"Bug"},
+			"priority":{"name":"Low"},
+			"status":{"name":"Backlog"},
+			"created":"2025-03-05T09:00:00.000+0000",
+			"updated":"2025-03-06T09:00:00.000+0000"
+		}}]}`,
+	}
+
+	// The fixture server answers the authentication check and the v3 search API at the custom path
+	var paths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc(MyselfPath, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"accountId":"fixture"}`)
+	})
+	mux.HandleFunc(searchPath, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if _, _, ok := r.BasicAuth(); !ok {
+			http.Error(w, "missing credentials", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, pages[r.URL.Query().Get("nextPageToken")])
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewClient(config.JiraConfig{
+		BaseURL:     srv.URL,
+		Email:       "bot@example.com",
+		APIToken:    "fixture-token",
+		ProjectKeys: []string{"DEMO"},
+		SearchPath:  searchPath,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	bugs, err := client.FetchBugs()
+	if err != nil {
+		t.Fatalf("FetchBugs: %v", err)
+	}
+
+	if want := []string{searchPath, searchPath}; !slices.Equal(paths, want) {
+		t.Errorf("requested paths = %v, want both pages from %s", paths, searchPath)
+	}
+	if len(bugs) != 2 {
+		t.Fatalf("got %d bugs, want 2", len(bugs))
+	}
+	if bugs[0].Key != "DEMO-1" || bugs[0].Priority != "Critical" || bugs[0].Status != "Open" {
+		t.Errorf("first bug = %s %s %s, want DEMO-1 Critical Open", bugs[0].Key, bugs[0].Priority, bugs[0].Status)
+	}
+	if want := srv.URL + "/browse/DEMO-2"; bugs[1].URL() != want {
+		t.Errorf("second bug URL = %q, want %q", bugs[1].URL(), want)
+	}
+}
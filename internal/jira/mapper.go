@@ -3,14 +3,51 @@ package jira
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/andygrunwald/go-jira"
 	"github.com/neilmpatterson/bug-butler/internal/domain"
 )
 
+// FieldIDs holds the custom field IDs used when mapping issues
+type FieldIDs struct {
+	Sprint        string   // Sprint field ID
+	StoryPoints   string   // Story points field ID
+	FirstResponse string   // First response date field ID (optional)
+	EpicLink      string   // Epic link field ID (optional)
+	Priority      string   // Fallback priority field ID, used when the standard priority is unset (optional)
+	Resolved      string   // Resolution date field ID, preferred over the standard resolutiondate (optional)
+	Extra         []string // Additional field IDs copied raw into Bug.CustomFields
+	AllCustom     bool     // Copy every non-standard field into Bug.CustomFields (with --all-fields)
+
+	Aliases map[string]string // Priority names mapped to canonical names (matched case-insensitively)
+}
+
+// jiraDateTimeLayouts are the formats Jira uses for date and datetime custom field values
+var jiraDateTimeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+// canonicalPriority maps a priority name through the configured aliases; unmapped names pass through
+func canonicalPriority(name string, aliases map[string]string) string {
+	if canonical, ok := aliases[name]; ok {
+		return canonical
+	}
+	for alias, canonical := range aliases {
+		if strings.EqualFold(alias, name) {
+			return canonical
+		}
+	}
+	return name
+}
+
 // MapIssueToBug converts a Jira issue to a domain Bug
-func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, storyPointsFieldID string) (*domain.Bug, error) {
+func MapIssueToBug(issue *jira.Issue, baseURL string, fieldIDs FieldIDs) (*domain.Bug, error) {
 	if issue == nil {
 		return nil, fmt.Errorf("issue is nil")
 	}
@@ -19,7 +56,13 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 	priority := "Unknown"
 	if issue.Fields.Priority != nil {
 		priority = issue.Fields.Priority.Name
+	} else if fieldIDs.Priority != "" && issue.Fields.Unknowns != nil {
+		// Fall back to a custom field (e.g., a "Severity" select list)
+		if name := customFieldName(issue.Fields.Unknowns[fieldIDs.Priority]); name != "" {
+			priority = name
+		}
 	}
+	priority = canonicalPriority(priority, fieldIDs.Aliases)
 
 	// Extract status name (should always be present)
 	status := "Unknown"
@@ -33,10 +76,31 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 		issueType = issue.Fields.Type.Name
 	}
 
+	// Extract assignee (nil if unassigned)
+	assignee := ""
+	if issue.Fields.Assignee != nil {
+		assignee = issue.Fields.Assignee.DisplayName
+	}
+
+	// Count comments (nil when the comment field wasn't requested)
+	commentCount := 0
+	if issue.Fields.Comments != nil {
+		commentCount = len(issue.Fields.Comments.Comments)
+	}
+
 	// Parse timestamps (go-jira Time type)
 	created := time.Time(issue.Fields.Created)
 	updated := time.Time(issue.Fields.Updated)
 
+	// Clock skew can produce timestamps slightly in the future (ages are clamped to zero)
+	if now := time.Now(); created.After(now) || updated.After(now) {
+		slog.Warn("Issue has a future timestamp, treating its age as zero",
+			"issue_key", issue.Key,
+			"created", created,
+			"updated", updated,
+		)
+	}
+
 	// Extract resolution (may be nil if unresolved)
 	resolution := ""
 	if issue.Fields.Resolution != nil {
@@ -44,8 +108,22 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 	}
 
 	// Extract resolution date (may be zero if unresolved)
+	// A configured custom field wins, since the standard field is reset when an issue is reopened
 	var resolutionDate *time.Time
-	if !time.Time(issue.Fields.Resolutiondate).IsZero() {
+	if fieldIDs.Resolved != "" && issue.Fields.Unknowns != nil {
+		if value, ok := issue.Fields.Unknowns[fieldIDs.Resolved].(string); ok && value != "" {
+			if t, err := parseJiraDateTime(value); err == nil {
+				resolutionDate = &t
+			} else {
+				slog.Debug("Failed to parse resolution date field, using resolutiondate",
+					"issue_key", issue.Key,
+					"value", value,
+					"error", err,
+				)
+			}
+		}
+	}
+	if resolutionDate == nil && !time.Time(issue.Fields.Resolutiondate).IsZero() {
 		t := time.Time(issue.Fields.Resolutiondate)
 		resolutionDate = &t
 	}
@@ -53,6 +131,8 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 	// Extract sprint information (from Unknowns map - customfield_10020 is common for sprints)
 	sprintID := ""
 	sprintName := ""
+	sprintState := ""
+	var sprintStart *time.Time
 	if issue.Fields.Unknowns != nil {
 		// Log available custom fields for debugging (helps identify correct field IDs)
 		slog.Debug("Custom fields available for issue",
@@ -61,7 +141,7 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 		)
 
 		// Sprint field - use configured field ID
-		if sprintData, ok := issue.Fields.Unknowns[sprintFieldID]; ok && sprintData != nil {
+		if sprintData, ok := issue.Fields.Unknowns[fieldIDs.Sprint]; ok && sprintData != nil {
 			// Sprint can be an array of sprint objects
 			if sprints, ok := sprintData.([]interface{}); ok && len(sprints) > 0 {
 				// Take the first sprint (current sprint)
@@ -72,13 +152,21 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 					if name, ok := sprint["name"].(string); ok {
 						sprintName = name
 					}
+					if state, ok := sprint["state"].(string); ok {
+						sprintState = state
+					}
+					if start, ok := sprint["startDate"].(string); ok && start != "" {
+						if t, err := parseJiraDateTime(start); err == nil {
+							sprintStart = &t
+						}
+					}
 				}
 			}
 		} else {
 			slog.Debug("Sprint field not found or null",
 				"issue_key", issue.Key,
-				"sprint_field_id", sprintFieldID,
-				"field_exists", issue.Fields.Unknowns[sprintFieldID] != nil,
+				"sprint_field_id", fieldIDs.Sprint,
+				"field_exists", issue.Fields.Unknowns[fieldIDs.Sprint] != nil,
 			)
 		}
 	}
@@ -86,26 +174,160 @@ func MapIssueToBug(issue *jira.Issue, baseURL string, sprintFieldID string, stor
 	// Extract story points - use configured field ID
 	storyPoints := 0.0
 	if issue.Fields.Unknowns != nil {
-		if points, ok := issue.Fields.Unknowns[storyPointsFieldID]; ok && points != nil {
+		if points, ok := issue.Fields.Unknowns[fieldIDs.StoryPoints]; ok && points != nil {
 			if pointsFloat, ok := points.(float64); ok {
 				storyPoints = pointsFloat
 			}
 		}
 	}
 
+	// Extract first response date - use configured field ID (optional)
+	var firstResponse *time.Time
+	if fieldIDs.FirstResponse != "" && issue.Fields.Unknowns != nil {
+		if value, ok := issue.Fields.Unknowns[fieldIDs.FirstResponse].(string); ok && value != "" {
+			if t, err := parseJiraDateTime(value); err == nil {
+				firstResponse = &t
+			} else {
+				slog.Debug("Failed to parse first response date",
+					"issue_key", issue.Key,
+					"value", value,
+					"error", err,
+				)
+			}
+		}
+	}
+
+	// Extract the linked epic key - use configured field ID (optional)
+	var epicKey string
+	if fieldIDs.EpicLink != "" && issue.Fields.Unknowns != nil {
+		switch v := issue.Fields.Unknowns[fieldIDs.EpicLink].(type) {
+		case string:
+			epicKey = v
+		case map[string]interface{}:
+			epicKey, _ = v["key"].(string) // Some sites return the linked epic as an issue object
+		}
+	}
+
+	// Extract the parent issue key (sub-tasks and child issues)
+	var parentKey string
+	if issue.Fields.Parent != nil {
+		parentKey = issue.Fields.Parent.Key
+	}
+
+	// Extract fix and affects version names
+	var fixVersions []string
+	for _, v := range issue.Fields.FixVersions {
+		if v != nil {
+			fixVersions = append(fixVersions, v.Name)
+		}
+	}
+	var affectsVersions []string
+	for _, v := range issue.Fields.AffectsVersions {
+		if v != nil {
+			affectsVersions = append(affectsVersions, v.Name)
+		}
+	}
+	var components []string
+	for _, c := range issue.Fields.Components {
+		if c != nil {
+			components = append(components, c.Name)
+		}
+	}
+
+	// Copy configured extra fields (or all of them) as raw values (for downstream JSON dumps)
+	var customFields map[string]any
+	extra := fieldIDs.Extra
+	if fieldIDs.AllCustom {
+		extra = slices.Collect(maps.Keys(issue.Fields.Unknowns))
+	}
+	if len(extra) > 0 && issue.Fields.Unknowns != nil {
+		for _, id := range extra {
+			if value, ok := issue.Fields.Unknowns[id]; ok && value != nil {
+				if customFields == nil {
+					customFields = make(map[string]any)
+				}
+				customFields[id] = value
+			}
+		}
+	}
+
 	return &domain.Bug{
-		Key:            issue.Key,
-		Summary:        issue.Fields.Summary,
-		Priority:       priority,
-		Status:         status,
-		IssueType:      issueType,
-		Created:        created,
-		Updated:        updated,
-		Resolution:     resolution,
-		ResolutionDate: resolutionDate,
-		SprintID:       sprintID,
-		SprintName:     sprintName,
-		StoryPoints:    storyPoints,
-		BaseURL:        baseURL,
+		Key:             issue.Key,
+		Summary:         issue.Fields.Summary,
+		Priority:        priority,
+		Status:          status,
+		IssueType:       issueType,
+		Created:         created,
+		Updated:         updated,
+		Resolution:      resolution,
+		ResolutionDate:  resolutionDate,
+		SprintID:        sprintID,
+		SprintName:      sprintName,
+		SprintState:     sprintState,
+		SprintStart:     sprintStart,
+		StoryPoints:     storyPoints,
+		FixVersions:     fixVersions,
+		AffectsVersions: affectsVersions,
+		Components:      components,
+		Labels:          issue.Fields.Labels,
+		FirstResponse:   firstResponse,
+		Assignee:        assignee,
+		EpicKey:         epicKey,
+		ParentKey:       parentKey,
+		CommentCount:    commentCount,
+		BaseURL:         baseURL,
+		CustomFields:    customFields,
+		ReopenedAt:      reopenTimes(issue.Changelog),
 	}, nil
 }
+
+// reopenTimes returns when an issue was reopened, i.e. its resolution was cleared,
+// from the changelog (nil unless the search expanded it)
+func reopenTimes(changelog *jira.Changelog) []time.Time {
+	if changelog == nil {
+		return nil
+	}
+	var times []time.Time
+	for _, history := range changelog.Histories {
+		reopened := slices.ContainsFunc(history.Items, func(item jira.ChangelogItems) bool {
+			return item.Field == "resolution" && item.FromString != "" && item.ToString == ""
+		})
+		if !reopened {
+			continue
+		}
+		created, err := parseJiraDateTime(history.Created)
+		if err != nil {
+			slog.Debug("Skipping changelog entry with unparseable date", "id", history.Id, "created", history.Created)
+			continue
+		}
+		times = append(times, created)
+	}
+	return times
+}
+
+// customFieldName extracts a display name from a custom field value, which may be
+// a plain string or an option/object with a "value" or "name" key
+func customFieldName(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if s, ok := v["value"].(string); ok {
+			return s
+		}
+		if s, ok := v["name"].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// parseJiraDateTime parses a Jira date or datetime custom field value
+func parseJiraDateTime(value string) (time.Time, error) {
+	for _, layout := range jiraDateTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format: %q", value)
+}
@@ -0,0 +1,236 @@
+package jira
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// decodeIssue unmarshals a v3 issue, as the search response decoding does
+func decodeIssue(t *testing.T, data string) *jira.Issue {
+	t.Helper()
+	var issue jira.Issue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("decoding issue: %v", err)
+	}
+	return &issue
+}
+
+func TestMapIssueToBugVersions(t *testing.T) {
+	issue := decodeIssue(t, `{"key":"DEMO-1","fields":{
+		"summary":"Crash on save",
+		"fixVersions":[{"name":"2.4.0"},{"name":"2.5.0"}],
+		"versions":[{"name":"2.3.1"}]
+	}}`)
+
+	bug, err := MapIssueToBug(issue, "https://example.atlassian.net", FieldIDs{})
+	if err != nil {
+		t.Fatalf("MapIssueToBug: %v", err)
+	}
+	if want := []string{"2.4.0", "2.5.0"}; !slices.Equal(bug.FixVersions, want) {
+		t.Errorf("FixVersions = %v, want %v", bug.FixVersions, want)
+	}
+	if want := []string{"2.3.1"}; !slices.Equal(bug.AffectsVersions, want) {
+		t.Errorf("AffectsVersions = %v, want %v", bug.AffectsVersions, want)
+	}
+
+	unversioned, err := MapIssueToBug(decodeIssue(t, `{"key":"DEMO-2","fields":{"summary":"No versions"}}`), "", FieldIDs{})
+	if err != nil {
+		t.Fatalf("MapIssueToBug: %v", err)
+	}
+	if len(unversioned.FixVersions) != 0 || len(unversioned.AffectsVersions) != 0 {
+		t.Errorf("versions = %v / %v, want none", unversioned.FixVersions, unversioned.AffectsVersions)
+	}
+}
+
+func TestMapIssueToBugPriorityFallback(t *testing.T) {
+	fieldIDs := FieldIDs{Priority: "customfield_10400"}
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{"standard priority wins", `{"priority":{"name":"High"},"customfield_10400":{"value":"Sev 1"}}`, "High"},
+		{"option fallback", `{"customfield_10400":{"value":"Sev 1"}}`, "Sev 1"},
+		{"string fallback", `{"customfield_10400":"Sev 2"}`, "Sev 2"},
+		{"empty fallback", `{"customfield_10400":null}`, "Unknown"},
+		{"no fallback value", `{}`, "Unknown"},
+	}
+
+	for _, tt := range tests {
+		issue := decodeIssue(t, `{"key":"DEMO-1","fields":`+tt.fields+`}`)
+		bug, err := MapIssueToBug(issue, "", fieldIDs)
+		if err != nil {
+			t.Fatalf("%s: MapIssueToBug: %v", tt.name, err)
+		}
+		if bug.Priority != tt.want {
+			t.Errorf("%s: Priority = %q, want %q", tt.name, bug.Priority, tt.want)
+		}
+	}
+
+	// Without a configured fallback field, the custom field is ignored
+	issue := decodeIssue(t, `{"key":"DEMO-1","fields":{"customfield_10400":{"value":"Sev 1"}}}`)
+	if bug, _ := MapIssueToBug(issue, "", FieldIDs{}); bug.Priority != "Unknown" {
+		t.Errorf("Priority without fallback = %q, want Unknown", bug.Priority)
+	}
+}
+
+func TestMapIssueToBugSprintState(t *testing.T) {
+	issue := decodeIssue(t, `{"key":"DEMO-1","fields":{
+		"summary":"Crash on save",
+		"customfield_10020":[{"id":42,"name":"Sprint 7","state":"closed"}]
+	}}`)
+
+	bug, err := MapIssueToBug(issue, "", FieldIDs{Sprint: "customfield_10020"})
+	if err != nil {
+		t.Fatalf("MapIssueToBug: %v", err)
+	}
+	if bug.SprintID != "42" || bug.SprintName != "Sprint 7" || bug.SprintState != "closed" {
+		t.Errorf("sprint = %q, %q, %q, want 42, Sprint 7, closed", bug.SprintID, bug.SprintName, bug.SprintState)
+	}
+}
+
+func TestMapIssueToBugCommentCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		want   int
+	}{
+		{"comments not requested", `{"summary":"Crash"}`, 0},
+		{"no comments", `{"summary":"Crash","comment":{"comments":[],"total":0}}`, 0},
+		{"three comments", `{"summary":"Crash","comment":{"comments":[{"id":"1"},{"id":"2"},{"id":"3"}],"total":3}}`, 3},
+	}
+
+	for _, tt := range tests {
+		bug, err := MapIssueToBug(decodeIssue(t, `{"key":"DEMO-1","fields":`+tt.fields+`}`), "", FieldIDs{})
+		if err != nil {
+			t.Fatalf("%s: MapIssueToBug: %v", tt.name, err)
+		}
+		if bug.CommentCount != tt.want {
+			t.Errorf("%s: CommentCount = %d, want %d", tt.name, bug.CommentCount, tt.want)
+		}
+	}
+}
+
+func TestMapIssueToBugEpicLink(t *testing.T) {
+	fieldIDs := FieldIDs{EpicLink: "customfield_10014"}
+	tests := []struct {
+		name     string
+		fields   string
+		fieldIDs FieldIDs
+		want     string
+	}{
+		{"key string", `{"customfield_10014":"DEMO-100"}`, fieldIDs, "DEMO-100"},
+		{"issue object", `{"customfield_10014":{"id":"10100","key":"DEMO-200"}}`, fieldIDs, "DEMO-200"},
+		{"no epic", `{"customfield_10014":null}`, fieldIDs, ""},
+		{"field not configured", `{"customfield_10014":"DEMO-100"}`, FieldIDs{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bug, err := MapIssueToBug(decodeIssue(t, `{"key":"DEMO-1","fields":`+tt.fields+`}`), "", tt.fieldIDs)
+			if err != nil {
+				t.Fatalf("MapIssueToBug: %v", err)
+			}
+			if bug.EpicKey != tt.want {
+				t.Errorf("EpicKey = %q, want %q", bug.EpicKey, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapIssueToBugResolutionDateField(t *testing.T) {
+	standard := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	custom := time.Date(2025, 2, 1, 12, 30, 0, 0, time.UTC)
+	customDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	fieldIDs := FieldIDs{Resolved: "customfield_10500"}
+
+	tests := []struct {
+		name     string
+		fields   string
+		fieldIDs FieldIDs
+		want     *time.Time
+	}{
+		{"custom field preferred", `{"resolutiondate":"2025-03-10T09:00:00.000+0000","customfield_10500":"2025-02-01T12:30:00.000+0000"}`, fieldIDs, &custom},
+		{"custom date-only value", `{"resolutiondate":"2025-03-10T09:00:00.000+0000","customfield_10500":"2025-02-01"}`, fieldIDs, &customDate},
+		{"custom field empty", `{"resolutiondate":"2025-03-10T09:00:00.000+0000","customfield_10500":null}`, fieldIDs, &standard},
+		{"custom field unparseable", `{"resolutiondate":"2025-03-10T09:00:00.000+0000","customfield_10500":"last week"}`, fieldIDs, &standard},
+		{"not configured", `{"resolutiondate":"2025-03-10T09:00:00.000+0000","customfield_10500":"2025-02-01"}`, FieldIDs{}, &standard},
+		{"unresolved", `{}`, fieldIDs, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bug, err := MapIssueToBug(decodeIssue(t, `{"key":"DEMO-1","fields":`+tt.fields+`}`), "", tt.fieldIDs)
+			if err != nil {
+				t.Fatalf("MapIssueToBug: %v", err)
+			}
+			switch {
+			case tt.want == nil && bug.ResolutionDate != nil:
+				t.Errorf("ResolutionDate = %v, want nil", *bug.ResolutionDate)
+			case tt.want != nil && (bug.ResolutionDate == nil || !bug.ResolutionDate.Equal(*tt.want)):
+				t.Errorf("ResolutionDate = %v, want %v", bug.ResolutionDate, *tt.want)
+			}
+		})
+	}
+}
+
+func TestMapIssueToBugPriorityAliases(t *testing.T) {
+	fieldIDs := FieldIDs{
+		Priority: "customfield_10400",
+		Aliases:  map[string]string{"Critical": "Highest", "trivial": "Lowest"},
+	}
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{"exact alias", `{"priority":{"name":"Critical"}}`, "Highest"},
+		{"case-insensitive alias", `{"priority":{"name":"Trivial"}}`, "Lowest"},
+		{"canonical name unchanged", `{"priority":{"name":"Highest"}}`, "Highest"},
+		{"unmapped passes through", `{"priority":{"name":"Medium"}}`, "Medium"},
+		{"fallback field aliased", `{"customfield_10400":{"value":"critical"}}`, "Highest"},
+	}
+	for _, tt := range tests {
+		bug, err := MapIssueToBug(decodeIssue(t, `{"key":"DEMO-1","fields":`+tt.fields+`}`), "", fieldIDs)
+		if err != nil {
+			t.Fatalf("%s: MapIssueToBug: %v", tt.name, err)
+		}
+		if bug.Priority != tt.want {
+			t.Errorf("%s: Priority = %q, want %q", tt.name, bug.Priority, tt.want)
+		}
+	}
+}
+
+func TestMapIssueToBugReopenTimes(t *testing.T) {
+	issue := decodeIssue(t, `{"key":"DEMO-1","fields":{"summary":"Flaky save"},"changelog":{"histories":[
+		{"id":"1","created":"2025-03-01T10:00:00.000+0000","items":[{"field":"resolution","fromString":"","toString":"Fixed"}]},
+		{"id":"2","created":"2025-03-02T10:00:00.000+0000","items":[
+			{"field":"status","fromString":"Done","toString":"Reopened"},
+			{"field":"resolution","fromString":"Fixed","toString":""}
+		]},
+		{"id":"3","created":"2025-03-04T10:00:00.000+0000","items":[{"field":"status","fromString":"Reopened","toString":"In Progress"}]},
+		{"id":"4","created":"2025-03-05T10:00:00.000+0000","items":[{"field":"resolution","fromString":"Fixed","toString":""}]},
+		{"id":"5","created":"not a date","items":[{"field":"resolution","fromString":"Fixed","toString":""}]}
+	]}}`)
+
+	bug, err := MapIssueToBug(issue, "", FieldIDs{})
+	if err != nil {
+		t.Fatalf("MapIssueToBug: %v", err)
+	}
+	want := []time.Time{
+		time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC),
+	}
+	if !slices.EqualFunc(bug.ReopenedAt, want, time.Time.Equal) {
+		t.Errorf("ReopenedAt = %v, want %v", bug.ReopenedAt, want)
+	}
+
+	// Without an expanded changelog there is nothing to report
+	if bug, _ := MapIssueToBug(decodeIssue(t, `{"key":"DEMO-2","fields":{}}`), "", FieldIDs{}); bug.ReopenedAt != nil {
+		t.Errorf("ReopenedAt without changelog = %v, want nil", bug.ReopenedAt)
+	}
+}
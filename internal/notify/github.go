@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+)
+
+// defaultGitHubAPIURL is the public GitHub REST API endpoint
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubNotifier posts the report as a comment on a GitHub issue
+type GitHubNotifier struct {
+	repo       string // "owner/name"
+	issue      int
+	token      string
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewGitHubNotifier creates a notifier for the configured repository issue
+func NewGitHubNotifier(cfg config.GitHubNotifyConfig) *GitHubNotifier {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	return &GitHubNotifier{
+		repo:       cfg.Repo,
+		issue:      cfg.Issue,
+		token:      cfg.Token,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name identifies the notifier
+func (g *GitHubNotifier) Name() string {
+	return fmt.Sprintf("GitHub %s#%d", g.repo, g.issue)
+}
+
+// Notify posts the report as a new issue comment
+func (g *GitHubNotifier) Notify(ctx context.Context, report string) error {
+	payload, err := json.Marshal(map[string]string{"body": report})
+	if err != nil {
+		return fmt.Errorf("failed to encode comment: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/issues/%d/comments", g.apiURL, g.repo, g.issue)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create comment request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("failed to post comment (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
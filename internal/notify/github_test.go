@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+)
+
+func TestGitHubNotifierPostsComment(t *testing.T) {
+	var path, auth, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.Path
+		auth = r.Header.Get("Authorization")
+		var payload struct {
+			Body string `json:"body"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decoding comment payload: %v", err)
+		}
+		body = payload.Body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	notifier := NewGitHubNotifier(config.GitHubNotifyConfig{
+		Repo:   "acme/widgets",
+		Issue:  42,
+		Token:  "gh-token",
+		APIURL: srv.URL + "/",
+	})
+
+	report := "## Bug Butler - SLA Violation Report\n\n**Total SLA violations: 1**\n"
+	if err := notifier.Notify(context.Background(), report); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	if path != "POST /repos/acme/widgets/issues/42/comments" {
+		t.Errorf("request = %q, want a POST to the issue comments", path)
+	}
+	if auth != "Bearer gh-token" {
+		t.Errorf("Authorization = %q, want the bearer token", auth)
+	}
+	if body != report {
+		t.Errorf("comment body = %q, want the report %q", body, report)
+	}
+}
+
+func TestGitHubNotifierReportsFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	notifier := NewGitHubNotifier(config.GitHubNotifyConfig{Repo: "acme/widgets", Issue: 42, APIURL: srv.URL})
+	err := notifier.Notify(context.Background(), "report")
+	if err == nil || !strings.Contains(err.Error(), "status 401") || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("Notify error = %v, want the status and response message", err)
+	}
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"slices"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// Notifier delivers a rendered Markdown report to an external destination
+type Notifier interface {
+	// Name identifies the notifier in logs and messages
+	Name() string
+	// Notify posts the report, returning an error if delivery failed
+	Notify(ctx context.Context, report string) error
+}
+
+// Route pairs a notifier with the buckets it receives (no buckets or severities = all of them)
+type Route struct {
+	Notifier   Notifier
+	Buckets    []string // Bucket names, matched like --bucket (case-insensitive, emoji optional)
+	Severities []int    // Bucket severities
+}
+
+// Filtered reports whether the route receives only some buckets
+func (r Route) Filtered() bool {
+	return len(r.Buckets) > 0 || len(r.Severities) > 0
+}
+
+// Select returns the buckets routed to this destination
+// At-risk bugs belong to no bucket, so only unfiltered routes receive them
+func (r Route) Select(bucketGroup *domain.BucketGroup) *domain.BucketGroup {
+	if !r.Filtered() {
+		return bucketGroup
+	}
+
+	selected := &domain.BucketGroup{}
+	for _, bucket := range bucketGroup.Buckets {
+		if r.matches(bucket) {
+			selected.Buckets = append(selected.Buckets, bucket)
+		}
+	}
+	return selected
+}
+
+// matches reports whether a bucket is routed here by name or severity
+func (r Route) matches(bucket *domain.Bucket) bool {
+	if slices.Contains(r.Severities, bucket.Severity) {
+		return true
+	}
+	return slices.ContainsFunc(r.Buckets, func(name string) bool {
+		return domain.BucketNameMatches(bucket.Name, name)
+	})
+}
+
+// FromConfig builds the routes enabled in the configuration: the top-level notifiers
+// receive every bucket, and each configured route only its own
+func FromConfig(cfg config.NotifyConfig) []Route {
+	var routes []Route
+
+	if cfg.GitHub.Repo != "" {
+		routes = append(routes, Route{Notifier: NewGitHubNotifier(cfg.GitHub)})
+	}
+	for _, route := range cfg.Routes {
+		routes = append(routes, Route{
+			Notifier:   NewGitHubNotifier(route.GitHub),
+			Buckets:    route.Buckets,
+			Severities: route.Severities,
+		})
+	}
+
+	return routes
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestRouteSelect(t *testing.T) {
+	bucketGroup := &domain.BucketGroup{
+		Buckets: []*domain.Bucket{
+			{Name: "🔴 URGENT", Severity: 1},
+			{Name: "🟡 ATTENTION", Severity: 2},
+			{Name: "🔵 REVIEW", Severity: 3},
+		},
+		AtRisk: []*domain.AtRiskBug{{Bug: &domain.Bug{Key: "DEMO-9"}}},
+	}
+
+	tests := []struct {
+		name       string
+		route      Route
+		want       []string
+		wantAtRisk bool
+	}{
+		{"unfiltered gets everything", Route{}, []string{"🔴 URGENT", "🟡 ATTENTION", "🔵 REVIEW"}, true},
+		{"by name", Route{Buckets: []string{"urgent"}}, []string{"🔴 URGENT"}, false},
+		{"by severity", Route{Severities: []int{3}}, []string{"🔵 REVIEW"}, false},
+		{"name or severity", Route{Buckets: []string{"🟡 ATTENTION"}, Severities: []int{1}}, []string{"🔴 URGENT", "🟡 ATTENTION"}, false},
+		{"no match", Route{Buckets: []string{"hotfix"}}, nil, false},
+	}
+	for _, tt := range tests {
+		selected := tt.route.Select(bucketGroup)
+		var names []string
+		for _, bucket := range selected.Buckets {
+			names = append(names, bucket.Name)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("%s: Select buckets = %v, want %v", tt.name, names, tt.want)
+		}
+		if got := len(selected.AtRisk) > 0; got != tt.wantAtRisk {
+			t.Errorf("%s: Select kept at-risk bugs = %v, want %v", tt.name, got, tt.wantAtRisk)
+		}
+	}
+}
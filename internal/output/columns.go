@@ -0,0 +1,167 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// bucketRow aggregates one bucket table row: a single bug, or with dedupe a group sharing a summary
+type bucketRow struct {
+	bug         *domain.Bug // First bug in the group, used for shared fields
+	keys        []string    // Hyperlinked issue keys
+	assignees   []string
+	sources     []string
+	tags        []string
+	maxAge      float64  // Largest time since last update in the group, in days
+	maxCreated  float64  // Largest time since creation in the group, in days
+	comments    int      // Most comments on any bug in the group
+	overage     *float64 // Largest SLA overage in the group (nil if none breached a rule)
+	sinceDeploy bool     // Any bug in the group was created after the deploy time
+}
+
+// newBucketRow aggregates the fields shown for a group of bugs
+func newBucketRow(group []*domain.Bug) *bucketRow {
+	r := &bucketRow{bug: group[0]}
+	for _, b := range group {
+		// Create clickable links using OSC 8 escape sequence (supported by modern terminals)
+		r.keys = append(r.keys, hyperlink(b.URL(), b.Key))
+		r.maxAge = max(r.maxAge, b.AgeDays())
+		r.maxCreated = max(r.maxCreated, b.CreatedAgeDays())
+		r.comments = max(r.comments, b.CommentCount)
+		r.sinceDeploy = r.sinceDeploy || isSinceDeploy(b)
+		if b.Violation != nil {
+			over := b.Violation.OverageDays()
+			if r.overage == nil || over > *r.overage {
+				r.overage = &over
+			}
+		}
+		assignee := b.Assignee
+		if assignee == "" {
+			assignee = "Unassigned"
+		}
+		r.assignees = appendUnique(r.assignees, assignee)
+		r.sources = appendUnique(r.sources, b.Source)
+		for _, tag := range b.Tags {
+			r.tags = appendUnique(r.tags, tag)
+		}
+	}
+	return r
+}
+
+// appendUnique appends value unless the slice already contains it
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+// bucketColumn is a selectable column of the check output tables
+type bucketColumn struct {
+	name   string // Name used with --columns
+	header string
+	value  func(r *bucketRow) string
+}
+
+// bucketColumns lists every column displayBucket can render, in default order
+var bucketColumns = []bucketColumn{
+	{"key", "Key", func(r *bucketRow) string { return strings.Join(r.keys, ", ") }},
+	{"summary", "Summary", func(r *bucketRow) string { return truncateString(r.bug.Summary, 40) }},
+	{"priority", "Priority", func(r *bucketRow) string { return r.bug.Priority }},
+	{"status", "Status", func(r *bucketRow) string { return r.bug.Status }},
+	{"age", "Age", func(r *bucketRow) string { return formatAge(r.maxAge) }},
+	{"created", "Created", func(r *bucketRow) string { return formatAge(r.maxCreated) }},
+	{"over_by", "Over By", func(r *bucketRow) string { return formatOverage(r.overage) }},
+	{"assignee", "Assignee", func(r *bucketRow) string { return strings.Join(r.assignees, ", ") }},
+	{"comments", "Comments", func(r *bucketRow) string { return strconv.Itoa(r.comments) }},
+	{"source", "Source", func(r *bucketRow) string { return strings.Join(r.sources, ", ") }},
+	{"tags", "Tags", func(r *bucketRow) string { return strings.Join(r.tags, ", ") }},
+	{"new", "New", func(r *bucketRow) string { return formatSinceDeploy(r.sinceDeploy) }},
+}
+
+// selectedColumns holds the --columns selection in display order (nil = defaults)
+var selectedColumns []bucketColumn
+
+// SetColumns selects which bucket table columns to render and in what order (empty = defaults)
+func SetColumns(names []string) error {
+	selectedColumns = nil
+	for _, name := range names {
+		name = strings.ToLower(name)
+		i := slices.IndexFunc(bucketColumns, func(c bucketColumn) bool { return c.name == name })
+		if i < 0 {
+			return fmt.Errorf("unknown column %q (supported: %s)", name, strings.Join(columnNames(), ", "))
+		}
+		if slices.ContainsFunc(selectedColumns, func(c bucketColumn) bool { return c.name == name }) {
+			return fmt.Errorf("column %q listed more than once", name)
+		}
+		selectedColumns = append(selectedColumns, bucketColumns[i])
+	}
+	return nil
+}
+
+// ColumnSelected reports whether the --columns selection includes the named column
+func ColumnSelected(name string) bool {
+	return slices.ContainsFunc(selectedColumns, func(c bucketColumn) bool { return c.name == name })
+}
+
+// columnNames returns the names of all selectable columns
+func columnNames() []string {
+	names := make([]string, len(bucketColumns))
+	for i, c := range bucketColumns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// activeColumns returns the columns to render: the --columns selection, or the defaults
+// The defaults add Source, Tags, and New only when multi-instance, tags, or a deploy time are in use
+func activeColumns() []bucketColumn {
+	if len(selectedColumns) > 0 {
+		return selectedColumns
+	}
+
+	var columns []bucketColumn
+	for _, c := range bucketColumns {
+		switch c.name {
+		case "assignee", "comments":
+			continue
+		case "source":
+			if !showSource {
+				continue
+			}
+		case "tags":
+			if !showTags {
+				continue
+			}
+		case "new":
+			if deployTime.IsZero() {
+				continue
+			}
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// columnHeader builds the table header row for the given columns
+func columnHeader(columns []bucketColumn) table.Row {
+	header := make(table.Row, len(columns))
+	for i, c := range columns {
+		header[i] = c.header
+	}
+	return header
+}
+
+// columnRow builds a table row of the given columns' values
+func columnRow(columns []bucketColumn, r *bucketRow) table.Row {
+	row := make(table.Row, len(columns))
+	for i, c := range columns {
+		row[i] = c.value(r)
+	}
+	return row
+}
@@ -0,0 +1,107 @@
+package output
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestNewBucketRowAggregatesGroup(t *testing.T) {
+	defer func(saved bool) { plainMode = saved }(plainMode)
+	plainMode = true
+
+	now := time.Now()
+	group := []*domain.Bug{
+		{Key: "WEB-1", Summary: "Login fails", Assignee: "ana", Source: "cloud", Updated: now.AddDate(0, 0, -3),
+			Violation: &domain.Violation{MaxAgeDays: 2, AgeDays: 3}},
+		{Key: "MOB-3", Summary: "login fails", Source: "legacy", Updated: now.AddDate(0, 0, -9), Tags: []string{"customer"},
+			Violation: &domain.Violation{MaxAgeDays: 2, AgeDays: 9}},
+		{Key: "WEB-4", Summary: "Login fails!", Assignee: "ana", Source: "cloud", Updated: now.AddDate(0, 0, -1)},
+	}
+
+	r := newBucketRow(group)
+
+	if want := []string{"WEB-1", "MOB-3", "WEB-4"}; !slices.Equal(r.keys, want) {
+		t.Errorf("keys = %v, want %v", r.keys, want)
+	}
+	if want := []string{"ana", "Unassigned"}; !slices.Equal(r.assignees, want) {
+		t.Errorf("assignees = %v, want %v", r.assignees, want)
+	}
+	if want := []string{"cloud", "legacy"}; !slices.Equal(r.sources, want) {
+		t.Errorf("sources = %v, want %v", r.sources, want)
+	}
+	if want := []string{"customer"}; !slices.Equal(r.tags, want) {
+		t.Errorf("tags = %v, want %v", r.tags, want)
+	}
+	if r.bug.Key != "WEB-1" {
+		t.Errorf("row bug = %s, want the first in the group", r.bug.Key)
+	}
+	if r.maxAge < 8.9 || r.maxAge > 9.1 {
+		t.Errorf("maxAge = %.2f, want the oldest bug's ~9 days", r.maxAge)
+	}
+	if r.overage == nil || *r.overage != 7 {
+		t.Errorf("overage = %v, want the largest overage of 7 days", r.overage)
+	}
+}
+
+func TestSetColumns(t *testing.T) {
+	defer func(saved []bucketColumn) { selectedColumns = saved }(selectedColumns)
+
+	tests := []struct {
+		names   []string
+		want    []string
+		wantErr string
+	}{
+		{nil, []string{"Key", "Summary", "Priority", "Status", "Age", "Created", "Over By"}, ""},
+		{[]string{"key", "PRIORITY", "assignee", "age"}, []string{"Key", "Priority", "Assignee", "Age"}, ""},
+		{[]string{"age", "key"}, []string{"Age", "Key"}, ""},
+		{[]string{"key", "reporter"}, nil, `unknown column "reporter"`},
+		{[]string{"key", "Key"}, nil, `column "key" listed more than once`},
+	}
+
+	for _, tt := range tests {
+		err := SetColumns(tt.names)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SetColumns(%v) = %v, want an error containing %q", tt.names, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SetColumns(%v): %v", tt.names, err)
+			continue
+		}
+
+		var headers []string
+		for _, cell := range columnHeader(activeColumns()) {
+			headers = append(headers, cell.(string))
+		}
+		if !slices.Equal(headers, tt.want) {
+			t.Errorf("SetColumns(%v) columns = %v, want %v", tt.names, headers, tt.want)
+		}
+	}
+}
+
+func TestAgeAndCreatedColumns(t *testing.T) {
+	defer func(saved []bucketColumn) { selectedColumns = saved }(selectedColumns)
+
+	// Old but recently active: created 40 days ago, updated 2 days ago
+	now := time.Now()
+	bug := &domain.Bug{Key: "DEMO-1", Created: now.Add(-40 * 24 * time.Hour), Updated: now.Add(-2 * 24 * time.Hour)}
+
+	if err := SetColumns([]string{"age", "created"}); err != nil {
+		t.Fatalf("SetColumns: %v", err)
+	}
+	columns := activeColumns()
+	if got, want := columnHeader(columns), (table.Row{"Age", "Created"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("header = %v, want %v", got, want)
+	}
+	if got, want := columnRow(columns, newBucketRow([]*domain.Bug{bug})), (table.Row{"2.0 days", "5.7 weeks"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("row = %v, want %v", got, want)
+	}
+}
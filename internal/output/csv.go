@@ -0,0 +1,58 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// WriteTrendStatsCSV writes one row per month (created, resolved, unresolved, net, change %,
+// then a column per priority seen in any month) to a CSV file
+// Numbers are written locale-independently so the file can be imported anywhere
+func WriteTrendStatsCSV(path string, monthly []domain.MonthlyBugStats) error {
+	prioritySet := make(map[string]bool)
+	for _, m := range monthly {
+		for priority := range m.ByPriority {
+			prioritySet[priority] = true
+		}
+	}
+	priorities := priorityColumns(prioritySet)
+
+	records := make([][]string, 0, len(monthly)+1)
+	header := []string{"month", "created", "resolved", "unresolved", "net_change", "change_percent"}
+	records = append(records, append(header, priorities...))
+
+	for _, m := range monthly {
+		record := []string{
+			m.Month.Format("2006-01"),
+			strconv.Itoa(m.TotalCreated),
+			strconv.Itoa(m.TotalResolved),
+			strconv.Itoa(m.TotalUnresolved),
+			strconv.Itoa(m.NetChange),
+			strconv.FormatFloat(m.ChangePercent, 'f', 1, 64),
+		}
+		for _, priority := range priorities {
+			record = append(record, strconv.Itoa(m.ByPriority[priority]))
+		}
+		records = append(records, record)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV export: %w", err)
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(records); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write CSV export: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write CSV export: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestWriteTrendStatsCSV(t *testing.T) {
+	monthly := []domain.MonthlyBugStats{
+		{
+			Month:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			TotalCreated: 12, TotalResolved: 9, TotalUnresolved: 40, NetChange: 3, ChangePercent: 8.1,
+			ByPriority: map[string]int{"High": 5, "Low": 7},
+		},
+		{
+			Month:        time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			TotalCreated: 6, TotalResolved: 10, TotalUnresolved: 36, NetChange: -4, ChangePercent: -10,
+			ByPriority: map[string]int{"Critical": 1, "Blocker": 2, "Low": 3},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "trend.csv")
+	if err := WriteTrendStatsCSV(path, monthly); err != nil {
+		t.Fatalf("WriteTrendStatsCSV: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+
+	// Priority columns are the union across months: known priorities first, then others alphabetically
+	wantHeader := []string{"month", "created", "resolved", "unresolved", "net_change", "change_percent", "Critical", "High", "Low", "Blocker"}
+	if len(records) != 3 || !slices.Equal(records[0], wantHeader) {
+		t.Fatalf("CSV = %q, want header %q and 2 month rows", records, wantHeader)
+	}
+	if want := []string{"2025-02", "6", "10", "36", "-4", "-10.0", "1", "0", "3", "2"}; !slices.Equal(records[2], want) {
+		t.Errorf("February row = %q, want %q", records[2], want)
+	}
+}
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// DumpBugs writes the fetched bugs to a file as pretty-printed JSON
+func DumpBugs(path string, bugs []*domain.Bug) error {
+	if bugs == nil {
+		bugs = []*domain.Bug{}
+	}
+
+	data, err := json.MarshalIndent(bugs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bugs: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write bug dump: %w", err)
+	}
+
+	return nil
+}
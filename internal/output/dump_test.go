@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestDumpBugs(t *testing.T) {
+	created := time.Date(2025, 3, 4, 9, 30, 0, 0, time.UTC)
+	bugs := []*domain.Bug{{
+		Key:         "DEMO-1",
+		Summary:     "Login fails",
+		Priority:    "High",
+		Status:      "Backlog",
+		Created:     created,
+		FixVersions: []string{"2.4.0"},
+		Violation:   &domain.Violation{RuleName: "high"},
+	}}
+
+	path := filepath.Join(t.TempDir(), "bugs.json")
+	if err := DumpBugs(path, bugs); err != nil {
+		t.Fatalf("DumpBugs: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading dump: %v", err)
+	}
+
+	var dumped []map[string]any
+	if err := json.Unmarshal(data, &dumped); err != nil {
+		t.Fatalf("dump is not a JSON array: %v\n%s", err, data)
+	}
+	if len(dumped) != 1 {
+		t.Fatalf("got %d dumped bugs, want 1", len(dumped))
+	}
+
+	bug := dumped[0]
+	for field, want := range map[string]any{
+		"key":      "DEMO-1",
+		"summary":  "Login fails",
+		"priority": "High",
+		"status":   "Backlog",
+		"created":  "2025-03-04T09:30:00Z",
+	} {
+		if bug[field] != want {
+			t.Errorf("%s = %v, want %v", field, bug[field], want)
+		}
+	}
+	if versions, ok := bug["fix_versions"].([]any); !ok || len(versions) != 1 || versions[0] != "2.4.0" {
+		t.Errorf("fix_versions = %v, want [2.4.0]", bug["fix_versions"])
+	}
+	if _, ok := bug["Violation"]; ok {
+		t.Error("dump includes the evaluation-only Violation field")
+	}
+}
+
+func TestDumpBugsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bugs.json")
+	if err := DumpBugs(path, nil); err != nil {
+		t.Fatalf("DumpBugs: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading dump: %v", err)
+	}
+	if string(data) != "[]\n" {
+		t.Errorf("empty dump = %q, want %q", data, "[]\n")
+	}
+}
@@ -0,0 +1,41 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// DisplayDuplicates renders clusters of likely duplicate bugs
+func DisplayDuplicates(clusters [][]*domain.Bug) {
+	printSection("POSSIBLE DUPLICATES")
+
+	if len(clusters) == 0 {
+		fmt.Println("\nNo likely duplicates found.")
+		return
+	}
+
+	fmt.Printf("\n%d groups of bugs with similar summaries\n", len(clusters))
+
+	for i, cluster := range clusters {
+		fmt.Printf("\nGroup %d (%d bugs)\n", i+1, len(cluster))
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.SetStyle(baseTableStyle())
+		t.AppendHeader(table.Row{"Key", "Summary", "Priority", "Status"})
+
+		for _, bug := range cluster {
+			t.AppendRow(table.Row{
+				hyperlink(bug.URL(), bug.Key),
+				truncateString(bug.Summary, 60),
+				bug.Priority,
+				bug.Status,
+			})
+		}
+
+		t.Render()
+	}
+}
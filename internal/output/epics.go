@@ -0,0 +1,51 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// DisplayEpics renders violating bug counts per epic with a column per priority
+func DisplayEpics(rollups []domain.EpicRollup) {
+	printSection("BUGS BY EPIC")
+
+	if len(rollups) == 0 {
+		fmt.Println("\nNo violating bugs to group by epic.")
+		return
+	}
+
+	prioritySet := make(map[string]bool)
+	for _, r := range rollups {
+		for priority := range r.ByPriority {
+			prioritySet[priority] = true
+		}
+	}
+	priorities := priorityColumns(prioritySet)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+
+	header := table.Row{"Epic", "Bugs"}
+	for _, p := range priorities {
+		header = append(header, p)
+	}
+	t.AppendHeader(header)
+
+	for _, r := range rollups {
+		epic := r.EpicKey
+		if epic == "" {
+			epic = "(no epic)"
+		}
+		row := table.Row{epic, r.Count}
+		for _, p := range priorities {
+			row = append(row, r.ByPriority[p])
+		}
+		t.AppendRow(row)
+	}
+
+	t.Render()
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// WriteGitHubOutput appends violation counts as key=value lines to a GitHub Actions output file
+// Every bucket in bucketNames gets a "<name>_count" line (0 when empty), so downstream steps can rely on the keys
+func WriteGitHubOutput(path string, bucketNames []string, bucketGroup *domain.BucketGroup) error {
+	keys, counts := bucketCounts(bucketNames, bucketGroup.Buckets)
+	totalViolations := bucketGroup.ActiveViolations()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "total_violations=%d\n", totalViolations)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s_count=%d\n", key, counts[key])
+	}
+	fmt.Fprintf(&b, "at_risk_count=%d\n", len(bucketGroup.AtRisk))
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open GitHub output file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(b.String()); err != nil {
+		return fmt.Errorf("failed to write GitHub output file: %w", err)
+	}
+
+	return nil
+}
+
+// bucketCounts returns an output key per bucket with its bug count, shared by the GitHub output,
+// summary line, and metrics file: bucketNames come first in order (0 when empty or absent), then
+// any other buckets by name; buckets whose names give the same key are counted together
+func bucketCounts(bucketNames []string, buckets []*domain.Bucket) ([]string, map[string]int) {
+	counts := make(map[string]int)
+	var keys []string
+	addKey := func(name string) string {
+		key := outputKey(name)
+		if key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+		return key
+	}
+
+	for _, name := range bucketNames {
+		addKey(name)
+	}
+
+	others := slices.Clone(buckets)
+	slices.SortStableFunc(others, func(a, b *domain.Bucket) int { return strings.Compare(a.Name, b.Name) })
+	for _, bucket := range others {
+		counts[addKey(bucket.Name)] += len(bucket.Bugs)
+	}
+	return keys, counts
+}
+
+// outputKey converts a bucket name to an output key (e.g., "🟡 ATTENTION NEEDED" -> "attention_needed")
+func outputKey(name string) string {
+	var b strings.Builder
+	pendingSep := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingSep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pendingSep = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingSep = true
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestWriteGitHubOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "github_output")
+	if err := os.WriteFile(path, []byte("existing=1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bucketGroup := &domain.BucketGroup{
+		Buckets: []*domain.Bucket{
+			{Name: "🔴 URGENT", Bugs: []*domain.Bug{{Key: "A-1"}, {Key: "A-2"}}},
+			{Name: domain.SnoozedBucketName, Bugs: []*domain.Bug{{Key: "A-3"}}},
+		},
+		AtRisk: []*domain.AtRiskBug{{Bug: &domain.Bug{Key: "A-4"}}},
+	}
+
+	err := WriteGitHubOutput(path, []string{"🔴 URGENT", "🟡 ATTENTION NEEDED"}, bucketGroup)
+	if err != nil {
+		t.Fatalf("WriteGitHubOutput: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "existing=1\n" +
+		"total_violations=2\n" +
+		"urgent_count=2\n" +
+		"attention_needed_count=0\n" +
+		"snoozed_count=1\n" +
+		"at_risk_count=1\n"
+	if string(got) != want {
+		t.Errorf("GitHub output file =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestOutputKey(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"🔴 URGENT", "urgent"},
+		{"🟡 ATTENTION NEEDED", "attention_needed"},
+		{"P1 - Critical!", "p1_critical"},
+		{"🔥", ""},
+	}
+
+	for _, tt := range tests {
+		if got := outputKey(tt.name); got != tt.want {
+			t.Errorf("outputKey(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBucketCounts(t *testing.T) {
+	buckets := []*domain.Bucket{
+		{Name: "🚒 Hot-fix", Bugs: []*domain.Bug{{Key: "A-1"}}},
+		{Name: "🔴 URGENT", Bugs: []*domain.Bug{{Key: "A-2"}, {Key: "A-3"}}},
+		{Name: "📋 Needs Data", Bugs: []*domain.Bug{{Key: "A-4"}}},
+		{Name: "🔴 Urgent", Bugs: []*domain.Bug{{Key: "A-5"}}},
+	}
+
+	keys, counts := bucketCounts([]string{"🟡 ATTENTION NEEDED", "🔴 URGENT"}, buckets)
+
+	// Configured names keep their order, the rest follow by name, and same-key buckets add up
+	if want := []string{"attention_needed", "urgent", "needs_data", "hot_fix"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+	if want := map[string]int{"urgent": 3, "needs_data": 1, "hot_fix": 1}; !maps.Equal(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+}
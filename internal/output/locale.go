@@ -0,0 +1,196 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// localeFormat describes how dates and numbers are rendered for a locale
+type localeFormat struct {
+	dateLayout  string     // Full date layout (e.g., "2006-01-02")
+	months      [12]string // Full month names
+	shortMonths [12]string // Abbreviated month names
+	weekdays    [7]string  // Full weekday names, Sunday first (time.Weekday order)
+	decimal     string     // Decimal separator
+	thousands   string     // Thousands separator
+}
+
+var englishMonths = [12]string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+var englishShortMonths = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+	"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+var englishWeekdays = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// locales maps supported locale names to their formats
+var locales = map[string]localeFormat{
+	"en-US": {
+		dateLayout:  "2006-01-02",
+		months:      englishMonths,
+		shortMonths: englishShortMonths,
+		weekdays:    englishWeekdays,
+		decimal:     ".",
+		thousands:   ",",
+	},
+	"en-GB": {
+		dateLayout:  "02/01/2006",
+		months:      englishMonths,
+		shortMonths: englishShortMonths,
+		weekdays:    englishWeekdays,
+		decimal:     ".",
+		thousands:   ",",
+	},
+	"de-DE": {
+		dateLayout: "02.01.2006",
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+			"Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		weekdays:  [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		decimal:   ",",
+		thousands: ".",
+	},
+	"fr-FR": {
+		dateLayout: "02/01/2006",
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:  [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		decimal:   ",",
+		thousands: " ",
+	},
+}
+
+// currentLocale is the active output locale (US-style by default)
+var currentLocale = locales["en-US"]
+
+// dateFormatPresets maps named date formats to their full date and month layouts
+var dateFormatPresets = map[string]struct{ date, month string }{
+	"iso": {date: "2006-01-02", month: "2006-01"},
+}
+
+// Layouts overriding the locale's dates and month labels (empty = locale)
+var (
+	dateLayout  string
+	monthLayout string
+)
+
+// SetLocale selects the output locale for dates and numbers (empty = en-US)
+func SetLocale(name string) error {
+	if name == "" {
+		name = "en-US"
+	}
+	format, ok := locales[name]
+	if !ok {
+		return fmt.Errorf("unsupported locale %q (supported: %s)", name, strings.Join(supportedLocales(), ", "))
+	}
+	currentLocale = format
+	return nil
+}
+
+// SetDateFormat overrides the locale's date rendering. dateFormat is a Go layout or a
+// preset name; monthFormat is a Go layout for month labels, defaulting to the preset's
+func SetDateFormat(dateFormat, monthFormat string) error {
+	dateLayout, monthLayout = "", ""
+	if preset, ok := dateFormatPresets[dateFormat]; ok {
+		dateLayout, monthLayout = preset.date, preset.month
+	} else if dateFormat != "" {
+		if err := validateLayout(dateFormat); err != nil {
+			return fmt.Errorf("invalid date format: %w", err)
+		}
+		dateLayout = dateFormat
+	}
+	if monthFormat != "" {
+		if err := validateLayout(monthFormat); err != nil {
+			return fmt.Errorf("invalid month format: %w", err)
+		}
+		monthLayout = monthFormat
+	}
+	return nil
+}
+
+// validateLayout checks that a Go time layout renders a date and parses back
+func validateLayout(layout string) error {
+	reference := time.Date(2009, time.November, 17, 20, 34, 58, 0, time.UTC)
+	formatted := reference.Format(layout)
+	if formatted == layout {
+		return fmt.Errorf("layout %q has no date elements (use a Go layout like \"02 Jan 2006\" or a preset: iso)", layout)
+	}
+	if _, err := time.Parse(layout, formatted); err != nil {
+		return fmt.Errorf("layout %q doesn't parse: %w", layout, err)
+	}
+	return nil
+}
+
+// supportedLocales returns the sorted list of supported locale names
+func supportedLocales() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FormatDate renders a full date in the configured date format or the active locale
+func FormatDate(t time.Time) string {
+	if dateLayout != "" {
+		return t.Format(dateLayout)
+	}
+	return t.Format(currentLocale.dateLayout)
+}
+
+// formatMonth renders an abbreviated month and year (e.g., "Jan 2006")
+func formatMonth(t time.Time) string {
+	if monthLayout != "" {
+		return t.Format(monthLayout)
+	}
+	return currentLocale.shortMonths[t.Month()-1] + " " + strconv.Itoa(t.Year())
+}
+
+// formatLongMonth renders a full month name and year (e.g., "January 2006")
+func formatLongMonth(t time.Time) string {
+	if monthLayout != "" {
+		return t.Format(monthLayout)
+	}
+	return currentLocale.months[t.Month()-1] + " " + strconv.Itoa(t.Year())
+}
+
+// formatWeekday renders a weekday's full name in the locale (e.g., "Monday")
+func formatWeekday(day time.Weekday) string {
+	return currentLocale.weekdays[day]
+}
+
+// formatFloat renders a number with the given decimals using the locale separators
+func formatFloat(value float64, decimals int) string {
+	formatted := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+
+	intPart, fracPart, _ := strings.Cut(formatted, ".")
+
+	// Group the integer part into thousands
+	var grouped strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			grouped.WriteString(currentLocale.thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	result := grouped.String()
+	if fracPart != "" {
+		result += currentLocale.decimal + fracPart
+	}
+	if value < 0 && strings.Trim(formatted, "0.") != "" {
+		result = "-" + result
+	}
+	return result
+}
+
+// formatPercent renders a percentage with the given decimals (e.g., "12.5%")
+func formatPercent(value float64, decimals int) string {
+	return formatFloat(value, decimals) + "%"
+}
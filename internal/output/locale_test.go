@@ -0,0 +1,85 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleFormatting(t *testing.T) {
+	defer func(saved localeFormat) { currentLocale = saved }(currentLocale)
+
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		locale      string
+		wantDate    string
+		wantMonth   string
+		wantPercent string
+		wantNumber  string
+	}{
+		{"", "2025-03-04", "Mar 2025", "12.5%", "1,234.5"},
+		{"en-GB", "04/03/2025", "Mar 2025", "12.5%", "1,234.5"},
+		{"de-DE", "04.03.2025", "Mär 2025", "12,5%", "1.234,5"},
+		{"fr-FR", "04/03/2025", "mars 2025", "12,5%", "1 234,5"},
+	}
+
+	for _, tt := range tests {
+		if err := SetLocale(tt.locale); err != nil {
+			t.Fatalf("SetLocale(%q): %v", tt.locale, err)
+		}
+		if got := FormatDate(date); got != tt.wantDate {
+			t.Errorf("%s: FormatDate = %q, want %q", tt.locale, got, tt.wantDate)
+		}
+		if got := formatMonth(date); got != tt.wantMonth {
+			t.Errorf("%s: formatMonth = %q, want %q", tt.locale, got, tt.wantMonth)
+		}
+		if got := formatPercent(12.5, 1); got != tt.wantPercent {
+			t.Errorf("%s: formatPercent = %q, want %q", tt.locale, got, tt.wantPercent)
+		}
+		if got := formatFloat(1234.5, 1); got != tt.wantNumber {
+			t.Errorf("%s: formatFloat = %q, want %q", tt.locale, got, tt.wantNumber)
+		}
+	}
+
+	if err := SetLocale("xx-XX"); err == nil {
+		t.Error("SetLocale(xx-XX) = nil, want an unsupported locale error")
+	}
+}
+
+func TestSetDateFormat(t *testing.T) {
+	defer func(date, month string) { dateLayout, monthLayout = date, month }(dateLayout, monthLayout)
+
+	date := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		dateFormat, monthFormat string
+		wantDate, wantMonth     string
+		wantLongMonth           string
+	}{
+		{"", "", "2025-03-04", "Mar 2025", "March 2025"},
+		{"iso", "", "2025-03-04", "2025-03", "2025-03"},
+		{"02 Jan 2006", "", "04 Mar 2025", "Mar 2025", "March 2025"},
+		{"iso", "January '06", "2025-03-04", "March '25", "March '25"},
+		{"", "01/2006", "2025-03-04", "03/2025", "03/2025"},
+	}
+	for _, tt := range tests {
+		if err := SetDateFormat(tt.dateFormat, tt.monthFormat); err != nil {
+			t.Fatalf("SetDateFormat(%q, %q): %v", tt.dateFormat, tt.monthFormat, err)
+		}
+		if got := FormatDate(date); got != tt.wantDate {
+			t.Errorf("SetDateFormat(%q, %q): FormatDate = %q, want %q", tt.dateFormat, tt.monthFormat, got, tt.wantDate)
+		}
+		if got := formatMonth(date); got != tt.wantMonth {
+			t.Errorf("SetDateFormat(%q, %q): formatMonth = %q, want %q", tt.dateFormat, tt.monthFormat, got, tt.wantMonth)
+		}
+		if got := formatLongMonth(date); got != tt.wantLongMonth {
+			t.Errorf("SetDateFormat(%q, %q): formatLongMonth = %q, want %q", tt.dateFormat, tt.monthFormat, got, tt.wantLongMonth)
+		}
+	}
+
+	for _, invalid := range [][2]string{{"yyyy-mm-dd", ""}, {"", "month"}} {
+		if err := SetDateFormat(invalid[0], invalid[1]); err == nil {
+			t.Errorf("SetDateFormat(%q, %q) = nil, want an error", invalid[0], invalid[1])
+		}
+	}
+}
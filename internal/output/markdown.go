@@ -0,0 +1,106 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// RenderMarkdown renders the bucket groups as a Markdown report (for notifications)
+// With maxBugs > 0 only the maxBugs most overdue bugs are listed, with an "and X more" line per bucket
+// With summaryWidth > 0 summaries are truncated to that many characters, independent of the terminal table
+func RenderMarkdown(bucketGroup *domain.BucketGroup, maxBugs, summaryWidth int) string {
+	var b strings.Builder
+
+	b.WriteString("## Bug Butler - SLA Violation Report\n\n")
+
+	if len(bucketGroup.Buckets) == 0 {
+		b.WriteString("✅ All bugs are compliant with SLA rules!\n")
+		return b.String()
+	}
+
+	totalViolations := 0
+	for _, bucket := range bucketGroup.Buckets {
+		totalViolations += len(bucket.Bugs)
+	}
+	fmt.Fprintf(&b, "**Total SLA violations: %d**\n", totalViolations)
+
+	var listed map[*domain.Bug]bool
+	if maxBugs > 0 && totalViolations > maxBugs {
+		listed = mostOverdue(bucketGroup, maxBugs)
+		fmt.Fprintf(&b, "\nShowing the %d most overdue bugs.\n", maxBugs)
+	}
+
+	for _, bucket := range bucketGroup.Buckets {
+		fmt.Fprintf(&b, "\n### %s (%d bugs)\n\n", bucket.Name, len(bucket.Bugs))
+
+		bugs := bucket.Bugs
+		if listed != nil {
+			bugs = slices.DeleteFunc(slices.Clone(bugs), func(bug *domain.Bug) bool { return !listed[bug] })
+		}
+		hidden := len(bucket.Bugs) - len(bugs)
+		if len(bugs) == 0 {
+			fmt.Fprintf(&b, "_…and %d more_\n", hidden)
+			continue
+		}
+
+		b.WriteString("| Key | Summary | Priority | Status | Age | Created |\n")
+		b.WriteString("|-----|---------|----------|--------|-----|---------|\n")
+
+		for _, bug := range bugs {
+			summary := bug.Summary
+			if summaryWidth > 0 {
+				summary = truncateString(summary, summaryWidth)
+			}
+			fmt.Fprintf(&b, "| [%s](%s) | %s | %s | %s | %s | %s |\n",
+				bug.Key,
+				bug.URL(),
+				escapeMarkdownCell(summary),
+				escapeMarkdownCell(bug.Priority),
+				escapeMarkdownCell(bug.Status),
+				formatAge(bug.AgeDays()),
+				formatAge(bug.CreatedAgeDays()),
+			)
+		}
+		if hidden > 0 {
+			fmt.Fprintf(&b, "\n_…and %d more_\n", hidden)
+		}
+	}
+
+	return b.String()
+}
+
+// mostOverdue returns the n bugs furthest past their SLA threshold across all buckets
+// Bugs without a violation (e.g., missing data) rank after all overdue bugs
+func mostOverdue(bucketGroup *domain.BucketGroup, n int) map[*domain.Bug]bool {
+	var bugs []*domain.Bug
+	for _, bucket := range bucketGroup.Buckets {
+		bugs = append(bugs, bucket.Bugs...)
+	}
+
+	overage := func(bug *domain.Bug) float64 {
+		if bug.Violation == nil {
+			return math.Inf(-1)
+		}
+		return bug.Violation.OverageDays()
+	}
+	sort.SliceStable(bugs, func(i, j int) bool {
+		return overage(bugs[i]) > overage(bugs[j])
+	})
+
+	listed := make(map[*domain.Bug]bool, n)
+	for _, bug := range bugs[:min(n, len(bugs))] {
+		listed[bug] = true
+	}
+	return listed
+}
+
+// escapeMarkdownCell escapes characters that would break a Markdown table cell
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{{
+		Name:     "🔴 URGENT",
+		Severity: 1,
+		Bugs: []*domain.Bug{{
+			Key:      "DEMO-1",
+			Summary:  "Login | signup broken",
+			Priority: "Critical",
+			Status:   "Backlog",
+			Created:  time.Now().Add(-72 * time.Hour),
+			Updated:  time.Now().Add(-48 * time.Hour),
+			BaseURL:  "https://example.atlassian.net",
+		}},
+	}}}
+
+	report := RenderMarkdown(bucketGroup, 0, 0)
+
+	for _, want := range []string{
+		"## Bug Butler - SLA Violation Report",
+		"**Total SLA violations: 1**",
+		"### 🔴 URGENT (1 bugs)",
+		"| [DEMO-1](https://example.atlassian.net/browse/DEMO-1) | Login \\| signup broken | Critical | Backlog | 2.0 days | 3.0 days |",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+
+	if empty := RenderMarkdown(&domain.BucketGroup{}, 0, 0); !strings.Contains(empty, "All bugs are compliant") {
+		t.Errorf("empty report = %q, want the compliant message", empty)
+	}
+}
+
+func TestRenderMarkdownMaxBugs(t *testing.T) {
+	// overdue builds a bug that is the given number of days past its threshold
+	overdue := func(key string, days float64) *domain.Bug {
+		return &domain.Bug{Key: key, Violation: &domain.Violation{MaxAgeDays: 7, AgeDays: 7 + days}}
+	}
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{
+		{Name: "🔴 URGENT", Bugs: []*domain.Bug{overdue("DEMO-1", 1), overdue("DEMO-2", 30), overdue("DEMO-3", 20)}},
+		{Name: "🟡 WARNING", Bugs: []*domain.Bug{overdue("DEMO-4", 2), {Key: "DEMO-5"}}},
+	}}
+
+	report := RenderMarkdown(bucketGroup, 2, 0)
+
+	for _, want := range []string{
+		"**Total SLA violations: 5**",
+		"Showing the 2 most overdue bugs.",
+		"### 🔴 URGENT (3 bugs)",
+		"[DEMO-2]",
+		"[DEMO-3]",
+		"_…and 1 more_",
+		"### 🟡 WARNING (2 bugs)",
+		"_…and 2 more_",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	for _, hidden := range []string{"[DEMO-1]", "[DEMO-4]", "[DEMO-5]"} {
+		if strings.Contains(report, hidden) {
+			t.Errorf("report lists %s beyond the cap:\n%s", hidden, report)
+		}
+	}
+
+	// At or under the cap, every bug is listed without a "more" line
+	full := RenderMarkdown(bucketGroup, 5, 0)
+	if strings.Contains(full, "more_") || strings.Contains(full, "Showing the") {
+		t.Errorf("report under the cap was truncated:\n%s", full)
+	}
+}
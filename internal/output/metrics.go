@@ -0,0 +1,59 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// Metric names written by WriteMetricsFile, all gauges describing the latest check run
+const (
+	metricUnresolvedBugs = "bugbutler_unresolved_bugs"
+	metricViolations     = "bugbutler_violations"
+	metricBucketBugs     = "bugbutler_bucket_bugs"
+	metricAtRiskBugs     = "bugbutler_at_risk_bugs"
+	metricSnoozedBugs    = "bugbutler_snoozed_bugs"
+)
+
+// WriteMetricsFile writes the check results as gauges in OpenMetrics text format, replacing the file
+// Every bucket in bucketNames gets a bucket_bugs sample (0 when empty) so series don't disappear between runs
+func WriteMetricsFile(path string, bucketNames []string, bucketGroup *domain.BucketGroup, unresolvedBugs int) error {
+	// Snoozed bugs get their own gauge rather than a bucket sample
+	var active []*domain.Bucket
+	snoozed := 0
+	for _, bucket := range bucketGroup.Buckets {
+		if bucket.Name == domain.SnoozedBucketName {
+			snoozed += len(bucket.Bugs)
+			continue
+		}
+		active = append(active, bucket)
+	}
+	keys, counts := bucketCounts(bucketNames, active)
+
+	var b strings.Builder
+	writeGauge(&b, metricUnresolvedBugs, "Unresolved bugs fetched from Jira", unresolvedBugs)
+	writeGauge(&b, metricViolations, "Active SLA violations in the reported buckets", bucketGroup.ActiveViolations())
+	fmt.Fprintf(&b, "# HELP %s Bugs in each bucket, excluding snoozed bugs\n", metricBucketBugs)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", metricBucketBugs)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s{bucket=\"%s\"} %d\n", metricBucketBugs, key, counts[key])
+	}
+	writeGauge(&b, metricAtRiskBugs, "Compliant bugs nearing their SLA threshold", len(bucketGroup.AtRisk))
+	writeGauge(&b, metricSnoozedBugs, "Violating bugs deferred with snooze", snoozed)
+	b.WriteString("# EOF\n")
+
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+
+	return nil
+}
+
+// writeGauge writes the HELP and TYPE lines and a single unlabeled sample for a gauge
+func writeGauge(b *strings.Builder, name, help string, value int) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(b, "%s %d\n", name, value)
+}
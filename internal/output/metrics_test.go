@@ -0,0 +1,53 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestWriteMetricsFile(t *testing.T) {
+	bucketGroup := &domain.BucketGroup{
+		Buckets: []*domain.Bucket{
+			{Name: "🔴 Critical", Bugs: []*domain.Bug{{Key: "DEMO-1"}, {Key: "DEMO-2"}}},
+			{Name: "🟣 NEEDS DATA", Bugs: []*domain.Bug{{Key: "DEMO-3"}}},
+			{Name: domain.SnoozedBucketName, Bugs: []*domain.Bug{{Key: "DEMO-4"}}},
+		},
+		AtRisk: []*domain.AtRiskBug{{Bug: &domain.Bug{Key: "DEMO-5"}}},
+	}
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+
+	// The configured but empty Stale bucket is still written, as 0
+	if err := WriteMetricsFile(path, []string{"🔴 Critical", "🟡 Stale"}, bucketGroup, 12); err != nil {
+		t.Fatalf("WriteMetricsFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading metrics file: %v", err)
+	}
+
+	want := `# HELP bugbutler_unresolved_bugs Unresolved bugs fetched from Jira
+# TYPE bugbutler_unresolved_bugs gauge
+bugbutler_unresolved_bugs 12
+# HELP bugbutler_violations Active SLA violations in the reported buckets
+# TYPE bugbutler_violations gauge
+bugbutler_violations 3
+# HELP bugbutler_bucket_bugs Bugs in each bucket, excluding snoozed bugs
+# TYPE bugbutler_bucket_bugs gauge
+bugbutler_bucket_bugs{bucket="critical"} 2
+bugbutler_bucket_bugs{bucket="stale"} 0
+bugbutler_bucket_bugs{bucket="needs_data"} 1
+# HELP bugbutler_at_risk_bugs Compliant bugs nearing their SLA threshold
+# TYPE bugbutler_at_risk_bugs gauge
+bugbutler_at_risk_bugs 1
+# HELP bugbutler_snoozed_bugs Violating bugs deferred with snooze
+# TYPE bugbutler_snoozed_bugs gauge
+bugbutler_snoozed_bugs 1
+# EOF
+`
+	if string(data) != want {
+		t.Errorf("metrics file =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,287 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// FormatPDF is the stats --format value that also writes the report to a PDF file
+const FormatPDF = "pdf"
+
+// A4 page size and margin in PDF points
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+)
+
+// WriteTrendStatsPDF writes the backlog chart, goal progress, and monthly table to a PDF file
+func WriteTrendStatsPDF(path string, stats *domain.TrendStats) error {
+	doc := newPDFDocument()
+
+	doc.line(18, true, "Bug Butler - Trend Statistics")
+	doc.line(10, false, fmt.Sprintf("Generated %s, last %d months", FormatDate(time.Now()), len(stats.MonthlyData)))
+
+	if len(stats.MonthlyData) == 0 {
+		doc.space(12)
+		doc.line(11, false, "No bug data available for the selected time range")
+	} else {
+		writePDFBacklogChart(doc, stats.MonthlyData, stats.ReductionGoal)
+		for _, goal := range stats.Goals {
+			writePDFGoal(doc, stats, goal, len(stats.Goals) > 1)
+		}
+		writePDFMonthlyTable(doc, stats.MonthlyData)
+	}
+
+	if err := os.WriteFile(path, doc.bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write PDF report: %w", err)
+	}
+	return nil
+}
+
+// writePDFBacklogChart draws the unresolved backlog as a bar chart with the target as a dashed line
+func writePDFBacklogChart(doc *pdfDocument, monthly []domain.MonthlyBugStats, reductionGoal float64) {
+	target, hasTarget := backlogTarget(monthly, reductionGoal)
+	monthly = lastMonths(monthly, sparklineMonths)
+
+	title := "Unresolved Bug Backlog"
+	if weightedBacklog {
+		title += ", Weighted by Priority"
+	}
+	doc.space(12)
+	doc.line(13, true, title)
+
+	const chartHeight = 120.0
+	doc.reserve(chartHeight + 30)
+	top := doc.y - 10
+	bottom := top - chartHeight
+
+	high := 0.0
+	for _, m := range monthly {
+		high = max(high, backlogValue(m))
+	}
+	if hasTarget {
+		high = max(high, target)
+	}
+	if high == 0 {
+		high = 1
+	}
+
+	width := pdfPageWidth - 2*pdfMargin
+	slot := width / float64(len(monthly))
+	for i, m := range monthly {
+		height := backlogValue(m) / high * chartHeight
+		doc.rect(pdfMargin+float64(i)*slot+slot*0.15, bottom, slot*0.7, height, 0.45)
+	}
+	doc.hline(pdfMargin, pdfMargin+width, bottom, false)
+	if hasTarget {
+		y := bottom + target/high*chartHeight
+		doc.hline(pdfMargin, pdfMargin+width, y, true)
+		doc.text(pdfMargin+width-120, y+3, 8, false, "target ("+formatBacklog(target)+")")
+	}
+	doc.text(pdfMargin, top+2, 8, false, "max "+formatBacklog(high))
+
+	// Label the first and last months under the axis
+	doc.text(pdfMargin, bottom-12, 8, false, monthLabel(monthly[0]))
+	if len(monthly) > 1 {
+		last := monthLabel(monthly[len(monthly)-1])
+		doc.text(pdfMargin+width-float64(len(last))*4.5, bottom-12, 8, false, last)
+	}
+	doc.y = bottom - 16
+}
+
+// writePDFGoal writes the current goal period's target and status against one baseline
+func writePDFGoal(doc *pdfDocument, stats *domain.TrendStats, goal domain.GoalProgress, named bool) {
+	title, period := "Current Month Goal", formatLongMonth(stats.CurrentMonth.Month)
+	if stats.GoalMode == "trailing_30_days" {
+		title, period = "Trailing 30-Day Goal", "Last 30 days"
+	}
+	if named {
+		title += " (" + baselineComparison(goal.OffsetMonths) + ")"
+	}
+	status := "On track"
+	if !goal.OnTrack {
+		status = "Over target"
+	}
+	baselineName := baselinePeriod(goal.OffsetMonths)
+
+	doc.space(12)
+	doc.line(13, true, title)
+	doc.line(10, false, period)
+	doc.line(10, false, fmt.Sprintf("%s%s: %d bugs created", strings.ToUpper(baselineName[:1]), baselineName[1:], goal.Baseline))
+	doc.line(10, false, fmt.Sprintf("Target: <= %d bugs (%s reduction goal)", goal.Target, formatPercent(stats.ReductionGoal, 0)))
+	doc.line(10, false, fmt.Sprintf("Actual: %d bugs created so far", goal.Current))
+	doc.line(10, true, "Status: "+status)
+}
+
+// writePDFMonthlyTable writes every analyzed month, repeating the header on new pages
+func writePDFMonthlyTable(doc *pdfDocument, monthly []domain.MonthlyBugStats) {
+	columns := []float64{0, 150, 250, 350}
+	header := []string{"Month", "Created", "Resolved", "Unresolved"}
+
+	doc.space(12)
+	doc.line(13, true, "Monthly Bug Statistics")
+	doc.row(10, true, columns, header)
+	for _, m := range monthly {
+		if doc.newPageNeeded(15) {
+			doc.newPage()
+			doc.row(10, true, columns, header)
+		}
+		doc.row(10, false, columns, []string{
+			monthLabel(m),
+			formatFloat(float64(m.TotalCreated), 0),
+			formatFloat(float64(m.TotalResolved), 0),
+			formatFloat(float64(m.TotalUnresolved), 0),
+		})
+	}
+}
+
+// pdfDocument is a minimal PDF writer: Helvetica text, filled rectangles, and lines on A4 pages
+type pdfDocument struct {
+	pages []*bytes.Buffer // Content stream of each page
+	y     float64         // Current baseline on the last page, from the bottom edge
+}
+
+// newPDFDocument creates a document with one empty page
+func newPDFDocument() *pdfDocument {
+	doc := &pdfDocument{}
+	doc.newPage()
+	return doc
+}
+
+// newPage starts a page and moves to its top margin
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// newPageNeeded reports whether height no longer fits above the bottom margin
+func (d *pdfDocument) newPageNeeded(height float64) bool {
+	return d.y-height < pdfMargin
+}
+
+// reserve starts a new page unless height fits on the current one
+func (d *pdfDocument) reserve(height float64) {
+	if d.newPageNeeded(height) {
+		d.newPage()
+	}
+}
+
+// space moves down by height points
+func (d *pdfDocument) space(height float64) {
+	d.y -= height
+}
+
+// line writes a line of text at the left margin and moves below it
+func (d *pdfDocument) line(size float64, bold bool, s string) {
+	d.row(size, bold, []float64{0}, []string{s})
+}
+
+// row writes cells at the given offsets from the left margin and moves below them
+func (d *pdfDocument) row(size float64, bold bool, columns []float64, cells []string) {
+	height := size * 1.5
+	d.reserve(height)
+	d.y -= height
+	for i, cell := range cells {
+		d.text(pdfMargin+columns[i], d.y, size, bold, cell)
+	}
+}
+
+// text draws a string with its baseline at (x, y)
+func (d *pdfDocument) text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.current(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+// rect fills a rectangle in the given gray level (0 = black, 1 = white)
+func (d *pdfDocument) rect(x, y, width, height, gray float64) {
+	fmt.Fprintf(d.current(), "%.2f g %.2f %.2f %.2f %.2f re f 0 g\n", gray, x, y, width, height)
+}
+
+// hline draws a thin horizontal line, optionally dashed
+func (d *pdfDocument) hline(x1, x2, y float64, dashed bool) {
+	dash := "[] 0 d"
+	if dashed {
+		dash = "[4 3] 0 d"
+	}
+	fmt.Fprintf(d.current(), "%s 0.8 w %.2f %.2f m %.2f %.2f l S [] 0 d\n", dash, x1, y, x2, y)
+}
+
+// current returns the content stream of the last page
+func (d *pdfDocument) current() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// bytes assembles the document: catalog, page tree, fonts, then a page and content stream per page
+func (d *pdfDocument) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	const firstPage = 5 // Objects 1-4 are the catalog, page tree, and two fonts
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfReplacements spell out common symbols missing from the standard fonts' WinAnsi encoding
+var pdfReplacements = strings.NewReplacer("≤", "<=", "≥", ">=", "→", "->", "✓", "", "⚠", "")
+
+// pdfString encodes s for a PDF string literal in WinAnsi (Latin-1 plus a few symbols),
+// dropping emoji and escaping delimiters; other characters become "?"
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range pdfReplacements.Replace(stripEmoji(s)) {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '€':
+			b.WriteByte(0x80)
+		case r == '–':
+			b.WriteByte(0x96)
+		case r == '—':
+			b.WriteByte(0x97)
+		case r == '…':
+			b.WriteByte(0x85)
+		case r == '•':
+			b.WriteByte(0x95)
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestWriteTrendStatsPDF(t *testing.T) {
+	var monthly []domain.MonthlyBugStats
+	for i := range 13 {
+		monthly = append(monthly, domain.MonthlyBugStats{
+			Month:           time.Date(2024, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC),
+			TotalCreated:    10 + i,
+			TotalResolved:   8,
+			TotalUnresolved: 40 + 2*i,
+		})
+	}
+	baseline, current := monthly[0], monthly[12]
+	stats := &domain.TrendStats{
+		MonthlyData:   monthly,
+		CurrentMonth:  &current,
+		ReductionGoal: 20,
+		Goals:         []domain.GoalProgress{{OffsetMonths: 12, BaselineMonth: &baseline, Baseline: 10, Current: 22, Target: 8}},
+	}
+
+	for name, stats := range map[string]*domain.TrendStats{"full report": stats, "no data": {}} {
+		path := filepath.Join(t.TempDir(), "report.pdf")
+		if err := WriteTrendStatsPDF(path, stats); err != nil {
+			t.Fatalf("%s: WriteTrendStatsPDF: %v", name, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(data, []byte("%PDF-")) {
+			t.Errorf("%s: PDF starts with %q, want %%PDF-", name, data[:min(len(data), 8)])
+		}
+		if !bytes.HasSuffix(bytes.TrimSpace(data), []byte("%%EOF")) {
+			t.Errorf("%s: PDF doesn't end with %%%%EOF", name)
+		}
+	}
+}
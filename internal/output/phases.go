@@ -0,0 +1,38 @@
+package output
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// DisplayPhases shows the backlog grouped into status phases with each phase's share
+func DisplayPhases(phases []domain.PhaseCount) {
+	if len(phases) == 0 {
+		return
+	}
+
+	total := 0
+	for _, p := range phases {
+		total += p.Bugs
+	}
+
+	Println("\n🧭 Backlog by Phase")
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+
+	t.AppendHeader(table.Row{"Phase", "Bugs", "Share"})
+
+	for _, p := range phases {
+		share := 0.0
+		if total > 0 {
+			share = float64(p.Bugs) / float64(total) * 100
+		}
+		t.AppendRow(table.Row{p.Phase, p.Bugs, formatPercent(share, 1)})
+	}
+
+	t.Render()
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// spinnerFrames are cycled on each progress update
+var spinnerFrames = []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'}
+
+// Progress renders an in-place pagination indicator after a label
+// It only animates on an interactive terminal outside of plain mode
+type Progress struct {
+	label   string
+	enabled bool
+	frame   int
+	width   int
+}
+
+// NewProgress prints the label and returns an indicator that redraws after it
+func NewProgress(label string) *Progress {
+	p := &Progress{
+		label:   Decorate(label),
+		enabled: !plainMode && IsTerminal(os.Stdout),
+	}
+	fmt.Print(p.label)
+	return p
+}
+
+// Update redraws the indicator with the latest page count (matches jira.ProgressFunc)
+func (p *Progress) Update(page, fetched int) {
+	if !p.enabled {
+		return
+	}
+
+	line := fmt.Sprintf("%s %c page %d (%d issues)", p.label, spinnerFrames[p.frame%len(spinnerFrames)], page, fetched)
+	p.frame++
+
+	// Pad with spaces to overwrite any longer previous line
+	width := text.StringWidthWithoutEscSequences(line)
+	padding := ""
+	if p.width > width {
+		padding = strings.Repeat(" ", p.width-width)
+	}
+	p.width = width
+
+	fmt.Print("\r" + line + padding)
+}
+
+// Done clears the indicator, leaving only the label on the line
+func (p *Progress) Done() {
+	if !p.enabled || p.width == 0 {
+		return
+	}
+	fmt.Print("\r" + strings.Repeat(" ", p.width) + "\r" + p.label)
+	p.width = 0
+}
+
+// IsTerminal reports whether f is attached to an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
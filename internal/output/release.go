@@ -0,0 +1,63 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// DisplayReleaseReport renders resolved bug counts per week with a priority breakdown
+func DisplayReleaseReport(weeks []domain.WeeklyResolvedStats, start, end time.Time) {
+	printBanner("BUG BUTLER - RESOLVED BUGS BY WEEK")
+
+	// The end date is exclusive; show the last included day
+	fmt.Printf("\n%s to %s\n", FormatDate(start), FormatDate(end.AddDate(0, 0, -1)))
+
+	total := 0
+	prioritySet := make(map[string]bool)
+	for _, w := range weeks {
+		total += w.Resolved
+		for priority := range w.ByPriority {
+			prioritySet[priority] = true
+		}
+	}
+
+	if total == 0 {
+		Println("\n✅ No bugs were resolved in this period")
+		return
+	}
+
+	priorities := priorityColumns(prioritySet)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+
+	header := table.Row{"Week Of", "Resolved"}
+	for _, p := range priorities {
+		header = append(header, p)
+	}
+	t.AppendHeader(header)
+
+	totals := make(map[string]int)
+	for _, w := range weeks {
+		row := table.Row{FormatDate(w.WeekStart), w.Resolved}
+		for _, p := range priorities {
+			row = append(row, w.ByPriority[p])
+			totals[p] += w.ByPriority[p]
+		}
+		t.AppendRow(row)
+	}
+
+	footer := table.Row{"Total", total}
+	for _, p := range priorities {
+		footer = append(footer, totals[p])
+	}
+	t.AppendFooter(footer)
+
+	fmt.Println()
+	t.Render()
+}
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/config"
+)
+
+// DisplayRules renders the configured SLA rules as a table, optionally with an example violating bug per rule
+func DisplayRules(rules []config.SLARule, withExamples bool) {
+	printBanner("BUG BUTLER - SLA RULES")
+
+	if len(rules) == 0 {
+		fmt.Println("\nNo SLA rules configured.")
+		return
+	}
+
+	Printf("\n📏 %d rules, evaluated in order (first violation wins)\n\n", len(rules))
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+
+	header := table.Row{"#", "Name", "Priority", "Statuses", "Max Age", "Bucket", "Severity"}
+	if withExamples {
+		header = append(header, "Example Violation")
+	}
+	t.AppendHeader(header)
+
+	for i, rule := range rules {
+		name := rule.Name
+		if !rule.IsEnabled() {
+			name += " (disabled)"
+		}
+
+		// Tiered rules list each escalation step
+		maxAge := formatRuleDays(rule.MaxAgeDays)
+		bucket := Decorate(rule.Bucket)
+		severity := fmt.Sprintf("%d", rule.Severity)
+		if len(rule.Tiers) > 0 {
+			var ages, buckets, severities []string
+			for _, tier := range rule.Tiers {
+				ages = append(ages, formatRuleDays(tier.MaxAgeDays))
+				buckets = append(buckets, Decorate(tier.Bucket))
+				severities = append(severities, fmt.Sprintf("%d", tier.Severity))
+			}
+			maxAge = strings.Join(ages, " → ")
+			bucket = strings.Join(buckets, " → ")
+			severity = strings.Join(severities, " → ")
+		}
+		if rule.Clock == config.ClockWorkingHours {
+			maxAge += " (working)"
+		}
+
+		row := table.Row{
+			i + 1,
+			name,
+			orAny(rule.Priority),
+			orAny(strings.Join(rule.Status, ", ")),
+			maxAge,
+			bucket,
+			severity,
+		}
+		if withExamples {
+			row = append(row, exampleViolation(rule))
+		}
+		t.AppendRow(row)
+	}
+
+	t.Render()
+}
+
+// exampleViolation describes a synthetic bug that would violate the rule's first threshold
+func exampleViolation(rule config.SLARule) string {
+	threshold := rule.MaxAgeDays
+	for i, tier := range rule.Tiers {
+		if i == 0 || tier.MaxAgeDays < threshold {
+			threshold = tier.MaxAgeDays
+		}
+	}
+	exampleAge := math.Floor(threshold) + 1
+
+	priority := rule.Priority
+	if priority == "" {
+		priority = "Any"
+	}
+	status := "any status"
+	if len(rule.Status) > 0 {
+		status = rule.Status[0]
+	}
+
+	var measured string
+	switch rule.AgeFrom {
+	case "created":
+		measured = "created"
+	case "first_response":
+		measured = "created with no response"
+	default:
+		measured = "last updated"
+	}
+
+	example := fmt.Sprintf("%s bug in %s, %s %s ago", priority, status, measured, formatRuleDays(exampleAge))
+	if len(rule.FixVersion) > 0 {
+		example += fmt.Sprintf(", fix version %s", rule.FixVersion[0])
+	}
+	return example
+}
+
+// formatRuleDays renders a day threshold (e.g., "7 days", or "6.0 hours" for fractional days)
+func formatRuleDays(days float64) string {
+	if days != math.Trunc(days) {
+		return formatAge(days)
+	}
+	if days == 1 {
+		return "1 day"
+	}
+	return formatFloat(days, 0) + " days"
+}
+
+// orAny returns "any" for empty rule criteria
+func orAny(s string) string {
+	if s == "" {
+		return "any"
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+)
+
+func TestDisplayRules(t *testing.T) {
+	defer SetPlain(plainMode)
+	SetPlain(true)
+
+	disabled := false
+	rules := []config.SLARule{
+		{Name: "critical", Priority: "Critical", Status: []string{"Open", "In Progress"}, MaxAgeDays: 2, Bucket: "🔴 URGENT", Severity: 1},
+		{Name: "stale", MaxAgeDays: 30, Bucket: "🟡 ATTENTION", Severity: 2, AgeFrom: "created", Enabled: &disabled},
+		{Name: "high", Priority: "High", Bucket: "🟠 HIGH", Severity: 2, Tiers: []config.SLATier{
+			{MaxAgeDays: 7, Bucket: "🟠 HIGH", Severity: 2},
+			{MaxAgeDays: 14, Bucket: "🔥 ESCALATED", Severity: 1},
+		}},
+	}
+
+	out := captureStdout(t, func() { DisplayRules(rules, true) })
+
+	if !strings.Contains(out, "3 rules, evaluated in order") {
+		t.Errorf("rules output missing the rule count in:\n%s", out)
+	}
+
+	// Compare the trimmed cells of each numbered row
+	var rows [][]string
+	for _, line := range strings.Split(out, "\n") {
+		cells := strings.Split(strings.Trim(line, "| "), "|")
+		if len(cells) < 2 || strings.TrimSpace(cells[0]) == "#" {
+			continue
+		}
+		for i := range cells {
+			cells[i] = strings.TrimSpace(cells[i])
+		}
+		rows = append(rows, cells)
+	}
+
+	want := [][]string{
+		{"1", "critical", "Critical", "Open, In Progress", "2 days", "URGENT", "1", "Critical bug in Open, last updated 3 days ago"},
+		{"2", "stale (disabled)", "any", "any", "30 days", "ATTENTION", "2", "Any bug in any status, created 31 days ago"},
+		{"3", "high", "High", "any", "7 days → 14 days", "HIGH → ESCALATED", "2 → 1", "High bug in any status, last updated 8 days ago"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rule rows = %q, want %q", rows, want)
+	}
+}
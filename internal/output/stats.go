@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -14,68 +16,271 @@ import (
 // DisplayTrendStats renders the complete trend statistics report
 func DisplayTrendStats(stats *domain.TrendStats) {
 	if len(stats.MonthlyData) == 0 {
-		fmt.Println("\n⚠️  No bug data available for the selected time range")
+		Println("\n⚠️  No bug data available for the selected time range")
 		return
 	}
 
-	displayHeader()
-	displayUnresolvedSparkline(stats.MonthlyData)
+	displayHeader(stats.RollingCreated)
+	displayUnresolvedSparkline(stats.MonthlyData, stats.ReductionGoal)
+	displayPrioritySparklines(stats.MonthlyData)
 	displayMonthlyTable(stats.MonthlyData)
+	displayResolvedAnomalies(stats.ResolvedAnomalies)
 	displayGoalProgress(stats)
+	displayRunway(stats.Runway)
+	DisplayPhases(stats.Phases)
 	displayPriorityBreakdown(stats.MonthlyData)
+	displayWeekdayInflow(stats.CreatedByWeekday)
+	displayResolutionTimes(stats.ResolutionTimes)
 	displaySprintStats(stats.SprintStats)
 }
 
-// displayHeader prints the report header
-func displayHeader() {
-	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Println("  BUG BUTLER - TREND STATISTICS")
-	fmt.Println(strings.Repeat("=", 80))
+// displayHeader prints the report header with rolling created-bug counts
+func displayHeader(rolling []domain.RollingCount) {
+	printBanner("BUG BUTLER - TREND STATISTICS")
+
+	if len(rolling) == 0 {
+		return
+	}
+
+	parts := make([]string, len(rolling))
+	for i, r := range rolling {
+		parts[i] = fmt.Sprintf("%d days: %d", r.Days, r.Count)
+	}
+	fmt.Printf("\nBugs created in the last %s\n", strings.Join(parts, "  |  "))
+}
+
+// sparklineMonths limits the backlog sparkline to the last N months (0 = all)
+var sparklineMonths int
+
+// SetSparklineMonths sets how many trailing months the backlog sparkline shows (0 shows all)
+func SetSparklineMonths(months int) {
+	sparklineMonths = months
+}
+
+// markPartialMonth labels the in-progress month and leaves it out of trend arrows
+var markPartialMonth = true
+
+// SetMarkPartialMonth sets whether the in-progress month is labeled "(partial)" in the stats report
+func SetMarkPartialMonth(mark bool) {
+	markPartialMonth = mark
+}
+
+// weightedBacklog plots the priority-weighted backlog in the sparkline instead of the bug count
+var weightedBacklog bool
+
+// SetWeightedBacklog sets whether the backlog sparkline uses TotalUnresolvedWeighted
+func SetWeightedBacklog(weighted bool) {
+	weightedBacklog = weighted
+}
+
+// backlogValue returns the month's backlog as plotted in the sparkline
+func backlogValue(m domain.MonthlyBugStats) float64 {
+	if weightedBacklog {
+		return m.TotalUnresolvedWeighted
+	}
+	return float64(m.TotalUnresolved)
+}
+
+// formatBacklog formats a sparkline backlog value with its unit
+func formatBacklog(value float64) string {
+	if weightedBacklog {
+		return formatFloat(value, 1) + " weighted"
+	}
+	return formatFloat(value, 0) + " bugs"
+}
+
+// monthLabel formats a month for the stats report, marking the in-progress month when enabled
+func monthLabel(m domain.MonthlyBugStats) string {
+	if m.Partial && markPartialMonth {
+		return formatMonth(m.Month) + " (partial)"
+	}
+	return formatMonth(m.Month)
 }
 
-// displayUnresolvedSparkline shows a sparkline of unresolved bug counts
-func displayUnresolvedSparkline(monthly []domain.MonthlyBugStats) {
+// displayUnresolvedSparkline shows a sparkline of unresolved bug counts with a
+// target line at last year's backlog reduced by the goal percentage, followed by
+// created and resolved sparklines sharing their own scale
+func displayUnresolvedSparkline(monthly []domain.MonthlyBugStats, reductionGoal float64) {
 	if len(monthly) == 0 {
 		return
 	}
 
-	fmt.Println("\n📈 Unresolved Bug Backlog Trend (Last 24 Months)")
+	target, hasTarget := backlogTarget(monthly, reductionGoal)
+	monthly = lastMonths(monthly, sparklineMonths)
+
+	if weightedBacklog {
+		Printf("\n📈 Unresolved Bug Backlog Trend, Weighted by Priority (Last %d Months)\n", len(monthly))
+	} else {
+		Printf("\n📈 Unresolved Bug Backlog Trend (Last %d Months)\n", len(monthly))
+	}
 
-	// Extract unresolved counts
-	values := make([]int, len(monthly))
+	// Extract unresolved counts (or weighted totals)
+	values := make([]float64, len(monthly))
 	for i, m := range monthly {
-		values[i] = m.TotalUnresolved
+		values[i] = backlogValue(m)
+	}
+
+	// Generate sparkline, scaled to include the target so both lines share an axis
+	low, high := valueRange(values)
+	if hasTarget {
+		low, high = min(low, target), max(high, target)
+	}
+	sparkline := generateSparkline(values, low, high)
+	fmt.Printf("\n%s  backlog\n", sparkline)
+	if hasTarget {
+		fmt.Printf("%s  target (%s)\n", generateTargetLine(len(values), target, low, high), formatBacklog(target))
+	}
+
+	// Inflow vs outflow beneath the backlog
+	created, resolved := generateFlowSparklines(monthly)
+	fmt.Printf("%s  created\n%s  resolved\n", created, resolved)
+
+	// Annotate the marker months with their counts, three per line
+	var markers []string
+	for _, i := range markerIndices(monthly, sparklineMarkers) {
+		markers = append(markers, fmt.Sprintf("%s: %s", monthLabel(monthly[i]), formatBacklog(backlogValue(monthly[i]))))
+	}
+	fmt.Println()
+	for chunk := range slices.Chunk(markers, 3) {
+		fmt.Println(strings.Join(chunk, "  →  "))
 	}
+}
+
+// displayPrioritySparklines shows a small backlog sparkline per priority, each scaled to its own range,
+// with the first and latest unresolved counts; skipped when fewer than two priorities have a backlog
+func displayPrioritySparklines(monthly []domain.MonthlyBugStats) {
+	monthly = lastMonths(monthly, sparklineMonths)
 
-	// Generate sparkline
-	sparkline := generateSparkline(values)
-	fmt.Printf("\n%s\n", sparkline)
+	prioritySet := make(map[string]bool)
+	for _, m := range monthly {
+		for priority, count := range m.UnresolvedByPriority {
+			if count > 0 {
+				prioritySet[priority] = true
+			}
+		}
+	}
+	if len(prioritySet) < 2 {
+		return
+	}
 
-	// Show first, middle, and last months with counts
-	if len(monthly) >= 3 {
-		first := monthly[0]
-		middle := monthly[len(monthly)/2]
-		last := monthly[len(monthly)-1]
+	priorities := priorityColumns(prioritySet)
+	labels := make([]string, len(priorities))
+	width := 0
+	for i, priority := range priorities {
+		labels[i] = priority
+		if priority == "" {
+			labels[i] = "(none)"
+		}
+		width = max(width, len(labels[i]))
+	}
 
-		fmt.Printf("\n%s: %d bugs  →  %s: %d bugs  →  %s: %d bugs\n",
-			first.Month.Format("Jan 2006"), first.TotalUnresolved,
-			middle.Month.Format("Jan 2006"), middle.TotalUnresolved,
-			last.Month.Format("Jan 2006"), last.TotalUnresolved,
-		)
+	Printf("\n📊 Unresolved Backlog by Priority (Last %d Months)\n\n", len(monthly))
+	for i, priority := range priorities {
+		values := make([]float64, len(monthly))
+		for j, m := range monthly {
+			values[j] = float64(m.UnresolvedByPriority[priority])
+		}
+		low, high := valueRange(values)
+		fmt.Printf("%-*s  %s  %d → %d\n", width, labels[i], generateSparkline(values, low, high),
+			int(values[0]), int(values[len(values)-1]))
 	}
 }
 
+// Sparkline marker modes for SetSparklineMarkers
+const (
+	SparklineMarkersEndpoints = "endpoints" // First, middle, and last months
+	SparklineMarkersQuarterly = "quarterly" // First month of each quarter, plus the first and latest months
+)
+
+// sparklineMarkers selects the months annotated under the backlog sparkline
+var sparklineMarkers = SparklineMarkersEndpoints
+
+// SetSparklineMarkers sets which months are annotated under the backlog sparkline (see SparklineMarkers* constants)
+func SetSparklineMarkers(mode string) {
+	sparklineMarkers = mode
+}
+
+// markerIndices returns the ascending, distinct indices of the months to annotate
+// Endpoints gives one index for a single month and two for two months
+func markerIndices(monthly []domain.MonthlyBugStats, mode string) []int {
+	if len(monthly) == 0 {
+		return nil
+	}
+	last := len(monthly) - 1
+
+	indices := []int{0}
+	if mode == SparklineMarkersQuarterly {
+		for i, m := range monthly[1:] {
+			if (m.Month.Month()-1)%3 == 0 {
+				indices = append(indices, i+1)
+			}
+		}
+	} else {
+		indices = append(indices, len(monthly)/2)
+	}
+	indices = append(indices, last)
+	return slices.Compact(indices)
+}
+
+// backlogTarget returns the backlog 12 months before the latest month reduced by the goal,
+// and false if the data doesn't reach back that far
+func backlogTarget(monthly []domain.MonthlyBugStats, reductionGoal float64) (float64, bool) {
+	yearAgo := monthly[len(monthly)-1].Month.AddDate(-1, 0, 0)
+	for _, m := range monthly {
+		if m.Month.Equal(yearAgo) {
+			target := backlogValue(m) * (1 - reductionGoal/100)
+			if !weightedBacklog {
+				target = math.Round(target)
+			}
+			return target, true
+		}
+	}
+	return 0, false
+}
+
+// lastMonths returns the trailing n months of data (all of it when n is 0 or exceeds the length)
+func lastMonths(monthly []domain.MonthlyBugStats, n int) []domain.MonthlyBugStats {
+	if n <= 0 || n >= len(monthly) {
+		return monthly
+	}
+	return monthly[len(monthly)-n:]
+}
+
 // displayMonthlyTable shows month-by-month breakdown
 func displayMonthlyTable(monthly []domain.MonthlyBugStats) {
 	if len(monthly) == 0 {
 		return
 	}
 
-	fmt.Println("\n📊 Monthly Bug Statistics")
+	Println("\n📊 Monthly Bug Statistics")
+	renderMonthlyTable(monthly)
+}
+
+// DisplayComponentTrends shows a monthly table for each component's bugs
+func DisplayComponentTrends(trends []domain.ComponentTrend) {
+	if len(trends) == 0 {
+		return
+	}
 
+	Println("\n📦 Monthly Bug Statistics by Component")
+	for _, trend := range trends {
+		if len(trend.MonthlyData) == 0 {
+			continue // All of the component's bugs are older than the analyzed months
+		}
+		name := trend.Component
+		if name == "" {
+			name = "(no component)"
+		}
+		fmt.Printf("\n%s (%d bugs)\n", name, trend.Bugs)
+		renderMonthlyTable(trend.MonthlyData)
+	}
+}
+
+// renderMonthlyTable renders the last 12 months of created, resolved, and unresolved counts
+func renderMonthlyTable(monthly []domain.MonthlyBugStats) {
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.SetStyle(table.StyleRounded)
+	t.SetStyle(baseTableStyle())
 
 	// Set headers
 	t.AppendHeader(table.Row{"Month", "Created", "Resolved", "Unresolved", "Trend"})
@@ -89,16 +294,18 @@ func displayMonthlyTable(monthly []domain.MonthlyBugStats) {
 	for i := startIdx; i < len(monthly); i++ {
 		m := monthly[i]
 
-		// Format trend indicator
+		// Format trend indicator (a partial month would always look like a drop)
 		trend := "→"
-		if m.ChangePercent > 5 {
-			trend = "↑ " + fmt.Sprintf("+%.1f%%", m.ChangePercent)
+		if m.Partial && markPartialMonth {
+			trend = "-"
+		} else if m.ChangePercent > 5 {
+			trend = "↑ +" + formatPercent(m.ChangePercent, 1)
 		} else if m.ChangePercent < -5 {
-			trend = "↓ " + fmt.Sprintf("%.1f%%", m.ChangePercent)
+			trend = "↓ " + formatPercent(m.ChangePercent, 1)
 		}
 
 		t.AppendRow(table.Row{
-			m.Month.Format("Jan 2006"),
+			monthLabel(m),
 			m.TotalCreated,
 			m.TotalResolved,
 			m.TotalUnresolved,
@@ -109,18 +316,34 @@ func displayMonthlyTable(monthly []domain.MonthlyBugStats) {
 	t.Render()
 }
 
-// displayGoalProgress shows current month goal tracking
+// displayGoalProgress shows current period goal tracking against each baseline
 func displayGoalProgress(stats *domain.TrendStats) {
-	if stats.CurrentMonth == nil || stats.LastYearSameMonth == nil {
-		return
+	for _, goal := range stats.Goals {
+		displayGoal(stats, goal, len(stats.Goals) > 1)
+	}
+}
+
+// displayGoal shows goal tracking against one baseline, naming the comparison in the
+// title when several baselines are shown
+func displayGoal(stats *domain.TrendStats, goal domain.GoalProgress, named bool) {
+	title, period := "Current Month Goal", formatLongMonth(stats.CurrentMonth.Month)
+	baselineName := baselinePeriod(goal.OffsetMonths)
+	baselineLine := fmt.Sprintf("%s%s: %d bugs created", strings.ToUpper(baselineName[:1]), baselineName[1:], goal.Baseline)
+	switch stats.GoalMode {
+	case "trailing_30_days":
+		title, period = "Trailing 30-Day Goal", "Last 30 days"
+		baselineLine = fmt.Sprintf("Same 30 days %s: %d bugs created", baselineName, goal.Baseline)
+	case "prorated":
+		baselineLine += fmt.Sprintf(" by this point in the month (%d in the full month)", goal.BaselineMonth.TotalCreated)
+	}
+	if named {
+		title += " (" + baselineComparison(goal.OffsetMonths) + ")"
 	}
 
-	fmt.Println("\n🎯 Current Month Goal")
+	Printf("\n🎯 %s\n", title)
 
-	currentMonthName := stats.CurrentMonth.Month.Format("January 2006")
-	lastYearCount := stats.LastYearSameMonth.TotalCreated
-	currentCount := stats.CurrentMonth.TotalCreated
-	goalTarget := stats.GoalTarget
+	currentCount := goal.Current
+	goalTarget := goal.Target
 
 	// Calculate how we're doing
 	var status string
@@ -128,19 +351,120 @@ func displayGoalProgress(stats *domain.TrendStats) {
 
 	if currentCount <= goalTarget {
 		percentBelow := ((float64(goalTarget-currentCount) / float64(goalTarget)) * 100)
-		status = fmt.Sprintf("✓ On track (%.1f%% below target)", percentBelow)
+		status = fmt.Sprintf("✓ On track (%s below target)", formatPercent(percentBelow, 1))
 		statusColor = text.Colors{text.FgGreen, text.Bold}
 	} else {
 		percentOver := ((float64(currentCount-goalTarget) / float64(goalTarget)) * 100)
-		status = fmt.Sprintf("⚠ Over target (%.1f%% above)", percentOver)
+		status = fmt.Sprintf("⚠ Over target (%s above)", formatPercent(percentOver, 1))
 		statusColor = text.Colors{text.FgYellow, text.Bold}
 	}
 
-	fmt.Printf("\n%s\n", currentMonthName)
-	fmt.Printf("Last year: %d bugs created\n", lastYearCount)
-	fmt.Printf("Target: ≤ %d bugs (%.0f%% reduction goal)\n", goalTarget, stats.ReductionGoal)
+	fmt.Printf("\n%s\n", period)
+	fmt.Println(baselineLine)
+	fmt.Printf("Target: ≤ %d bugs (%s reduction goal)\n", goalTarget, formatPercent(stats.ReductionGoal, 0))
 	fmt.Printf("Actual: %d bugs created so far\n", currentCount)
-	fmt.Printf("Status: %s\n", text.Colors.Sprint(statusColor, status))
+	Printf("Status: %s\n", text.Colors.Sprint(statusColor, status))
+
+	displayPriorityGoals(goal.PriorityGoals, goal.OffsetMonths)
+}
+
+// baselinePeriod names when a goal baseline was, offsetMonths before the current period
+func baselinePeriod(offsetMonths int) string {
+	switch offsetMonths {
+	case 12:
+		return "last year"
+	case 1:
+		return "last month"
+	}
+	return fmt.Sprintf("%d months ago", offsetMonths)
+}
+
+// baselineComparison names the comparison against a goal baseline (e.g., "Year over Year")
+func baselineComparison(offsetMonths int) string {
+	switch offsetMonths {
+	case 12:
+		return "Year over Year"
+	case 3:
+		return "Quarter over Quarter"
+	case 1:
+		return "Month over Month"
+	}
+	return fmt.Sprintf("vs %d Months Ago", offsetMonths)
+}
+
+// displayPriorityGoals renders goal progress for each priority with its own reduction goal
+func displayPriorityGoals(goals []domain.PriorityGoal, offsetMonths int) {
+	if len(goals) == 0 {
+		return
+	}
+
+	fmt.Println("\nBy priority:")
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+	baselineHeader := baselinePeriod(offsetMonths)
+	if offsetMonths == 12 {
+		baselineHeader = "Last Year"
+	}
+	t.AppendHeader(table.Row{"Priority", "Goal", baselineHeader, "Target", "Actual", "Status"})
+
+	for _, g := range goals {
+		status := text.Colors{text.FgGreen}.Sprint("On track")
+		if !g.OnTrack {
+			status = text.Colors{text.FgYellow}.Sprint("Over target")
+		}
+		t.AppendRow(table.Row{
+			g.Priority,
+			"-" + formatPercent(g.ReductionGoal, 0),
+			g.Baseline,
+			fmt.Sprintf("≤ %d", g.Target),
+			g.Current,
+			status,
+		})
+	}
+
+	t.Render()
+}
+
+// displayResolvedAnomalies warns about months with zero resolved bugs after a busy trailing history
+func displayResolvedAnomalies(anomalies []domain.ResolvedAnomaly) {
+	for _, anomaly := range anomalies {
+		Printf("\n%s\n", text.Colors{text.FgYellow, text.Bold}.Sprintf(
+			"⚠ %s: 0 bugs resolved, but the previous %d months averaged %s; check for a data or workflow problem",
+			formatMonth(anomaly.Month), anomaly.WindowMonths, formatFloat(anomaly.TrailingAverage, 1)))
+	}
+}
+
+// displayRunway shows how long until the backlog doubles or halves at the recent net rate
+func displayRunway(runway *domain.BacklogRunway) {
+	if runway == nil {
+		return
+	}
+
+	Println("\n⏳ Backlog Runway")
+	fmt.Printf("\nUnresolved: %d bugs\n", runway.Unresolved)
+	fmt.Printf("Net change: %s bugs/day (created - resolved, last %d days)\n",
+		formatSignedFloat(runway.NetPerDay, 2), runway.WindowDays)
+
+	switch {
+	case runway.Stable():
+		fmt.Println("Forecast: Backlog is flat; it will neither double nor halve at this rate")
+	case runway.Growing():
+		Printf("Forecast: %s\n", text.Colors{text.FgYellow, text.Bold}.Sprintf(
+			"Backlog doubles in ~%s days at this rate", formatFloat(math.Round(runway.Days), 0)))
+	default:
+		Printf("Forecast: %s\n", text.Colors{text.FgGreen, text.Bold}.Sprintf(
+			"Backlog halves in ~%s days at this rate", formatFloat(math.Round(runway.Days), 0)))
+	}
+}
+
+// formatSignedFloat formats a value with an explicit sign for positive numbers
+func formatSignedFloat(value float64, decimals int) string {
+	if value > 0 {
+		return "+" + formatFloat(value, decimals)
+	}
+	return formatFloat(value, decimals)
 }
 
 // displayPriorityBreakdown shows priority distribution over time
@@ -149,7 +473,7 @@ func displayPriorityBreakdown(monthly []domain.MonthlyBugStats) {
 		return
 	}
 
-	fmt.Println("\n🔍 Priority Breakdown (Last 6 Months)")
+	Println("\n🔍 Priority Breakdown (Last 6 Months)")
 
 	// Get last 6 months
 	startIdx := 0
@@ -168,41 +492,22 @@ func displayPriorityBreakdown(monthly []domain.MonthlyBugStats) {
 	// Build table
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.SetStyle(table.StyleRounded)
+	t.SetStyle(baseTableStyle())
 
 	// Build header with priorities in order: Critical, High, Medium, Low, Others
-	priorityOrder := []string{"Critical", "High", "Medium", "Low"}
+	priorityOrder := priorityColumns(prioritySet)
 	headerRow := table.Row{"Month"}
 	for _, p := range priorityOrder {
-		if prioritySet[p] {
-			headerRow = append(headerRow, p)
-		}
-	}
-	// Add any other priorities
-	for p := range prioritySet {
-		found := false
-		for _, known := range priorityOrder {
-			if p == known {
-				found = true
-				break
-			}
-		}
-		if !found {
-			headerRow = append(headerRow, p)
-			priorityOrder = append(priorityOrder, p)
-		}
+		headerRow = append(headerRow, p)
 	}
 	t.AppendHeader(headerRow)
 
 	// Add rows
 	for i := startIdx; i < len(monthly); i++ {
 		m := monthly[i]
-		row := table.Row{m.Month.Format("Jan 2006")}
+		row := table.Row{formatMonth(m.Month)}
 		for _, p := range priorityOrder {
-			if prioritySet[p] {
-				count := m.ByPriority[p]
-				row = append(row, count)
-			}
+			row = append(row, m.ByPriority[p])
 		}
 		t.AppendRow(row)
 	}
@@ -210,61 +515,158 @@ func displayPriorityBreakdown(monthly []domain.MonthlyBugStats) {
 	t.Render()
 }
 
-// generateSparkline creates an ASCII sparkline from values
-func generateSparkline(values []int) string {
-	if len(values) == 0 {
-		return ""
+// displayResolutionTimes shows mean resolution time per priority
+func displayResolutionTimes(resolutions []domain.PriorityResolution) {
+	if len(resolutions) == 0 {
+		return
+	}
+
+	Println("\n⏱️  Mean Resolution Time by Priority")
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+
+	t.AppendHeader(table.Row{"Priority", "Resolved", "Mean Days"})
+
+	for _, r := range resolutions {
+		t.AppendRow(table.Row{
+			r.Priority,
+			r.Count,
+			formatFloat(r.MeanDays, 1),
+		})
+	}
+
+	t.Render()
+}
+
+// weekdayBarWidth is the length of the bar for the busiest weekday
+const weekdayBarWidth = 30
+
+// displayWeekdayInflow shows bugs created per day of the week with a bar scaled to the busiest day
+func displayWeekdayInflow(weekdays []domain.WeekdayCount) {
+	total, busiest := 0, 0
+	for _, w := range weekdays {
+		total += w.Created
+		busiest = max(busiest, w.Created)
+	}
+	if total == 0 {
+		return
 	}
 
-	// Unicode block characters for sparkline
-	blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	Println("\n📅 Bugs Created by Day of Week")
 
-	// Find min and max
-	min, max := values[0], values[0]
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+
+	t.AppendHeader(table.Row{"Day", "Created", "Share", ""})
+
+	for _, w := range weekdays {
+		bar := strings.Repeat("█", int(math.Round(float64(w.Created)/float64(busiest)*weekdayBarWidth)))
+		t.AppendRow(table.Row{
+			formatWeekday(w.Weekday),
+			w.Created,
+			formatPercent(float64(w.Created)/float64(total)*100, 1),
+			bar,
+		})
+	}
+
+	t.Render()
+}
+
+// sparklineBlocks are the Unicode block characters used for sparklines, lowest first
+var sparklineBlocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// valueRange returns the minimum and maximum of values
+func valueRange(values []float64) (float64, float64) {
+	low, high := values[0], values[0]
 	for _, v := range values {
-		if v < min {
-			min = v
+		low, high = min(low, v), max(high, v)
+	}
+	return low, high
+}
+
+// sparklineLevel normalizes a value to a block index (0-7) within [low, high]
+func sparklineLevel(val, low, high float64) int {
+	if high == low {
+		return 4 // Middle if all values are the same
+	}
+
+	ratio := (val - low) / (high - low)
+	level := int(math.Round(ratio * 7))
+	return min(max(level, 0), 7)
+}
+
+// priorityColumns orders the priorities present for table columns: Critical, High, Medium, Low, then others alphabetically
+func priorityColumns(prioritySet map[string]bool) []string {
+	known := []string{"Critical", "High", "Medium", "Low"}
+
+	var columns, others []string
+	for _, p := range known {
+		if prioritySet[p] {
+			columns = append(columns, p)
 		}
-		if v > max {
-			max = v
+	}
+	for p := range prioritySet {
+		if !slices.Contains(known, p) {
+			others = append(others, p)
 		}
 	}
+	sort.Strings(others)
+
+	return append(columns, others...)
+}
+
+// generateSparkline creates an ASCII sparkline from values scaled to [low, high]
+func generateSparkline(values []float64, low, high float64) string {
+	if len(values) == 0 {
+		return ""
+	}
 
-	// Build sparkline
 	var result strings.Builder
 	for _, val := range values {
-		// Normalize to 0-7 range
-		var normalized int
-		if max == min {
-			normalized = 4 // Middle if all values are the same
-		} else {
-			ratio := float64(val-min) / float64(max-min)
-			normalized = int(math.Round(ratio * 7))
-			if normalized < 0 {
-				normalized = 0
-			}
-			if normalized > 7 {
-				normalized = 7
-			}
-		}
-		result.WriteRune(blocks[normalized])
+		result.WriteRune(sparklineBlocks[sparklineLevel(val, low, high)])
 	}
 
 	return result.String()
 }
 
+// generateFlowSparklines renders created and resolved counts per month on one shared scale,
+// so a taller block means more bugs in either line
+func generateFlowSparklines(monthly []domain.MonthlyBugStats) (string, string) {
+	if len(monthly) == 0 {
+		return "", ""
+	}
+
+	created := make([]float64, len(monthly))
+	resolved := make([]float64, len(monthly))
+	for i, m := range monthly {
+		created[i] = float64(m.TotalCreated)
+		resolved[i] = float64(m.TotalResolved)
+	}
+
+	low, high := valueRange(slices.Concat(created, resolved))
+	return generateSparkline(created, low, high), generateSparkline(resolved, low, high)
+}
+
+// generateTargetLine renders a flat line of the given width at the target's level
+func generateTargetLine(width int, target, low, high float64) string {
+	return strings.Repeat(string(sparklineBlocks[sparklineLevel(target, low, high)]), width)
+}
+
 // displaySprintStats shows sprint-level bug statistics
 func displaySprintStats(sprintStats []domain.SprintStats) {
 	if len(sprintStats) == 0 {
 		return
 	}
 
-	fmt.Println("\n🏃 Sprint Statistics")
+	Println("\n🏃 Sprint Statistics")
 	fmt.Printf("\nShowing bug density across %d sprints\n", len(sprintStats))
 
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.SetStyle(table.StyleRounded)
+	t.SetStyle(baseTableStyle())
 
 	// Set headers
 	t.AppendHeader(table.Row{
@@ -276,13 +678,14 @@ func displaySprintStats(sprintStats []domain.SprintStats) {
 		"Bug Pts",
 		"Total Pts",
 		"Pts %",
+		"Reopened",
 	})
 
 	// Add rows for each sprint
 	for _, sprint := range sprintStats {
 		// Format percentages
-		bugPercent := fmt.Sprintf("%.1f%%", sprint.BugPercentage)
-		pointsPercent := fmt.Sprintf("%.1f%%", sprint.PointsPercentage)
+		bugPercent := formatPercent(sprint.BugPercentage, 1)
+		pointsPercent := formatPercent(sprint.PointsPercentage, 1)
 
 		// Color code bug percentage (higher is worse)
 		var bugPercentColor text.Colors
@@ -300,9 +703,10 @@ func displaySprintStats(sprintStats []domain.SprintStats) {
 			sprint.OtherCount,
 			sprint.TotalCount,
 			text.Colors.Sprint(bugPercentColor, bugPercent),
-			fmt.Sprintf("%.1f", sprint.BugStoryPoints),
-			fmt.Sprintf("%.1f", sprint.TotalStoryPoints),
+			formatFloat(sprint.BugStoryPoints, 1),
+			formatFloat(sprint.TotalStoryPoints, 1),
 			pointsPercent,
+			reopenedCell(sprint.ReopenedInSprint),
 		})
 	}
 
@@ -310,10 +714,12 @@ func displaySprintStats(sprintStats []domain.SprintStats) {
 
 	// Display summary statistics
 	if len(sprintStats) > 0 {
-		var totalBugs, totalOther int
+		var totalBugs, totalOther, totalReopened, totalUnestimated int
 		var totalBugPoints, totalAllPoints float64
 
 		for _, s := range sprintStats {
+			totalReopened += s.ReopenedInSprint
+			totalUnestimated += s.UnestimatedCount
 			totalBugs += s.BugCount
 			totalOther += s.OtherCount
 			totalBugPoints += s.BugStoryPoints
@@ -333,7 +739,19 @@ func displaySprintStats(sprintStats []domain.SprintStats) {
 
 		fmt.Printf("\nSummary:\n")
 		fmt.Printf("  Total issues: %d (%d bugs, %d other)\n", totalIssues, totalBugs, totalOther)
-		fmt.Printf("  Average bug density: %.1f%% of issues\n", avgBugPercent)
-		fmt.Printf("  Average bug points: %.1f%% of story points\n", avgPointsPercent)
+		fmt.Printf("  Average bug density: %s of issues\n", formatPercent(avgBugPercent, 1))
+		fmt.Printf("  Average bug points: %s of story points\n", formatPercent(avgPointsPercent, 1))
+		fmt.Printf("  Bugs reopened mid-sprint: %d\n", totalReopened)
+		if totalUnestimated > 0 {
+			fmt.Printf("  Issues without story points: %d\n", totalUnestimated)
+		}
+	}
+}
+
+// reopenedCell highlights bugs reopened after the sprint started, a sign of incomplete fixes
+func reopenedCell(count int) string {
+	if count == 0 {
+		return "0"
 	}
+	return text.Colors{text.FgRed, text.Bold}.Sprint(count)
 }
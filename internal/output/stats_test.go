@@ -0,0 +1,253 @@
+package output
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestLastMonths(t *testing.T) {
+	monthly := make([]domain.MonthlyBugStats, 12)
+	for i := range monthly {
+		monthly[i].Month = time.Date(2025, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		n, wantLen int
+		wantFirst  time.Month
+	}{
+		{0, 12, time.January},
+		{6, 6, time.July},
+		{24, 12, time.January},
+	}
+
+	for _, tt := range tests {
+		got := lastMonths(monthly, tt.n)
+		if len(got) != tt.wantLen || got[0].Month.Month() != tt.wantFirst {
+			t.Errorf("lastMonths(%d) = %d months from %s, want %d from %s",
+				tt.n, len(got), got[0].Month.Month(), tt.wantLen, tt.wantFirst)
+		}
+	}
+}
+
+func TestBacklogTargetAndTargetLine(t *testing.T) {
+	monthly := make([]domain.MonthlyBugStats, 13)
+	for i := range monthly {
+		monthly[i].Month = time.Date(2024, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
+		monthly[i].TotalUnresolved = 100 - i*5
+	}
+
+	// A year before the latest month the backlog was 100, so a 30% goal targets 70
+	target, ok := backlogTarget(monthly, 30)
+	if !ok || target != 70 {
+		t.Fatalf("backlogTarget = %v, %v, want 70, true", target, ok)
+	}
+
+	// 70 on a 40-100 axis is halfway up, the level 4 block (rounding 3.5 up)
+	if got, want := generateTargetLine(6, target, 40, 100), "▅▅▅▅▅▅"; got != want {
+		t.Errorf("generateTargetLine = %q, want %q", got, want)
+	}
+	if got, want := generateTargetLine(3, 40, 40, 100), "▁▁▁"; got != want {
+		t.Errorf("generateTargetLine at the low end = %q, want %q", got, want)
+	}
+
+	if _, ok := backlogTarget(monthly[1:], 30); ok {
+		t.Error("backlogTarget found a target without a month from a year earlier")
+	}
+}
+
+func TestDisplaySprintStatsSummaryAverages(t *testing.T) {
+	sprints := []domain.SprintStats{
+		{SprintName: "Sprint 1", BugCount: 2, OtherCount: 8, TotalCount: 10, BugPercentage: 20},
+		{SprintName: "Sprint 2", BugCount: 3, OtherCount: 7, TotalCount: 10, BugPercentage: 30},
+	}
+
+	out := captureStdout(t, func() { displaySprintStats(sprints) })
+
+	for _, want := range []string{"Total issues: 20 (5 bugs, 15 other)", "Average bug density: 25.0% of issues"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("sprint summary missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestDisplayRunwayWording(t *testing.T) {
+	defer SetPlain(plainMode)
+	SetPlain(true)
+
+	tests := []struct {
+		runway domain.BacklogRunway
+		want   string
+	}{
+		{domain.BacklogRunway{WindowDays: 90, Unresolved: 16, NetPerDay: 0.2, Days: 80}, "Backlog doubles in ~80 days at this rate"},
+		{domain.BacklogRunway{WindowDays: 90, Unresolved: 14, NetPerDay: -0.2, Days: 35}, "Backlog halves in ~35 days at this rate"},
+		{domain.BacklogRunway{WindowDays: 90, Unresolved: 5, Days: math.Inf(1)}, "Backlog is flat"},
+	}
+
+	for _, tt := range tests {
+		out := captureStdout(t, func() { displayRunway(&tt.runway) })
+		if !strings.Contains(out, tt.want) {
+			t.Errorf("runway %+v output missing %q in:\n%s", tt.runway, tt.want, out)
+		}
+	}
+}
+
+func TestMonthLabelPartial(t *testing.T) {
+	defer SetMarkPartialMonth(markPartialMonth)
+
+	complete := domain.MonthlyBugStats{Month: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)}
+	current := domain.MonthlyBugStats{Month: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Partial: true}
+
+	SetMarkPartialMonth(true)
+	if got, want := monthLabel(current), "Jun 2025 (partial)"; got != want {
+		t.Errorf("monthLabel(current) = %q, want %q", got, want)
+	}
+	if got, want := monthLabel(complete), "May 2025"; got != want {
+		t.Errorf("monthLabel(complete) = %q, want %q", got, want)
+	}
+
+	SetMarkPartialMonth(false)
+	if got, want := monthLabel(current), "Jun 2025"; got != want {
+		t.Errorf("monthLabel(current) unmarked = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateSparklineNormalization(t *testing.T) {
+	tests := []struct {
+		values    []float64
+		low, high float64
+		want      string
+	}{
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, 0, 7, "▁▂▃▄▅▆▇█"},
+		{[]float64{10, 20, 15}, 10, 20, "▁█▅"},
+		{[]float64{2.5, 10.5}, 2.5, 10.5, "▁█"},
+		{[]float64{5, 5, 5}, 5, 5, "▅▅▅"},
+		{[]float64{-1, 12}, 0, 7, "▁█"},
+		{nil, 0, 7, ""},
+	}
+	for _, tt := range tests {
+		if got := generateSparkline(tt.values, tt.low, tt.high); got != tt.want {
+			t.Errorf("generateSparkline(%v, %v, %v) = %q, want %q", tt.values, tt.low, tt.high, got, tt.want)
+		}
+	}
+}
+
+func TestBacklogValueWeighted(t *testing.T) {
+	defer SetWeightedBacklog(weightedBacklog)
+	m := domain.MonthlyBugStats{TotalUnresolved: 3, TotalUnresolvedWeighted: 7.5}
+
+	SetWeightedBacklog(false)
+	if got := backlogValue(m); got != 3 {
+		t.Errorf("backlogValue = %v, want the bug count 3", got)
+	}
+	if got := formatBacklog(3); got != "3 bugs" {
+		t.Errorf("formatBacklog = %q, want %q", got, "3 bugs")
+	}
+
+	SetWeightedBacklog(true)
+	if got := backlogValue(m); got != 7.5 {
+		t.Errorf("weighted backlogValue = %v, want 7.5", got)
+	}
+	if got := formatBacklog(7.5); got != "7.5 weighted" {
+		t.Errorf("weighted formatBacklog = %q, want %q", got, "7.5 weighted")
+	}
+}
+
+func TestMarkerIndices(t *testing.T) {
+	// months returns n consecutive months starting in the given month of 2025
+	months := func(start time.Month, n int) []domain.MonthlyBugStats {
+		monthly := make([]domain.MonthlyBugStats, n)
+		for i := range monthly {
+			monthly[i].Month = time.Date(2025, start+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+		}
+		return monthly
+	}
+
+	tests := []struct {
+		name    string
+		monthly []domain.MonthlyBugStats
+		mode    string
+		want    []int
+	}{
+		{"endpoints empty", nil, SparklineMarkersEndpoints, nil},
+		{"endpoints one month", months(1, 1), SparklineMarkersEndpoints, []int{0}},
+		{"endpoints two months", months(1, 2), SparklineMarkersEndpoints, []int{0, 1}},
+		{"endpoints three months", months(1, 3), SparklineMarkersEndpoints, []int{0, 1, 2}},
+		{"endpoints even length", months(1, 12), SparklineMarkersEndpoints, []int{0, 6, 11}},
+		{"quarterly one month", months(1, 1), SparklineMarkersQuarterly, []int{0}},
+		{"quarterly from a quarter start", months(1, 7), SparklineMarkersQuarterly, []int{0, 3, 6}},
+		{"quarterly from mid-quarter", months(2, 12), SparklineMarkersQuarterly, []int{0, 2, 5, 8, 11}},
+		{"quarterly ending mid-quarter", months(11, 5), SparklineMarkersQuarterly, []int{0, 2, 4}},
+	}
+	for _, tt := range tests {
+		if got := markerIndices(tt.monthly, tt.mode); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: markerIndices = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateFlowSparklinesSharedScale(t *testing.T) {
+	// Created spans 0-7 and resolved 0-3.5; on a shared scale resolved never reaches the top block
+	monthly := []domain.MonthlyBugStats{
+		{TotalCreated: 0, TotalResolved: 0},
+		{TotalCreated: 7, TotalResolved: 3},
+		{TotalCreated: 7, TotalResolved: 7},
+		{TotalCreated: 2, TotalResolved: 1},
+	}
+
+	created, resolved := generateFlowSparklines(monthly)
+	if created != "▁██▃" {
+		t.Errorf("created = %q, want %q", created, "▁██▃")
+	}
+	if resolved != "▁▄█▂" {
+		t.Errorf("resolved = %q, want %q", resolved, "▁▄█▂")
+	}
+
+	// Equal counts render the same block in both lines, whatever each line's own range is
+	c, r := generateFlowSparklines([]domain.MonthlyBugStats{{TotalCreated: 10, TotalResolved: 4}, {TotalCreated: 4, TotalResolved: 2}})
+	if []rune(c)[1] != []rune(r)[0] {
+		t.Errorf("created %q and resolved %q render a count of 4 differently", c, r)
+	}
+
+	if c, r := generateFlowSparklines(nil); c != "" || r != "" {
+		t.Errorf("generateFlowSparklines(nil) = %q, %q, want empty", c, r)
+	}
+}
+
+func TestDisplayPrioritySparklines(t *testing.T) {
+	defer SetPlain(plainMode)
+	SetPlain(true)
+
+	monthly := []domain.MonthlyBugStats{
+		{UnresolvedByPriority: map[string]int{"High": 2, "Critical": 5}},
+		{UnresolvedByPriority: map[string]int{"High": 4, "Critical": 3, "": 1}},
+		{UnresolvedByPriority: map[string]int{"High": 6, "Critical": 1, "Low": 0}},
+	}
+	out := captureStdout(t, func() { displayPrioritySparklines(monthly) })
+
+	// Known priorities come first in severity order, and each line is scaled to its own range
+	want := []string{
+		"Critical  █▅▁  5 → 1",
+		"High      ▁▅█  2 → 6",
+		"(none)    ▁█▁  0 → 0",
+	}
+	var got []string
+	for line := range strings.Lines(out) {
+		if line = strings.TrimRight(line, "\n"); strings.Contains(line, "→") {
+			got = append(got, line)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("priority sparklines =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// A single priority adds nothing over the overall sparkline
+	single := []domain.MonthlyBugStats{{UnresolvedByPriority: map[string]int{"High": 2}}, {UnresolvedByPriority: map[string]int{"High": 3}}}
+	if out := captureStdout(t, func() { displayPrioritySparklines(single) }); out != "" {
+		t.Errorf("single priority printed %q, want nothing", out)
+	}
+}
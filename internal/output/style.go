@@ -0,0 +1,135 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// plainMode disables emoji, colors, and banners for embedding output in other tools
+var plainMode bool
+
+// SetPlain toggles plain-text output mode
+func SetPlain(plain bool) {
+	plainMode = plain
+	if plain {
+		text.DisableColors()
+	} else {
+		text.EnableColors()
+	}
+}
+
+// Output formats for SetFormat
+const (
+	FormatAuto  = "auto"  // Rich on a terminal, plain when stdout is piped or redirected
+	FormatRich  = "rich"  // Emoji, colors, banners, and hyperlinks
+	FormatPlain = "plain" // Same as SetPlain(true)
+)
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal (replaceable for testing)
+var stdoutIsTerminal = func() bool {
+	return IsTerminal(os.Stdout)
+}
+
+// SetFormat selects the output format (empty = auto)
+func SetFormat(format string) error {
+	switch format {
+	case "", FormatAuto:
+		SetPlain(!stdoutIsTerminal())
+	case FormatRich:
+		SetPlain(false)
+	case FormatPlain:
+		SetPlain(true)
+	default:
+		return fmt.Errorf("invalid output format %q (must be auto, rich, or plain)", format)
+	}
+	return nil
+}
+
+// IsPlain reports whether plain-text output mode is enabled
+func IsPlain() bool {
+	return plainMode
+}
+
+// Decorate returns s unchanged, or with emoji removed in plain mode
+func Decorate(s string) string {
+	if !plainMode {
+		return s
+	}
+	return stripEmoji(s)
+}
+
+// Print writes to stdout, dropping emoji in plain mode
+func Print(a ...any) {
+	fmt.Print(Decorate(fmt.Sprint(a...)))
+}
+
+// Printf writes formatted output to stdout, dropping emoji in plain mode
+func Printf(format string, a ...any) {
+	fmt.Print(Decorate(fmt.Sprintf(format, a...)))
+}
+
+// Println writes a line to stdout, dropping emoji in plain mode
+func Println(a ...any) {
+	fmt.Print(Decorate(fmt.Sprintln(a...)))
+}
+
+// printBanner prints a report title framed by "=" rules (title only in plain mode)
+func printBanner(title string) {
+	if plainMode {
+		fmt.Println("\n" + title)
+		return
+	}
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("  " + title)
+	fmt.Println(strings.Repeat("=", 80))
+}
+
+// printSection prints a section title framed by "-" rules (title only in plain mode)
+func printSection(title string) {
+	if plainMode {
+		fmt.Println("\n" + title)
+		return
+	}
+	fmt.Println("\n" + strings.Repeat("-", 80))
+	fmt.Println("  " + title)
+	fmt.Println(strings.Repeat("-", 80))
+}
+
+// baseTableStyle returns the table style for the current output mode
+func baseTableStyle() table.Style {
+	if plainMode {
+		return table.StyleDefault
+	}
+	return table.StyleRounded
+}
+
+// hyperlink wraps label in an OSC 8 terminal hyperlink (plain label in plain mode)
+func hyperlink(url, label string) string {
+	if plainMode {
+		return label
+	}
+	return fmt.Sprintf("\033]8;;%s\033\\%s\033]8;;\033\\", url, label)
+}
+
+// stripEmoji removes emoji symbols (and the spacing after them) from s
+func stripEmoji(s string) string {
+	var b strings.Builder
+	skipSpace := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.So, r) || r == '️' || r == '‍':
+			skipSpace = true
+			continue
+		case skipSpace && r == ' ':
+			continue
+		}
+		skipSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
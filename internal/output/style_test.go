@@ -0,0 +1,123 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns what f writes to stdout
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = saved }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	f()
+	w.Close()
+	return <-done
+}
+
+func TestPrintBanner(t *testing.T) {
+	defer SetPlain(plainMode)
+
+	rule := strings.Repeat("=", 80)
+	tests := []struct {
+		plain bool
+		want  string
+	}{
+		{false, "\n" + rule + "\n  BUG BUTLER - SLA VIOLATION REPORT\n" + rule + "\n"},
+		{true, "\nBUG BUTLER - SLA VIOLATION REPORT\n"},
+	}
+
+	for _, tt := range tests {
+		SetPlain(tt.plain)
+		got := captureStdout(t, func() { printBanner("BUG BUTLER - SLA VIOLATION REPORT") })
+		if got != tt.want {
+			t.Errorf("plain=%v: printBanner wrote %q, want %q", tt.plain, got, tt.want)
+		}
+	}
+}
+
+func TestPrintSection(t *testing.T) {
+	defer SetPlain(plainMode)
+
+	SetPlain(false)
+	if got := captureStdout(t, func() { printSection("Sprints") }); !strings.Contains(got, strings.Repeat("-", 80)) {
+		t.Errorf("decorated section %q has no rule", got)
+	}
+
+	SetPlain(true)
+	if got := captureStdout(t, func() { printSection("Sprints") }); got != "\nSprints\n" {
+		t.Errorf("plain section = %q, want %q", got, "\nSprints\n")
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	defer SetPlain(plainMode)
+
+	SetPlain(false)
+	if got := Decorate("🔴 URGENT"); got != "🔴 URGENT" {
+		t.Errorf("decorated = %q, want the emoji kept", got)
+	}
+
+	SetPlain(true)
+	for in, want := range map[string]string{
+		"🔴 URGENT":             "URGENT",
+		"⚠️  No bugs found":    "No bugs found",
+		"📥 Fetching bugs... ✓": "Fetching bugs... ",
+		"plain text":           "plain text",
+	} {
+		if got := Decorate(in); got != want {
+			t.Errorf("Decorate(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := captureStdout(t, func() { Println("📊 Stats") }); got != "Stats\n" {
+		t.Errorf("plain Println wrote %q, want %q", got, "Stats\n")
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	defer SetPlain(plainMode)
+	defer func(saved func() bool) { stdoutIsTerminal = saved }(stdoutIsTerminal)
+
+	tests := []struct {
+		format    string
+		terminal  bool
+		wantPlain bool
+	}{
+		{"", true, false},
+		{FormatAuto, true, false},
+		{FormatAuto, false, true},
+		{"", false, true},
+		{FormatRich, false, false},
+		{FormatPlain, true, true},
+	}
+
+	for _, tt := range tests {
+		stdoutIsTerminal = func() bool { return tt.terminal }
+		if err := SetFormat(tt.format); err != nil {
+			t.Fatalf("SetFormat(%q): %v", tt.format, err)
+		}
+		if IsPlain() != tt.wantPlain {
+			t.Errorf("SetFormat(%q) with terminal %v: plain = %v, want %v", tt.format, tt.terminal, IsPlain(), tt.wantPlain)
+		}
+	}
+
+	if err := SetFormat("fancy"); err == nil {
+		t.Error("SetFormat(fancy) = nil, want an error")
+	}
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// SummaryLinePrefix starts the machine-readable summary line printed by check --summary-line
+const SummaryLinePrefix = "BUGBUTLER_RESULT"
+
+// FormatSummaryLine renders violation counts as one greppable line
+// (e.g., "BUGBUTLER_RESULT total=30 urgent=12 attention_needed=18"), with a key per bucket in
+// bucketNames order (0 when absent from buckets) followed by any other buckets by name
+func FormatSummaryLine(total int, buckets []*domain.Bucket, bucketNames []string) string {
+	keys, counts := bucketCounts(bucketNames, buckets)
+
+	parts := []string{SummaryLinePrefix, fmt.Sprintf("total=%d", total)}
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%d", key, counts[key]))
+	}
+	return strings.Join(parts, " ")
+}
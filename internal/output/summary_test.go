@@ -0,0 +1,51 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// bucketOfSize builds a bucket holding n bugs
+func bucketOfSize(name string, n int) *domain.Bucket {
+	bucket := &domain.Bucket{Name: name}
+	for range n {
+		bucket.Bugs = append(bucket.Bugs, &domain.Bug{})
+	}
+	return bucket
+}
+
+func TestFormatSummaryLine(t *testing.T) {
+	bucketNames := []string{"🔴 URGENT", "🟡 ATTENTION NEEDED", "🔵 Review"}
+
+	tests := []struct {
+		name    string
+		total   int
+		buckets []*domain.Bucket
+		want    string
+	}{
+		{
+			"configured order with missing buckets as zero",
+			20,
+			[]*domain.Bucket{bucketOfSize("🔴 URGENT", 12), bucketOfSize("🔵 Review", 8)},
+			"BUGBUTLER_RESULT total=20 urgent=12 attention_needed=0 review=8",
+		},
+		{
+			"unconfigured buckets appended in bucket name order",
+			7,
+			[]*domain.Bucket{bucketOfSize("🚒 Hot-fix", 2), bucketOfSize("🔴 URGENT", 1), bucketOfSize("📋 Needs Data", 4)},
+			"BUGBUTLER_RESULT total=7 urgent=1 attention_needed=0 review=0 needs_data=4 hot_fix=2",
+		},
+		{
+			"nothing evaluated",
+			0,
+			nil,
+			"BUGBUTLER_RESULT total=0 urgent=0 attention_needed=0 review=0",
+		},
+	}
+	for _, tt := range tests {
+		if got := FormatSummaryLine(tt.total, tt.buckets, bucketNames); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
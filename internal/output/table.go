@@ -2,30 +2,38 @@ package output
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/neilmpatterson/bug-butler/internal/domain"
+	"github.com/neilmpatterson/bug-butler/internal/stats"
 )
 
 // DisplayBuckets renders the bucket groups as formatted terminal tables
 func DisplayBuckets(bucketGroup *domain.BucketGroup) {
 	if len(bucketGroup.Buckets) == 0 {
-		fmt.Println("\n✅ All bugs are compliant with SLA rules!")
+		Println("\n✅ All bugs are compliant with SLA rules!")
 		fmt.Println("No bugs require immediate attention.")
 		return
 	}
 
-	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Println("  BUG BUTLER - SLA VIOLATION REPORT")
-	fmt.Println(strings.Repeat("=", 80))
+	printBanner("BUG BUTLER - SLA VIOLATION REPORT")
 
-	// Display each bucket
+	// Display each bucket, listing buckets under the minimum size together at the end
+	var minor []*domain.Bucket
 	for _, bucket := range bucketGroup.Buckets {
+		if len(bucket.Bugs) > 0 && len(bucket.Bugs) < minBucketSize {
+			minor = append(minor, bucket)
+			continue
+		}
 		displayBucket(bucket)
 	}
+	displayMinorBuckets(minor)
 
 	// Display summary
 	displaySummary(bucketGroup)
@@ -33,7 +41,7 @@ func DisplayBuckets(bucketGroup *domain.BucketGroup) {
 
 // displayBucket renders a single bucket as a table
 func displayBucket(bucket *domain.Bucket) {
-	fmt.Printf("\n%s (%d bugs)\n", bucket.Name, len(bucket.Bugs))
+	Printf("\n%s (%d bugs)\n", bucket.Name, len(bucket.Bugs))
 
 	if len(bucket.Bugs) == 0 {
 		return
@@ -45,85 +53,284 @@ func displayBucket(bucket *domain.Bucket) {
 
 	// Set style based on severity
 	switch bucket.Severity {
-	case 1:
+	case 0, 1:
 		// Urgent - use rounded style with red colors
-		t.SetStyle(table.StyleRounded)
+		t.SetStyle(baseTableStyle())
 		t.Style().Color.Header = text.Colors{text.BgRed, text.FgWhite, text.Bold}
 		t.Style().Color.Row = text.Colors{text.FgHiRed}
 	case 2:
 		// Attention - use rounded style with yellow colors
-		t.SetStyle(table.StyleRounded)
+		t.SetStyle(baseTableStyle())
 		t.Style().Color.Header = text.Colors{text.BgYellow, text.FgBlack, text.Bold}
 		t.Style().Color.Row = text.Colors{text.FgHiYellow}
 	default:
 		// Review - default rounded style
-		t.SetStyle(table.StyleRounded)
+		t.SetStyle(baseTableStyle())
 	}
 
-	// Set headers
-	t.AppendHeader(table.Row{"Key", "Summary", "Priority", "Status", "Age"})
+	columns := activeColumns()
+	t.AppendHeader(columnHeader(columns))
 
-	// Add rows with clickable URLs
-	for _, bug := range bucket.Bugs {
-		// Create clickable link using OSC 8 escape sequence (supported by modern terminals)
-		clickableKey := fmt.Sprintf("\033]8;;%s\033\\%s\033]8;;\033\\", bug.URL(), bug.Key)
+	// Each row shows one bug, or with dedupe a group of bugs sharing a normalized summary
+	groups := make([][]*domain.Bug, len(bucket.Bugs))
+	for i, bug := range bucket.Bugs {
+		groups[i] = []*domain.Bug{bug}
+	}
+	if dedupeRows {
+		groups = stats.GroupBySummary(bucket.Bugs)
+	}
 
-		t.AppendRow(table.Row{
-			clickableKey,
-			truncateString(bug.Summary, 40),
-			bug.Priority,
-			bug.Status,
-			formatAge(bug.AgeDays()),
-		})
+	for _, group := range groups {
+		t.AppendRow(columnRow(columns, newBucketRow(group)))
 	}
 
 	t.Render()
 }
 
+// displayMinorBuckets lists the bugs of buckets below the minimum size, one line per bucket
+func displayMinorBuckets(buckets []*domain.Bucket) {
+	if len(buckets) == 0 {
+		return
+	}
+
+	total := 0
+	for _, bucket := range buckets {
+		total += len(bucket.Bugs)
+	}
+	Printf("\nMinor buckets (under %d bugs each, %d bugs)\n", minBucketSize, total)
+	for _, bucket := range buckets {
+		keys := make([]string, len(bucket.Bugs))
+		for i, bug := range bucket.Bugs {
+			keys[i] = bug.Key
+		}
+		Printf("  %s: %s\n", bucket.Name, strings.Join(keys, ", "))
+	}
+}
+
 // displaySummary shows a summary of all violations
 func displaySummary(bucketGroup *domain.BucketGroup) {
-	fmt.Println("\n" + strings.Repeat("-", 80))
-	fmt.Println("  SUMMARY")
-	fmt.Println(strings.Repeat("-", 80))
+	printSection("SUMMARY")
 
 	totalViolations := 0
 	for _, bucket := range bucketGroup.Buckets {
 		totalViolations += len(bucket.Bugs)
 	}
 
-	fmt.Printf("\nTotal SLA violations: %d\n", totalViolations)
+	if snoozed := totalViolations - bucketGroup.ActiveViolations(); snoozed > 0 {
+		fmt.Printf("\nTotal SLA violations: %d (%d snoozed)\n", totalViolations, snoozed)
+	} else {
+		fmt.Printf("\nTotal SLA violations: %d\n", totalViolations)
+	}
 	fmt.Println("\nBreakdown by bucket:")
 	for _, bucket := range bucketGroup.Buckets {
-		fmt.Printf("  %s: %d bugs\n", bucket.Name, len(bucket.Bugs))
+		Printf("  %s: %d bugs\n", bucket.Name, len(bucket.Bugs))
 	}
 
+	displayStatusBreakdown(bucketGroup.CountByStatus(), totalViolations)
+
 	fmt.Println()
 }
 
+// displayStatusBreakdown renders violation counts by status, most common first
+func displayStatusBreakdown(counts map[string]int, total int) {
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if counts[statuses[i]] != counts[statuses[j]] {
+			return counts[statuses[i]] > counts[statuses[j]]
+		}
+		return statuses[i] < statuses[j]
+	})
+
+	fmt.Println("\nBreakdown by status:")
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+	t.AppendHeader(table.Row{"Status", "Bugs", "Share"})
+	for _, status := range statuses {
+		t.AppendRow(table.Row{
+			status,
+			counts[status],
+			formatPercent(float64(counts[status])/float64(total)*100, 0),
+		})
+	}
+	t.Render()
+}
+
+// DisplaySinceDeploy reports how many fetched bugs were created after the deploy time
+func DisplaySinceDeploy(newBugs []*domain.Bug, deployed time.Time) {
+	printSection("SINCE LAST DEPLOY")
+
+	fmt.Printf("\nDeployed: %s\n", deployed.Format(time.RFC3339))
+	if len(newBugs) == 0 {
+		fmt.Println("No new bugs since the deploy.")
+		return
+	}
+
+	Printf("🆕 %d new bugs since the deploy (marked in the New column)\n", len(newBugs))
+}
+
+// DisplayAtRisk renders compliant bugs that are close to breaching their SLA
+func DisplayAtRisk(atRisk []*domain.AtRiskBug) {
+	printSection("AT RISK")
+
+	if len(atRisk) == 0 {
+		fmt.Println("\nNo compliant bugs are close to breaching their SLA.")
+		return
+	}
+
+	Printf("\n⏳ %d bugs will breach their SLA soon\n", len(atRisk))
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+	t.AppendHeader(table.Row{"Key", "Summary", "Priority", "Status", "Rule", "Remaining"})
+
+	for _, item := range atRisk {
+		t.AppendRow(table.Row{
+			hyperlink(item.Bug.URL(), item.Bug.Key),
+			truncateString(item.Bug.Summary, 40),
+			item.Bug.Priority,
+			item.Bug.Status,
+			item.RuleName,
+			formatAge(item.RemainingDays),
+		})
+	}
+
+	t.Render()
+}
+
+// showSource adds a Source column naming each bug's Jira instance
+var showSource bool
+
+// SetShowSource enables the Source column (used when several Jira instances are configured)
+func SetShowSource(show bool) {
+	showSource = show
+}
+
+// dedupeRows collapses bugs with the same normalized summary into one row per bucket
+var dedupeRows bool
+
+// SetDedupe enables collapsing duplicate summaries within a bucket into a single row
+func SetDedupe(dedupe bool) {
+	dedupeRows = dedupe
+}
+
+// showTags adds a Tags column listing the tag queries each bug matched
+var showTags bool
+
+// SetShowTags enables the Tags column (used when tag queries are configured)
+func SetShowTags(show bool) {
+	showTags = show
+}
+
+// deployTime adds a New column flagging bugs created after it (zero = no column)
+var deployTime time.Time
+
+// SetDeployTime enables the New column for bugs created after the given deploy time
+func SetDeployTime(t time.Time) {
+	deployTime = t
+}
+
+// isSinceDeploy reports whether the bug was created after the configured deploy time
+func isSinceDeploy(bug *domain.Bug) bool {
+	return !deployTime.IsZero() && bug.Created.After(deployTime)
+}
+
+// formatSinceDeploy renders the New column value
+func formatSinceDeploy(sinceDeploy bool) string {
+	if sinceDeploy {
+		return "yes"
+	}
+	return ""
+}
+
+// maxDisplayAgeDays caps displayed ages; older bugs show as ">N" (0 = no cap)
+var maxDisplayAgeDays float64
+
+// SetMaxDisplayAge sets the age cap in days for the Age column (0 disables)
+func SetMaxDisplayAge(days float64) {
+	maxDisplayAgeDays = days
+}
+
+// minBucketSize is the bug count below which a bucket is listed under minor buckets instead of as a table (0 = all tables)
+var minBucketSize int
+
+// SetMinBucketSize lists buckets with fewer than n bugs as single lines instead of tables (0 disables)
+func SetMinBucketSize(n int) {
+	minBucketSize = n
+}
+
 // formatAge converts age in days to a human-readable string
 func formatAge(days float64) string {
+	if maxDisplayAgeDays > 0 && days > maxDisplayAgeDays {
+		return ">" + formatAgeCap(maxDisplayAgeDays)
+	}
+	return formatDays(days)
+}
+
+// formatDays renders a number of days in minutes, hours, days, or weeks, whichever reads best
+func formatDays(days float64) string {
 	if days < 1 {
 		hours := days * 24
 		if hours < 1 {
 			minutes := hours * 60
-			return fmt.Sprintf("%.0f minutes", minutes)
+			return formatFloat(minutes, 0) + " minutes"
 		}
-		return fmt.Sprintf("%.1f hours", hours)
+		return formatFloat(hours, 1) + " hours"
 	} else if days < 7 {
-		return fmt.Sprintf("%.1f days", days)
+		return formatFloat(days, 1) + " days"
 	} else {
 		weeks := days / 7
-		return fmt.Sprintf("%.1f weeks", weeks)
+		return formatFloat(weeks, 1) + " weeks"
+	}
+}
+
+// formatAgeCap renders the age cap in the largest whole-ish unit (e.g., "2 years")
+func formatAgeCap(days float64) string {
+	var value float64
+	var unit string
+	switch {
+	case days >= 365:
+		value, unit = days/365, "year"
+	case days >= 7:
+		value, unit = days/7, "week"
+	default:
+		value, unit = days, "day"
+	}
+
+	rounded := math.Round(value*10) / 10
+	formatted := formatFloat(rounded, 0)
+	if rounded != math.Trunc(rounded) {
+		formatted = formatFloat(rounded, 1)
+	}
+	if rounded != 1 {
+		unit += "s"
+	}
+	return formatted + " " + unit
+}
+
+// formatOverage renders how far past its SLA threshold a bug is (e.g., "+4.2 days")
+// Bugs without a rule breach (e.g., data quality) show "-"
+// The overage is never capped by the max display age, so it always reads as an amount
+func formatOverage(overage *float64) string {
+	if overage == nil {
+		return "-"
 	}
+	return "+" + formatDays(*overage)
 }
 
 // truncateString truncates a string to maxLen characters with ellipsis
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
 	if maxLen <= 3 {
-		return s[:maxLen]
+		return string(runes[:maxLen])
 	}
-	return s[:maxLen-3] + "..."
+	return string(runes[:maxLen-3]) + "..."
 }
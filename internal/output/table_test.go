@@ -0,0 +1,144 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestFormatAgeCap(t *testing.T) {
+	defer SetMaxDisplayAge(maxDisplayAgeDays)
+
+	tests := []struct {
+		capDays float64
+		days    float64
+		want    string
+	}{
+		{0, 1000, "142.9 weeks"},
+		{730, 1000, ">2 years"},
+		{730, 700, "100.0 weeks"},
+		{365, 365, "52.1 weeks"},
+		{90, 100, ">12.9 weeks"},
+		{14, 20, ">2 weeks"},
+		{5, 6, ">5 days"},
+		{365, 0.5, "12.0 hours"},
+	}
+
+	for _, tt := range tests {
+		SetMaxDisplayAge(tt.capDays)
+		if got := formatAge(tt.days); got != tt.want {
+			t.Errorf("cap %v: formatAge(%v) = %q, want %q", tt.capDays, tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestFormatOverage(t *testing.T) {
+	defer SetMaxDisplayAge(maxDisplayAgeDays)
+	SetMaxDisplayAge(30)
+
+	overage := func(days float64) *float64 { return &days }
+
+	tests := []struct {
+		overage *float64
+		want    string
+	}{
+		{nil, "-"},
+		{overage(0.5), "+12.0 hours"},
+		{overage(4.2), "+4.2 days"},
+		{overage(70), "+10.0 weeks"},
+	}
+
+	for _, tt := range tests {
+		if got := formatOverage(tt.overage); got != tt.want {
+			t.Errorf("formatOverage = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestIsSinceDeploy(t *testing.T) {
+	defer SetDeployTime(deployTime)
+
+	deployed := time.Date(2025, 10, 1, 14, 0, 0, 0, time.UTC)
+	atDeploy := &domain.Bug{Created: deployed}
+	afterDeploy := &domain.Bug{Created: deployed.Add(time.Minute)}
+
+	SetDeployTime(time.Time{})
+	if isSinceDeploy(afterDeploy) {
+		t.Error("bug flagged as new without a deploy time")
+	}
+
+	SetDeployTime(deployed)
+	if isSinceDeploy(atDeploy) {
+		t.Error("bug created at the deploy time flagged as new")
+	}
+	if !isSinceDeploy(afterDeploy) {
+		t.Error("bug created after the deploy time not flagged as new")
+	}
+}
+
+func TestDisplayBucketsMinBucketSize(t *testing.T) {
+	defer SetPlain(plainMode)
+	defer SetMinBucketSize(minBucketSize)
+	SetPlain(true)
+
+	bugs := func(keys ...string) []*domain.Bug {
+		var bugs []*domain.Bug
+		for _, key := range keys {
+			bugs = append(bugs, &domain.Bug{Key: key, Priority: "High", Status: "Open", Updated: time.Now()})
+		}
+		return bugs
+	}
+	bucketGroup := &domain.BucketGroup{Buckets: []*domain.Bucket{
+		{Name: "URGENT", Severity: 1, Bugs: bugs("DEMO-1", "DEMO-2", "DEMO-3")},
+		{Name: "ATTENTION", Severity: 2, Bugs: bugs("DEMO-4")},
+		{Name: "REVIEW", Severity: 3, Bugs: bugs("DEMO-5", "DEMO-6")},
+	}}
+
+	SetMinBucketSize(3)
+	out := captureStdout(t, func() { DisplayBuckets(bucketGroup) })
+	for _, want := range []string{
+		"URGENT (3 bugs)",
+		"Minor buckets (under 3 bugs each, 3 bugs)",
+		"  ATTENTION: DEMO-4\n",
+		"  REVIEW: DEMO-5, DEMO-6\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, collapsed := range []string{"ATTENTION (1 bugs)", "REVIEW (2 bugs)"} {
+		if strings.Contains(out, collapsed) {
+			t.Errorf("output has a table for %q below the minimum:\n%s", collapsed, out)
+		}
+	}
+
+	// The default shows every bucket as a table
+	SetMinBucketSize(0)
+	out = captureStdout(t, func() { DisplayBuckets(bucketGroup) })
+	if strings.Contains(out, "Minor buckets") || !strings.Contains(out, "ATTENTION (1 bugs)") {
+		t.Errorf("output with no minimum collapsed buckets:\n%s", out)
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a longer summary", 10, "a longe..."},
+		{"abcdef", 3, "abc"},
+		// Multi-byte characters count once and are never split
+		{"Fehler beim Öffnen der Datei", 14, "Fehler beim..."},
+		{"🔥🔥🔥🔥🔥", 4, "🔥..."},
+	}
+	for _, tt := range tests {
+		if got := truncateString(tt.s, tt.maxLen); got != tt.want {
+			t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// DisplayThrashing renders unresolved bugs with many comments, most discussed first
+func DisplayThrashing(bugs []*domain.Bug, minComments int) {
+	printSection("POSSIBLY THRASHING")
+
+	if len(bugs) == 0 {
+		fmt.Printf("\nNo unresolved bugs with %d or more comments.\n", minComments)
+		return
+	}
+
+	Printf("\n💬 %d unresolved bugs with %d or more comments\n", len(bugs), minComments)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(baseTableStyle())
+	t.AppendHeader(table.Row{"Key", "Summary", "Priority", "Status", "Age", "Comments"})
+
+	for _, bug := range bugs {
+		t.AppendRow(table.Row{
+			hyperlink(bug.URL(), bug.Key),
+			truncateString(bug.Summary, 40),
+			bug.Priority,
+			bug.Status,
+			formatAge(bug.AgeDays()),
+			bug.CommentCount,
+		})
+	}
+
+	t.Render()
+}
@@ -0,0 +1,187 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type Schedule struct {
+	minute, hour, dom, month, dow uint64 // Bit i set = value i allowed
+	domAny, dowAny                bool   // Field starts with "*", so only the other day field restricts days
+}
+
+// macros are the supported @-shorthands and their five-field equivalents
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// fieldBounds are the allowed value ranges of the five fields, in order
+var fieldBounds = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are both Sunday
+}
+
+// Parse parses a standard five-field cron expression (e.g., "0 9 * * 1-5") or an @-shorthand
+// like "@daily"; fields accept *, values, ranges (1-5), steps (*/15, 0-30/10), and lists (1,3,5)
+// As in classic cron, when both day fields are restricted a day matching either one runs
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expanded, ok := macros[strings.ToLower(expr)]; ok {
+		expr = expanded
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		b, err := parseField(field, fieldBounds[i].min, fieldBounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %w", fieldBounds[i].name, field, err)
+		}
+		bits[i] = b
+	}
+
+	// Sunday may be written as 7
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    dow,
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseField returns the bitset of values a comma-separated field allows within [min, max]
+func parseField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepPart)
+			if err != nil || s < 1 {
+				return 0, fmt.Errorf("step %q must be a positive number", stepPart)
+			}
+			step = s
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			loPart, hiPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = parseValue(loPart, min, max); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(hiPart, min, max); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("range %q starts after it ends", rangePart)
+			}
+		default:
+			v, err := parseValue(rangePart, min, max)
+			if err != nil {
+				return 0, err
+			}
+			// "5/15" means from 5 to the end in steps of 15
+			lo, hi = v, v
+			if hasStep {
+				hi = max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseValue parses a single field value and checks it is within [min, max]
+func parseValue(s string, min, max int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%d is outside %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+// maxSearchYears bounds the search for the next run (e.g., "0 0 30 2 *" never runs)
+const maxSearchYears = 5
+
+// Next returns the first time strictly after t, truncated to the minute, that the schedule runs,
+// in t's location; it returns the zero time if the schedule never runs
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether t's day satisfies the day-of-month and day-of-week fields
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
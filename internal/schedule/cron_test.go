@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"0 9 * *", "must have 5 fields"},
+		{"60 * * * *", "invalid minute field"},
+		{"0 24 * * *", "invalid hour field"},
+		{"0 0 0 * *", "invalid day of month field"},
+		{"0 0 * 13 *", "invalid month field"},
+		{"0 0 * * 8", "invalid day of week field"},
+		{"*/0 * * * *", "must be a positive number"},
+		{"5-1 * * * *", "starts after it ends"},
+		{"a * * * *", "is not a number"},
+		{"@fortnightly", "must have 5 fields"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Parse(%q) error = %v, want it to contain %q", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2025-03-05 is a Wednesday
+	from := time.Date(2025, 3, 5, 10, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 3, 5, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 3, 5, 10, 45, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2025, 3, 5, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2025, 3, 6, 9, 0, 0, 0, time.UTC)},
+		{"0 9,17 * * *", time.Date(2025, 3, 5, 17, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2025, 3, 6, 10, 30, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 5, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 10th or any Monday, whichever comes first
+		{"0 0 10 * 1", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"0 0 6 * 5", time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		sched, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := sched.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %s, want %s", tt.expr, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestNextNeverRuns(t *testing.T) {
+	sched, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := sched.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Next for February 30 = %s, want the zero time", got)
+	}
+}
+
+func TestNextKeepsLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	sched, err := Parse("0 9 * * *")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// 01:00 UTC is 10:00 in UTC+9, past today's 09:00 there
+	got := sched.Next(time.Date(2025, 3, 5, 1, 0, 0, 0, time.UTC).In(loc))
+	if want := time.Date(2025, 3, 6, 9, 0, 0, 0, loc); !got.Equal(want) || got.Location() != loc {
+		t.Errorf("Next = %s, want %s", got, want)
+	}
+}
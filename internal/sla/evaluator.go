@@ -1,7 +1,11 @@
 package sla
 
 import (
+	"fmt"
 	"log/slog"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/neilmpatterson/bug-butler/internal/config"
 	"github.com/neilmpatterson/bug-butler/internal/domain"
@@ -9,14 +13,29 @@ import (
 
 // Evaluator applies SLA rules to bugs and groups them into buckets
 type Evaluator struct {
-	rules []domain.SLARule
+	rules               []domain.SLARule
+	ignoreOlderThanDays float64
+	requiredFields      []string
+	dataBucket          string
+	dataSeverity        int
+	atRiskPercent       float64
+	snoozed             func(key string) bool // Reports whether a bug is currently snoozed (nil = none)
+	activeParents       []string              // Parent statuses that suppress a bug's violations
+	labelBuckets        []config.LabelBucket  // Labels routing bugs to a bucket ahead of SLA rules
+	matchers            []domain.RuleMatcher  // Rules in evaluation order: the configured rules plus any custom ones
 }
 
 // NewEvaluator creates a new SLA evaluator with the given rules
 func NewEvaluator(rules []config.SLARule) *Evaluator {
 	// Convert config rules to domain rules
-	domainRules := make([]domain.SLARule, len(rules))
-	for i, rule := range rules {
+	domainRules := make([]domain.SLARule, 0, len(rules))
+	for _, rule := range rules {
+		// Skip rules that have been temporarily disabled
+		if !rule.IsEnabled() {
+			slog.Info("Skipping disabled SLA rule", "rule", rule.Name)
+			continue
+		}
+
 		// Handle single status string by converting to array
 		statuses := rule.Status
 		if len(statuses) == 0 && rule.Priority != "" {
@@ -24,26 +43,136 @@ func NewEvaluator(rules []config.SLARule) *Evaluator {
 			statuses = nil
 		}
 
-		domainRules[i] = domain.SLARule{
-			Name:       rule.Name,
-			Priority:   rule.Priority,
-			Status:     statuses,
-			MaxAgeDays: rule.MaxAgeDays,
-			BucketName: rule.Bucket,
-			Severity:   rule.Severity,
+		// Convert escalation tiers
+		var tiers []domain.SLATier
+		for _, tier := range rule.Tiers {
+			tiers = append(tiers, domain.SLATier{
+				MaxAgeDays: tier.MaxAgeDays,
+				BucketName: tier.Bucket,
+				Severity:   tier.Severity,
+			})
 		}
+
+		domainRules = append(domainRules, domain.SLARule{
+			Name:           rule.Name,
+			Priority:       rule.Priority,
+			Status:         statuses,
+			MaxAgeDays:     rule.MaxAgeDays,
+			Bucket:         rule.Bucket,
+			BucketSeverity: rule.Severity,
+			FixVersions:    rule.FixVersion,
+			Tiers:          tiers,
+			AgeFrom:        rule.AgeFrom,
+			Clock:          rule.Clock,
+		})
+	}
+
+	matchers := make([]domain.RuleMatcher, len(domainRules))
+	for i := range domainRules {
+		matchers[i] = &domainRules[i]
 	}
 
 	return &Evaluator{
-		rules: domainRules,
+		rules:    domainRules,
+		matchers: matchers,
+	}
+}
+
+// SetIgnoreOlderThan excludes bugs older than the given age in days from evaluation (0 disables)
+func (e *Evaluator) SetIgnoreOlderThan(days float64) {
+	e.ignoreOlderThanDays = days
+}
+
+// SetDataQuality flags bugs missing any of the required fields into the given bucket
+// The check is a fallback: it applies to compliant bugs of any age, while a bug that violates
+// an SLA rule stays in that rule's bucket, so each bug is listed once
+func (e *Evaluator) SetDataQuality(requiredFields []string, bucket string, severity int) {
+	e.requiredFields = requiredFields
+	e.dataBucket = bucket
+	e.dataSeverity = severity
+}
+
+// SetAtRiskPercent reports compliant bugs within the given percentage of their
+// rule's threshold as at risk (0 disables)
+func (e *Evaluator) SetAtRiskPercent(percent float64) {
+	e.atRiskPercent = percent
+}
+
+// SetSnoozed moves violating bugs for which snoozed returns true into the Snoozed bucket
+func (e *Evaluator) SetSnoozed(snoozed func(key string) bool) {
+	e.snoozed = snoozed
+}
+
+// SetActiveParentStatuses skips bugs whose parent is in one of the statuses (case-insensitive),
+// since the parent's work covers them; requires Bug.ParentStatus to be fetched
+func (e *Evaluator) SetActiveParentStatuses(statuses []string) {
+	e.activeParents = statuses
+}
+
+// SetLabelBuckets routes bugs with a matching label (case-insensitive) to its bucket regardless
+// of age, ahead of SLA rules; the first matching entry wins
+func (e *Evaluator) SetLabelBuckets(labelBuckets []config.LabelBucket) {
+	e.labelBuckets = labelBuckets
+}
+
+// AddRuleMatchers registers custom rules after the configured rules; all rules are tried in order
+// and the first one a bug violates picks its bucket
+func (e *Evaluator) AddRuleMatchers(matchers ...domain.RuleMatcher) {
+	e.matchers = append(e.matchers, matchers...)
+}
+
+// InsertRuleMatchers registers custom rules at the given position among the rules
+// (0 = ahead of every configured rule; past the end appends)
+func (e *Evaluator) InsertRuleMatchers(index int, matchers ...domain.RuleMatcher) {
+	e.matchers = slices.Insert(e.matchers, min(max(index, 0), len(e.matchers)), matchers...)
+}
+
+// ruleName names a rule in violations; custom rules use their String method if they have one
+func ruleName(matcher domain.RuleMatcher) string {
+	switch m := matcher.(type) {
+	case *domain.SLARule:
+		return m.Name
+	case fmt.Stringer:
+		return m.String()
+	default:
+		return fmt.Sprintf("%T", matcher)
 	}
 }
 
+// labelBucket returns the first label bucket matching one of the bug's labels (nil if none)
+func (e *Evaluator) labelBucket(bug *domain.Bug) *config.LabelBucket {
+	for i, lb := range e.labelBuckets {
+		if slices.ContainsFunc(bug.Labels, func(label string) bool { return strings.EqualFold(label, lb.Label) }) {
+			return &e.labelBuckets[i]
+		}
+	}
+	return nil
+}
+
+// SetWorkingHours sets the clock used by rules with clock: working_hours
+func (e *Evaluator) SetWorkingHours(workingHours *domain.WorkingHours) {
+	for i := range e.rules {
+		if e.rules[i].Clock == config.ClockWorkingHours {
+			e.rules[i].WorkClock = workingHours
+		}
+	}
+}
+
+// addViolation adds a violating bug to its bucket, or to the Snoozed bucket if it is snoozed
+func (e *Evaluator) addViolation(bucketGroup *domain.BucketGroup, bucketName string, severity int, bug *domain.Bug) {
+	if e.snoozed != nil && e.snoozed(bug.Key) {
+		slog.Debug("Bug is snoozed", "bug_key", bug.Key, "bucket", bucketName)
+		bucketGroup.AddToBucket(domain.SnoozedBucketName, domain.SnoozedSeverity, bug)
+		return
+	}
+	bucketGroup.AddToBucket(bucketName, severity, bug)
+}
+
 // Evaluate applies SLA rules to bugs and returns grouped buckets
 func (e *Evaluator) Evaluate(bugs []*domain.Bug) *domain.BucketGroup {
 	bucketGroup := &domain.BucketGroup{}
 
-	slog.Debug("Evaluating bugs against SLA rules", "bug_count", len(bugs), "rule_count", len(e.rules))
+	slog.Debug("Evaluating bugs against SLA rules", "bug_count", len(bugs), "rule_count", len(e.rules), "custom_rule_count", len(e.matchers)-len(e.rules))
 
 	// Track unique priorities and statuses for debugging
 	priorities := make(map[string]int)
@@ -56,39 +185,124 @@ func (e *Evaluator) Evaluate(bugs []*domain.Bug) *domain.BucketGroup {
 	slog.Debug("Bug distribution by status", "statuses", statuses)
 
 	violationCount := 0
+	ignoredCount := 0
+	suppressedCount := 0
 
 	// Process each bug
 	for _, bug := range bugs {
-		// Try to match against rules in order (first-match wins)
+		// Clear any violation from a previous evaluation of the same bugs
+		bug.Violation = nil
+
+		// Labeled bugs go to their bucket whatever their age or parent
+		if lb := e.labelBucket(bug); lb != nil {
+			slog.Debug("Routing bug by label", "bug_key", bug.Key, "label", lb.Label, "bucket", lb.Bucket)
+			e.addViolation(bucketGroup, lb.Bucket, lb.Severity, bug)
+			violationCount++
+			continue
+		}
+
+		// Skip abandoned bugs beyond the ignore threshold
+		if e.ignoreOlderThanDays > 0 && bug.AgeDays() > e.ignoreOlderThanDays {
+			slog.Debug("Ignoring bug older than threshold",
+				"bug_key", bug.Key,
+				"age_days", bug.AgeDays(),
+				"ignore_older_than_days", e.ignoreOlderThanDays,
+			)
+			ignoredCount++
+			continue
+		}
+
+		// Skip bugs covered by their parent's active work
+		if bug.ParentStatus != "" && slices.ContainsFunc(e.activeParents, func(s string) bool {
+			return strings.EqualFold(s, bug.ParentStatus)
+		}) {
+			slog.Debug("Suppressing bug with active parent",
+				"bug_key", bug.Key,
+				"parent_key", bug.ParentKey,
+				"parent_status", bug.ParentStatus,
+			)
+			suppressedCount++
+			continue
+		}
+
+		// Try the configured and custom rules in order (first violation wins)
 		matched := false
-		for _, rule := range e.rules {
-			// Check if bug matches criteria (priority + status)
-			if rule.Matches(bug) {
-				// Bug matches criteria - check if it violates age threshold
-				if rule.Violates(bug) {
-					slog.Debug("Bug violates SLA rule",
-						"bug_key", bug.Key,
-						"rule", rule.Name,
-						"priority", bug.Priority,
-						"status", bug.Status,
-						"age_days", bug.AgeDays(),
-						"max_age", rule.MaxAgeDays,
-					)
-					bucketGroup.AddToBucket(rule.BucketName, rule.Severity, bug)
-					matched = true
-					violationCount++
-					break // First-match wins
-				} else {
-					// Matched criteria but within SLA (too new)
+		var compliantRule *domain.SLARule // First configured rule matched without violation
+		for _, matcher := range e.matchers {
+			if !matcher.Matches(bug) {
+				continue
+			}
+			rule, configured := matcher.(*domain.SLARule)
+
+			if !matcher.Violates(bug) {
+				// Matched criteria but within SLA (too new)
+				if configured {
 					slog.Debug("Bug matches criteria but within SLA",
 						"bug_key", bug.Key,
 						"rule", rule.Name,
 						"priority", bug.Priority,
 						"status", bug.Status,
-						"age_days", bug.AgeDays(),
+						"age_days", rule.AgeDays(bug),
 						"max_age", rule.MaxAgeDays,
 					)
+					if compliantRule == nil {
+						compliantRule = rule
+					}
 				}
+				continue
+			}
+
+			// Custom rules have no age threshold, so the whole age counts as overage
+			bucketName, severity := matcher.BucketName(), matcher.Severity()
+			violation := &domain.Violation{RuleName: ruleName(matcher), AgeDays: bug.AgeDays()}
+			if configured {
+				// Configured rules place the bug by the escalation tier it breached
+				tier := rule.BreachedTier(bug)
+				bucketName, severity = tier.BucketName, tier.Severity
+				violation.MaxAgeDays = tier.MaxAgeDays
+				violation.AgeDays = rule.AgeDays(bug)
+			}
+			slog.Debug("Bug violates SLA rule",
+				"bug_key", bug.Key,
+				"rule", violation.RuleName,
+				"priority", bug.Priority,
+				"status", bug.Status,
+				"age_days", violation.AgeDays,
+				"max_age", violation.MaxAgeDays,
+				"bucket", bucketName,
+			)
+			bug.Violation = violation
+			e.addViolation(bucketGroup, bucketName, severity, bug)
+			matched = true
+			violationCount++
+			break // First violation wins
+		}
+
+		// Fallback: bugs that pass SLA rules, however young, are still checked for missing
+		// required data (violating bugs are already reported in their rule's bucket)
+		if !matched && len(e.requiredFields) > 0 {
+			if missing := bug.MissingFields(e.requiredFields); len(missing) > 0 {
+				slog.Debug("Bug is missing required fields",
+					"bug_key", bug.Key,
+					"missing", missing,
+				)
+				e.addViolation(bucketGroup, e.dataBucket, e.dataSeverity, bug)
+				matched = true
+				violationCount++
+			}
+		}
+
+		// Compliant bugs close to breaching their rule are reported as at risk
+		if !matched && compliantRule != nil && e.atRiskPercent > 0 {
+			threshold := compliantRule.FirstThreshold()
+			remaining := compliantRule.RemainingDays(bug)
+			if threshold > 0 && remaining <= threshold*e.atRiskPercent/100 {
+				bucketGroup.AtRisk = append(bucketGroup.AtRisk, &domain.AtRiskBug{
+					Bug:           bug,
+					RuleName:      compliantRule.Name,
+					MaxAgeDays:    threshold,
+					RemainingDays: remaining,
+				})
 			}
 		}
 
@@ -102,12 +316,18 @@ func (e *Evaluator) Evaluate(bugs []*domain.Bug) *domain.BucketGroup {
 		}
 	}
 
-	// Sort buckets by severity
+	// Sort buckets by severity and at-risk bugs by time remaining
 	bucketGroup.Sort()
+	sort.SliceStable(bucketGroup.AtRisk, func(i, j int) bool {
+		return bucketGroup.AtRisk[i].RemainingDays < bucketGroup.AtRisk[j].RemainingDays
+	})
 
 	slog.Debug("SLA evaluation complete",
 		"total_bugs", len(bugs),
 		"violations", violationCount,
+		"ignored", ignoredCount,
+		"suppressed_by_parent", suppressedCount,
+		"at_risk", len(bucketGroup.AtRisk),
 		"buckets", len(bucketGroup.Buckets),
 	)
 
@@ -0,0 +1,346 @@
+package sla
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+	"github.com/neilmpatterson/bug-butler/internal/snooze"
+)
+
+// daysAgo returns the time the given number of days before now
+func daysAgo(days float64) time.Time {
+	return time.Now().Add(-time.Duration(days * 24 * float64(time.Hour)))
+}
+
+// bucketOf returns the name of the bucket holding the bug with the given key (empty if none)
+func bucketOf(bg *domain.BucketGroup, key string) string {
+	for _, bucket := range bg.Buckets {
+		for _, bug := range bucket.Bugs {
+			if bug.Key == key {
+				return bucket.Name
+			}
+		}
+	}
+	return ""
+}
+
+func TestEvaluateEscalationTiers(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name:     "critical",
+		Priority: "Critical",
+		Tiers: []config.SLATier{
+			{MaxAgeDays: 1, Bucket: "🔴 URGENT", Severity: 2},
+			{MaxAgeDays: 7, Bucket: "🔥 ESCALATED", Severity: 1},
+		},
+	}})
+
+	bugs := []*domain.Bug{
+		{Key: "NEW-1", Priority: "Critical", Updated: daysAgo(0.5)},
+		{Key: "DAY-2", Priority: "Critical", Updated: daysAgo(2)},
+		{Key: "WEEK-2", Priority: "Critical", Updated: daysAgo(14)},
+	}
+
+	bg := evaluator.Evaluate(bugs)
+
+	for key, want := range map[string]string{"NEW-1": "", "DAY-2": "🔴 URGENT", "WEEK-2": "🔥 ESCALATED"} {
+		if got := bucketOf(bg, key); got != want {
+			t.Errorf("%s bucket = %q, want %q", key, got, want)
+		}
+	}
+
+	if v := bugs[2].Violation; v == nil || v.MaxAgeDays != 7 {
+		t.Errorf("WEEK-2 violation = %+v, want the 7-day tier", v)
+	}
+	if bg.Buckets[0].Name != "🔥 ESCALATED" {
+		t.Errorf("first bucket = %q, want the most severe tier first", bg.Buckets[0].Name)
+	}
+}
+
+func TestEvaluateIgnoreOlderThan(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name: "any", MaxAgeDays: 7, Bucket: "🟠 STALE", Severity: 2,
+	}})
+	evaluator.SetIgnoreOlderThan(365)
+
+	bugs := []*domain.Bug{
+		{Key: "STALE-1", Updated: daysAgo(30)},
+		{Key: "ABANDONED-1", Updated: daysAgo(400)},
+	}
+
+	bg := evaluator.Evaluate(bugs)
+	if got := bucketOf(bg, "STALE-1"); got != "🟠 STALE" {
+		t.Errorf("STALE-1 bucket = %q, want 🟠 STALE", got)
+	}
+	if got := bucketOf(bg, "ABANDONED-1"); got != "" {
+		t.Errorf("ABANDONED-1 bucket = %q, want it ignored", got)
+	}
+}
+
+func TestEvaluateDisabledRules(t *testing.T) {
+	disabled, enabled := false, true
+	evaluator := NewEvaluator([]config.SLARule{
+		{Name: "paused", Priority: "Critical", MaxAgeDays: 1, Bucket: "🔴 URGENT", Severity: 1, Enabled: &disabled},
+		{Name: "on", Priority: "High", MaxAgeDays: 1, Bucket: "🟠 HIGH", Severity: 2, Enabled: &enabled},
+		{Name: "default", Priority: "Medium", MaxAgeDays: 1, Bucket: "🟡 MEDIUM", Severity: 3},
+	})
+
+	bugs := []*domain.Bug{
+		{Key: "CRIT-1", Priority: "Critical", Updated: daysAgo(30)},
+		{Key: "HIGH-1", Priority: "High", Updated: daysAgo(30)},
+		{Key: "MED-1", Priority: "Medium", Updated: daysAgo(30)},
+	}
+
+	bg := evaluator.Evaluate(bugs)
+	if got := bucketOf(bg, "CRIT-1"); got != "" {
+		t.Errorf("CRIT-1 bucket = %q, want no violation from the disabled rule", got)
+	}
+	if bugs[0].Violation != nil {
+		t.Errorf("CRIT-1 violation = %+v, want nil", bugs[0].Violation)
+	}
+	if got := bucketOf(bg, "HIGH-1"); got != "🟠 HIGH" {
+		t.Errorf("HIGH-1 bucket = %q, want 🟠 HIGH", got)
+	}
+	if got := bucketOf(bg, "MED-1"); got != "🟡 MEDIUM" {
+		t.Errorf("MED-1 bucket = %q, want rules enabled by default", got)
+	}
+}
+
+func TestEvaluateDataQuality(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name: "high", Priority: "High", MaxAgeDays: 7, Bucket: "🟠 HIGH", Severity: 2,
+	}})
+	evaluator.SetDataQuality([]string{"priority", "assignee"}, "🟣 NEEDS DATA", 4)
+
+	bugs := []*domain.Bug{
+		{Key: "NEW-1", Priority: "Unknown", Assignee: "Ada", Updated: daysAgo(0.1)},
+		{Key: "OLD-1", Priority: "Low", Updated: daysAgo(200)},
+		{Key: "OK-1", Priority: "Low", Assignee: "Ada", Updated: daysAgo(200)},
+		{Key: "STALE-1", Priority: "High", Updated: daysAgo(30)},
+		{Key: "FRESH-1", Priority: "High", Updated: daysAgo(1)},
+	}
+
+	bg := evaluator.Evaluate(bugs)
+
+	for key, want := range map[string]string{
+		"NEW-1":   "🟣 NEEDS DATA", // Missing priority, flagged however young
+		"OLD-1":   "🟣 NEEDS DATA", // Missing assignee, matched by no rule
+		"OK-1":    "",
+		"STALE-1": "🟠 HIGH",       // Violations stay in their rule's bucket
+		"FRESH-1": "🟣 NEEDS DATA", // Compliant with its rule but unassigned
+	} {
+		if got := bucketOf(bg, key); got != want {
+			t.Errorf("%s bucket = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEvaluateAtRisk(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name: "high", Priority: "High", MaxAgeDays: 10, Bucket: "🟠 HIGH", Severity: 2,
+	}})
+	evaluator.SetAtRiskPercent(20)
+
+	bugs := []*domain.Bug{
+		{Key: "SAFE-1", Priority: "High", Updated: daysAgo(5)},
+		{Key: "RISK-1", Priority: "High", Updated: daysAgo(8.5)},
+		{Key: "RISK-2", Priority: "High", Updated: daysAgo(9.5)},
+		{Key: "LATE-1", Priority: "High", Updated: daysAgo(12)},
+		{Key: "OTHER-1", Priority: "Low", Updated: daysAgo(9.5)},
+	}
+
+	bg := evaluator.Evaluate(bugs)
+
+	var keys []string
+	for _, risk := range bg.AtRisk {
+		keys = append(keys, risk.Bug.Key)
+		if risk.RuleName != "high" || risk.MaxAgeDays != 10 {
+			t.Errorf("%s at risk of %q (%v days), want high (10 days)", risk.Bug.Key, risk.RuleName, risk.MaxAgeDays)
+		}
+	}
+	// Soonest to breach first
+	if want := []string{"RISK-2", "RISK-1"}; !slices.Equal(keys, want) {
+		t.Errorf("at-risk bugs = %v, want %v", keys, want)
+	}
+	if remaining := bg.AtRisk[0].RemainingDays; remaining < 0.4 || remaining > 0.6 {
+		t.Errorf("RISK-2 remaining = %.2f days, want ~0.5", remaining)
+	}
+	if got := bucketOf(bg, "LATE-1"); got != "🟠 HIGH" {
+		t.Errorf("LATE-1 bucket = %q, want the violation bucket", got)
+	}
+
+	evaluator.SetAtRiskPercent(0)
+	if bg := evaluator.Evaluate(bugs); len(bg.AtRisk) != 0 {
+		t.Errorf("got %d at-risk bugs with the check disabled, want none", len(bg.AtRisk))
+	}
+}
+
+func TestEvaluateSnoozed(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name: "high", Priority: "High", MaxAgeDays: 5, Bucket: "🟠 HIGH", Severity: 2,
+	}})
+	snoozes := snooze.List{
+		"LIVE-1":    time.Now().AddDate(0, 0, 7),
+		"EXPIRED-1": time.Now().AddDate(0, 0, -1),
+	}
+	now := time.Now()
+	evaluator.SetSnoozed(func(key string) bool { return snoozes.Active(key, now) })
+
+	bg := evaluator.Evaluate([]*domain.Bug{
+		{Key: "LIVE-1", Priority: "High", Updated: daysAgo(10)},
+		{Key: "EXPIRED-1", Priority: "High", Updated: daysAgo(10)},
+		{Key: "OPEN-1", Priority: "High", Updated: daysAgo(10)},
+	})
+
+	want := map[string]string{
+		"LIVE-1":    domain.SnoozedBucketName,
+		"EXPIRED-1": "🟠 HIGH",
+		"OPEN-1":    "🟠 HIGH",
+	}
+	for key, bucket := range want {
+		if got := bucketOf(bg, key); got != bucket {
+			t.Errorf("%s bucket = %q, want %q", key, got, bucket)
+		}
+	}
+	if got := bg.ActiveViolations(); got != 2 {
+		t.Errorf("ActiveViolations = %d, want 2 (snoozed bugs don't count)", got)
+	}
+}
+
+func TestEvaluateActiveParentSuppression(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name: "high", Priority: "High", MaxAgeDays: 5, Bucket: "🟠 HIGH", Severity: 2,
+	}})
+	evaluator.SetActiveParentStatuses([]string{"In Progress", "In Review"})
+
+	bg := evaluator.Evaluate([]*domain.Bug{
+		{Key: "CHILD-1", Priority: "High", Updated: daysAgo(10), ParentKey: "EPIC-1", ParentStatus: "in progress"},
+		{Key: "CHILD-2", Priority: "High", Updated: daysAgo(10), ParentKey: "EPIC-2", ParentStatus: "Backlog"},
+		{Key: "CHILD-3", Priority: "High", Updated: daysAgo(10), ParentKey: "EPIC-3"},
+		{Key: "ORPHAN-1", Priority: "High", Updated: daysAgo(10)},
+	})
+
+	want := map[string]string{
+		"CHILD-1":  "",
+		"CHILD-2":  "🟠 HIGH",
+		"CHILD-3":  "🟠 HIGH", // Parent status unknown, so nothing suppresses it
+		"ORPHAN-1": "🟠 HIGH",
+	}
+	for key, bucket := range want {
+		if got := bucketOf(bg, key); got != bucket {
+			t.Errorf("%s bucket = %q, want %q", key, got, bucket)
+		}
+	}
+}
+
+func TestEvaluateLabelBucketPrecedence(t *testing.T) {
+	evaluator := NewEvaluator([]config.SLARule{{
+		Name:       "high",
+		Priority:   "High",
+		MaxAgeDays: 7,
+		Bucket:     "🔥 HIGH",
+		Severity:   1,
+	}})
+	evaluator.SetIgnoreOlderThan(365)
+	evaluator.SetActiveParentStatuses([]string{"In Progress"})
+	evaluator.SetLabelBuckets([]config.LabelBucket{
+		{Label: "hotfix", Bucket: "🚒 HOTFIX"},
+		{Label: "customer", Bucket: "📞 CUSTOMER", Severity: 1},
+	})
+
+	bugs := []*domain.Bug{
+		{Key: "NEW-1", Priority: "High", Updated: daysAgo(1), Labels: []string{"HotFix"}},
+		{Key: "BREACH-1", Priority: "High", Updated: daysAgo(30), Labels: []string{"hotfix"}},
+		{Key: "BOTH-1", Priority: "High", Updated: daysAgo(30), Labels: []string{"customer", "hotfix"}},
+		{Key: "OLD-1", Priority: "High", Updated: daysAgo(400), Labels: []string{"customer"}},
+		{Key: "CHILD-1", Priority: "High", Updated: daysAgo(30), ParentStatus: "In Progress", Labels: []string{"customer"}},
+		{Key: "PLAIN-1", Priority: "High", Updated: daysAgo(30), Labels: []string{"frontend"}},
+	}
+	bg := evaluator.Evaluate(bugs)
+
+	// Labels win over rules, age and active parents; the first configured label entry wins
+	for key, want := range map[string]string{
+		"NEW-1":    "🚒 HOTFIX",
+		"BREACH-1": "🚒 HOTFIX",
+		"BOTH-1":   "🚒 HOTFIX",
+		"OLD-1":    "📞 CUSTOMER",
+		"CHILD-1":  "📞 CUSTOMER",
+		"PLAIN-1":  "🔥 HIGH",
+	} {
+		if got := bucketOf(bg, key); got != want {
+			t.Errorf("%s bucket = %q, want %q", key, got, want)
+		}
+	}
+	if bg.Buckets[0].Name != "🚒 HOTFIX" {
+		t.Errorf("first bucket = %q, want the severity 0 label bucket first", bg.Buckets[0].Name)
+	}
+}
+
+// securityMatcher is a custom rule bucketing unassigned bugs that mention security, at any age
+type securityMatcher struct{}
+
+func (securityMatcher) Matches(bug *domain.Bug) bool {
+	return strings.Contains(strings.ToLower(bug.Summary), "security")
+}
+func (securityMatcher) Violates(bug *domain.Bug) bool {
+	return bug.Assignee == ""
+}
+func (securityMatcher) BucketName() string { return "🔐 SECURITY" }
+func (securityMatcher) Severity() int      { return 1 }
+func (securityMatcher) String() string     { return "unassigned security" }
+
+func TestEvaluateCustomRuleMatcher(t *testing.T) {
+	rules := []config.SLARule{{Name: "high", Priority: "High", MaxAgeDays: 7, Bucket: "🔥 HIGH", Severity: 2}}
+	bugs := func() []*domain.Bug {
+		return []*domain.Bug{
+			{Key: "SEC-1", Priority: "High", Summary: "Security: token leak", Updated: daysAgo(1)},
+			{Key: "SEC-2", Priority: "High", Summary: "Security: token leak", Updated: daysAgo(30)},
+			{Key: "SEC-3", Priority: "High", Summary: "Security: token leak", Updated: daysAgo(1), Assignee: "Alex"},
+			{Key: "OLD-1", Priority: "High", Summary: "Layout glitch", Updated: daysAgo(30)},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		register func(e *Evaluator)
+		want     map[string]string
+	}{
+		{
+			// Appended after the configured rule, it only gets bugs that rule doesn't find in violation
+			name:     "after configured rules",
+			register: func(e *Evaluator) { e.AddRuleMatchers(securityMatcher{}) },
+			want:     map[string]string{"SEC-1": "🔐 SECURITY", "SEC-2": "🔥 HIGH", "SEC-3": "", "OLD-1": "🔥 HIGH"},
+		},
+		{
+			name:     "ahead of configured rules",
+			register: func(e *Evaluator) { e.InsertRuleMatchers(0, securityMatcher{}) },
+			want:     map[string]string{"SEC-1": "🔐 SECURITY", "SEC-2": "🔐 SECURITY", "SEC-3": "", "OLD-1": "🔥 HIGH"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluator := NewEvaluator(rules)
+			tt.register(evaluator)
+			bugs := bugs()
+			bg := evaluator.Evaluate(bugs)
+
+			for key, want := range tt.want {
+				if got := bucketOf(bg, key); got != want {
+					t.Errorf("%s bucket = %q, want %q", key, got, want)
+				}
+			}
+
+			// Custom violations are recorded like configured ones, so Over By can show the age
+			v := bugs[0].Violation
+			if v == nil || v.RuleName != "unassigned security" || v.MaxAgeDays != 0 || v.OverageDays() < 0.9 {
+				t.Errorf("SEC-1 violation = %+v, want the custom rule with its age as overage", v)
+			}
+			if v := bugs[3].Violation; v == nil || v.RuleName != "high" || v.MaxAgeDays != 7 {
+				t.Errorf("OLD-1 violation = %+v, want the configured rule", v)
+			}
+		})
+	}
+}
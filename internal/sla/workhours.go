@@ -0,0 +1,76 @@
+package sla
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// LoadWorkingHours builds the working-hours clock from config, reading the holidays file if set
+func LoadWorkingHours(cfg config.WorkingHoursConfig) (*domain.WorkingHours, error) {
+	start, end, err := cfg.Window()
+	if err != nil {
+		return nil, err
+	}
+	weekdays, err := cfg.Weekdays()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	days := make(map[time.Weekday]bool)
+	for _, day := range weekdays {
+		days[day] = true
+	}
+
+	holidays := make(map[string]bool)
+	if cfg.HolidaysFile != "" {
+		if holidays, err = loadHolidays(cfg.HolidaysFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.WorkingHours{
+		StartMinute: start,
+		EndMinute:   end,
+		Days:        days,
+		Holidays:    holidays,
+		Location:    loc,
+	}, nil
+}
+
+// loadHolidays reads non-working dates from a file, one YYYY-MM-DD per line
+// Blank lines and lines starting with # are ignored
+func loadHolidays(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holidays file: %w", err)
+	}
+	defer f.Close()
+
+	holidays := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q on line %d of holidays file %s (expected YYYY-MM-DD)", line, lineNum, path)
+		}
+		holidays[date.Format("2006-01-02")] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read holidays file: %w", err)
+	}
+	return holidays, nil
+}
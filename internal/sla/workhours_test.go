@@ -0,0 +1,50 @@
+package sla
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/config"
+)
+
+func TestLoadWorkingHours(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := os.WriteFile(path, []byte("# Public holidays\n2025-01-01\n\n2025-12-25\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := LoadWorkingHours(config.WorkingHoursConfig{
+		Start:        "09:30",
+		End:          "17:00",
+		Timezone:     "UTC",
+		Days:         []string{"mon", "tue"},
+		HolidaysFile: path,
+	})
+	if err != nil {
+		t.Fatalf("LoadWorkingHours: %v", err)
+	}
+	if w.StartMinute != 9*60+30 || w.EndMinute != 17*60 {
+		t.Errorf("window = %d-%d, want %d-%d", w.StartMinute, w.EndMinute, 9*60+30, 17*60)
+	}
+	if !w.Days[time.Monday] || !w.Days[time.Tuesday] || w.Days[time.Wednesday] {
+		t.Errorf("Days = %v, want Monday and Tuesday", w.Days)
+	}
+	if len(w.Holidays) != 2 || !w.Holidays["2025-01-01"] || !w.Holidays["2025-12-25"] {
+		t.Errorf("Holidays = %v, want 2025-01-01 and 2025-12-25", w.Holidays)
+	}
+}
+
+func TestLoadWorkingHoursInvalidHoliday(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := os.WriteFile(path, []byte("2025-01-01\n25/12/2025\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadWorkingHours(config.WorkingHoursConfig{Start: "09:00", End: "17:00", HolidaysFile: path})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("LoadWorkingHours = %v, want an error naming line 2", err)
+	}
+}
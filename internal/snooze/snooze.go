@@ -0,0 +1,76 @@
+package snooze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// dateLayout is the format of snooze dates in the file and on the command line
+const dateLayout = "2006-01-02"
+
+// List maps bug keys to the date their snooze ends (exclusive: active until 00:00 UTC that day)
+type List map[string]time.Time
+
+// Load reads a snooze file; a missing file is an empty list
+func Load(path string) (List, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return List{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snooze file: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse snooze file %s: %w", path, err)
+	}
+
+	list := make(List, len(raw))
+	for key, value := range raw {
+		until, err := ParseDate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snooze date for %s in %s: %w", key, path, err)
+		}
+		list[key] = until
+	}
+	return list, nil
+}
+
+// Save writes the snooze list to a file, dropping snoozes that have already ended
+func (l List) Save(path string, now time.Time) error {
+	raw := make(map[string]string, len(l))
+	for key, until := range l {
+		if l.Active(key, now) {
+			raw[key] = until.Format(dateLayout)
+		}
+	}
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snooze file: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write snooze file: %w", err)
+	}
+	return nil
+}
+
+// Active reports whether the bug is snoozed at the given time
+func (l List) Active(key string, now time.Time) bool {
+	until, ok := l[key]
+	return ok && now.Before(until)
+}
+
+// ParseDate parses a YYYY-MM-DD snooze date as 00:00 UTC
+func ParseDate(value string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD, got %q", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,70 @@
+package snooze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestActiveExpiry(t *testing.T) {
+	until, err := ParseDate("2024-06-01")
+	if err != nil {
+		t.Fatalf("ParseDate: %v", err)
+	}
+	list := List{"DEMO-1": until}
+
+	tests := []struct {
+		key  string
+		now  time.Time
+		want bool
+	}{
+		{"DEMO-1", time.Date(2024, 5, 31, 23, 59, 0, 0, time.UTC), true},
+		{"DEMO-1", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"DEMO-1", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), false},
+		{"DEMO-2", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		if got := list.Active(tt.key, tt.now); got != tt.want {
+			t.Errorf("Active(%s, %s) = %v, want %v", tt.key, tt.now.Format(time.DateTime), got, tt.want)
+		}
+	}
+}
+
+func TestSaveDropsExpiredAndLoads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozes.json")
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	list := List{
+		"DEMO-1": time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"DEMO-2": time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	if err := list.Save(path, now); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded) != 1 || !loaded["DEMO-2"].Equal(list["DEMO-2"]) {
+		t.Errorf("loaded %v, want only DEMO-2 until 2024-07-01", loaded)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	list, err := Load(filepath.Join(dir, "missing.json"))
+	if err != nil || len(list) != 0 {
+		t.Errorf("Load(missing) = %v, %v, want an empty list", list, err)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"DEMO-1": "June 1st"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(bad); err == nil {
+		t.Error("Load with an invalid date = nil error, want an error")
+	}
+}
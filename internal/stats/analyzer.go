@@ -4,7 +4,9 @@ import (
 	"log/slog"
 	"math"
 	"regexp"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/neilmpatterson/bug-butler/internal/domain"
@@ -12,22 +14,172 @@ import (
 
 // Analyzer performs trend analysis on bug data
 type Analyzer struct {
-	reductionGoal   float64
-	monthsToAnalyze int
+	reductionGoal    float64
+	monthsToAnalyze  int
+	bugIssueTypes    []string
+	excludeFuture    bool                // Drop future-dated bugs from month grouping instead of counting them in the current month
+	sprintStates     []string            // Sprint states included in sprint stats (empty = all)
+	goalMode         string              // Goal comparison mode (see Goal* constants)
+	goalRounding     string              // Goal target rounding (see GoalRound* constants)
+	sprintMinIssues  int                 // Sprints with fewer total issues are excluded from sprint stats
+	sprintSortBy     string              // Sprint stats order (see SprintSort* constants)
+	minLifetime      time.Duration       // Bugs resolved sooner than this after creation are left out of trend stats
+	priorityGoals    map[string]float64  // Per-priority reduction goal percentages, tracked alongside the overall goal
+	resolvedStatuses []string            // Statuses counted as resolved in the backlog even without a resolution
+	priorityWeights  map[string]float64  // Weight of an unresolved bug by priority in the weighted backlog (default 1)
+	ignoredResolved  []string            // Resolutions that don't count as resolved (e.g., Cannot Reproduce)
+	zeroResolvedAvg  float64             // Trailing resolved average above which a zero-resolved month is flagged (0 = disabled)
+	baselineOffsets  []int               // Months between each goal baseline and the current period (12 = year over year)
+	defaultPoints    float64             // Story points assumed for sprint issues without an estimate (0 = none)
+	statusPhases     map[string][]string // Phase names mapped to the statuses they group (empty = no phase breakdown)
+	trimLeading      int                 // Earliest months dropped from the reported monthly data (0 = keep all)
 }
 
+// Goal comparison modes
+const (
+	GoalCalendarMonth  = "calendar_month"   // Current calendar month vs the same full month at the baseline
+	GoalTrailing30Days = "trailing_30_days" // Last 30 days vs the same 30-day window at the baseline
+	GoalProrated       = "prorated"         // Current month vs the baseline month prorated by the elapsed fraction
+)
+
+// Goal target rounding modes
+const (
+	GoalRoundNearest = "nearest" // Round half away from zero
+	GoalRoundFloor   = "floor"   // Always round down (stricter target)
+	GoalRoundCeil    = "ceil"    // Always round up (more forgiving target)
+)
+
+// Sprint stats sort orders
+const (
+	SprintSortName       = "name"        // Natural name order, so "Sprint 9" precedes "Sprint 10"
+	SprintSortBugPercent = "bug_percent" // Highest bug percentage first
+	SprintSortStartDate  = "start_date"  // Earliest start first, unstarted sprints last
+)
+
 // NewAnalyzer creates a new stats analyzer with configuration
 func NewAnalyzer(reductionGoal float64, months int) *Analyzer {
 	return &Analyzer{
 		reductionGoal:   reductionGoal,
 		monthsToAnalyze: months,
+		bugIssueTypes:   []string{"Bug"},
+		goalMode:        GoalCalendarMonth,
+		goalRounding:    GoalRoundNearest,
+		sprintSortBy:    SprintSortName,
+		baselineOffsets: []int{12},
+	}
+}
+
+// SetBugIssueTypes sets which issue types are classified as bugs in sprint stats
+func (a *Analyzer) SetBugIssueTypes(types []string) {
+	if len(types) > 0 {
+		a.bugIssueTypes = types
+	}
+}
+
+// SetExcludeFutureDated drops bugs created in the future (clock skew) from month grouping
+// By default they are counted in the current month
+func (a *Analyzer) SetExcludeFutureDated(exclude bool) {
+	a.excludeFuture = exclude
+}
+
+// SetSprintStates limits sprint stats to sprints in the given states (e.g., "closed"), case-insensitive
+func (a *Analyzer) SetSprintStates(states []string) {
+	a.sprintStates = states
+}
+
+// SetGoalComparisonMode sets how the current period is compared to its baselines for the reduction goal
+func (a *Analyzer) SetGoalComparisonMode(mode string) {
+	if mode != "" {
+		a.goalMode = mode
+	}
+}
+
+// SetGoalRounding sets how a fractional goal target is rounded to a bug count
+func (a *Analyzer) SetGoalRounding(mode string) {
+	if mode != "" {
+		a.goalRounding = mode
+	}
+}
+
+// SetSprintMinIssues excludes sprints with fewer than n total issues from sprint stats (0 disables)
+func (a *Analyzer) SetSprintMinIssues(n int) {
+	a.sprintMinIssues = n
+}
+
+// SetSprintSortBy sets the order of sprint stats (see SprintSort* constants)
+func (a *Analyzer) SetSprintSortBy(sortBy string) {
+	if sortBy != "" {
+		a.sprintSortBy = sortBy
+	}
+}
+
+// SetGoalBaselineOffsets compares the current period against each baseline this many months
+// earlier (e.g., 12 for year over year, 3 for quarter over quarter), one goal per offset
+func (a *Analyzer) SetGoalBaselineOffsets(months []int) {
+	if len(months) > 0 {
+		a.baselineOffsets = months
 	}
 }
 
+// SetDefaultStoryPoints counts sprint issues without story points as this estimate in the
+// sprint points totals (0 leaves them out of the points percentage)
+func (a *Analyzer) SetDefaultStoryPoints(points float64) {
+	a.defaultPoints = points
+}
+
+// SetTrimLeadingMonths drops the earliest N months from the reported monthly data, whose counts
+// lack the history before the fetch window (the latest month is always kept)
+func (a *Analyzer) SetTrimLeadingMonths(months int) {
+	a.trimLeading = months
+}
+
+// SetStatusPhases groups the current backlog into named phases of statuses (empty disables the breakdown)
+func (a *Analyzer) SetStatusPhases(phases map[string][]string) {
+	a.statusPhases = phases
+}
+
+// SetPriorityGoals tracks a separate reduction goal (percent) for each given priority
+func (a *Analyzer) SetPriorityGoals(goals map[string]float64) {
+	a.priorityGoals = goals
+}
+
+// SetMinLifetime leaves bugs resolved within d of creation (e.g., auto-closed monitoring alerts)
+// out of the trend stats (0 disables)
+func (a *Analyzer) SetMinLifetime(d time.Duration) {
+	a.minLifetime = d
+}
+
+// SetResolvedStatuses counts bugs in the given statuses (case-insensitive) as resolved in the backlog
+// trend even when they have no formal resolution, as of their last update
+func (a *Analyzer) SetResolvedStatuses(statuses []string) {
+	a.resolvedStatuses = statuses
+}
+
+// SetPriorityWeights sets how much an unresolved bug of each priority counts toward the weighted backlog
+func (a *Analyzer) SetPriorityWeights(weights map[string]float64) {
+	a.priorityWeights = weights
+}
+
+// SetIgnoredResolutions treats bugs with the given resolutions (case-insensitive) as unresolved,
+// keeping them in the backlog and out of resolved counts
+func (a *Analyzer) SetIgnoredResolutions(resolutions []string) {
+	a.ignoredResolved = resolutions
+}
+
+// SetZeroResolvedAlert flags completed months with zero resolved bugs when the preceding months
+// averaged at least minAverage resolved per month (0 disables)
+func (a *Analyzer) SetZeroResolvedAlert(minAverage float64) {
+	a.zeroResolvedAvg = minAverage
+}
+
 // Analyze processes bugs and returns trend statistics
 func (a *Analyzer) Analyze(bugs []*domain.Bug) (*domain.TrendStats, error) {
+	bugs = a.unresolveIgnored(bugs)
+	bugs = a.excludeShortLived(bugs)
+
 	// Group bugs by creation month
-	grouped := groupByMonth(bugs)
+	now := time.Now()
+	grouped := a.groupByMonth(bugs, now)
 
 	// Get list of months in chronological order
 	months := make([]time.Time, 0, len(grouped))
@@ -39,6 +191,7 @@ func (a *Analyzer) Analyze(bugs []*domain.Bug) (*domain.TrendStats, error) {
 	})
 
 	// Build monthly statistics
+	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 	monthlyData := make([]domain.MonthlyBugStats, 0, len(months))
 	var previousCreatedCount int
 
@@ -56,79 +209,349 @@ func (a *Analyzer) Analyze(bugs []*domain.Bug) (*domain.TrendStats, error) {
 		// Calculate total unresolved bugs at end of this month
 		// End of month is the last day of the month at 23:59:59
 		monthEnd := time.Date(month.Year(), month.Month()+1, 0, 23, 59, 59, 0, time.UTC)
-		unresolvedCount := countUnresolvedAtDate(bugs, monthEnd)
+		unresolvedCount := countUnresolvedAtDate(bugs, monthEnd, a.resolvedStatuses)
+		unresolvedWeighted := weighUnresolvedAtDate(bugs, monthEnd, a.resolvedStatuses, a.priorityWeights)
 
 		// Count bugs resolved in this month (for future tracking)
 		resolvedThisMonth := countResolvedInMonth(bugs, month)
 
 		monthlyData = append(monthlyData, domain.MonthlyBugStats{
-			Month:           month,
-			TotalCreated:    created,
-			TotalResolved:   resolvedThisMonth,
-			TotalUnresolved: unresolvedCount,
-			NetChange:       created - previousCreatedCount,
-			ChangePercent:   changePercent,
-			ByPriority:      priorityBreakdown,
+			Month:                   month,
+			TotalCreated:            created,
+			TotalResolved:           resolvedThisMonth,
+			TotalUnresolved:         unresolvedCount,
+			TotalUnresolvedWeighted: unresolvedWeighted,
+			NetChange:               created - previousCreatedCount,
+			ChangePercent:           changePercent,
+			ByPriority:              priorityBreakdown,
+			UnresolvedByPriority:    countUnresolvedByPriorityAtDate(bugs, monthEnd, a.resolvedStatuses),
+			Partial:                 month.Equal(currentMonthStart),
 		})
 
 		previousCreatedCount = created
 	}
 
-	// Identify current month and last year's same month
-	now := time.Now()
-	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-	lastYearMonthStart := currentMonthStart.AddDate(-1, 0, 0)
-
+	// Identify the current month and compare it against each goal baseline
 	var currentMonth *domain.MonthlyBugStats
-	var lastYearSameMonth *domain.MonthlyBugStats
-
 	for i := range monthlyData {
 		if monthlyData[i].Month.Equal(currentMonthStart) {
 			currentMonth = &monthlyData[i]
 		}
-		if monthlyData[i].Month.Equal(lastYearMonthStart) {
-			lastYearSameMonth = &monthlyData[i]
+	}
+
+	var goals []domain.GoalProgress
+	for _, offset := range a.baselineOffsets {
+		// Baselines are only fetched as far back as the analyzed window
+		if a.monthsToAnalyze > 0 && offset > a.monthsToAnalyze {
+			slog.Debug("Skipping goal baseline beyond the analyzed months", "offset_months", offset, "months_to_analyze", a.monthsToAnalyze)
+			continue
+		}
+		if goal, ok := a.goalProgress(bugs, now, monthlyData, currentMonth, offset); ok {
+			goals = append(goals, goal)
 		}
 	}
 
-	// Calculate goal target and progress
-	var goalTarget int
-	var onTrack bool
+	// Goal baselines above may reach before the reported window
+	monthlyData = a.reportedMonths(monthlyData, currentMonthStart)
 
-	if lastYearSameMonth != nil && currentMonth != nil {
-		goalTarget = calculateGoalTarget(lastYearSameMonth.TotalCreated, a.reductionGoal)
-		onTrack = currentMonth.TotalCreated <= goalTarget
+	// Weekday inflow covers the same months as the reported series
+	var createdByWeekday []domain.WeekdayCount
+	if len(monthlyData) > 0 {
+		createdByWeekday = CountCreatedByWeekday(bugs, monthlyData[0].Month)
 	}
 
 	return &domain.TrendStats{
 		MonthlyData:       monthlyData,
 		CurrentMonth:      currentMonth,
-		LastYearSameMonth: lastYearSameMonth,
 		ReductionGoal:     a.reductionGoal,
-		GoalTarget:        goalTarget,
-		OnTrack:           onTrack,
+		GoalMode:          a.goalMode,
+		Goals:             goals,
 		SprintStats:       []domain.SprintStats{}, // Will be populated separately if enabled
+		RollingCreated:    CountCreatedInWindows(bugs, now, rollingWindows),
+		ResolutionTimes:   CalculateResolutionByPriority(bugs),
+		Runway:            CalculateRunway(bugs, now, runwayWindowDays, a.resolvedStatuses),
+		ResolvedAnomalies: DetectResolvedAnomalies(monthlyData, resolvedAnomalyWindow, a.zeroResolvedAvg),
+		Phases:            a.backlogPhases(bugs, now),
+		CreatedByWeekday:  createdByWeekday,
 	}, nil
 }
 
+// reportedMonths keeps the months in the analyzed window (the last monthsToAnalyze months, the
+// current month included), then drops the trimmed leading months; the latest month is always kept
+func (a *Analyzer) reportedMonths(monthly []domain.MonthlyBugStats, currentMonthStart time.Time) []domain.MonthlyBugStats {
+	if a.monthsToAnalyze > 0 {
+		first := currentMonthStart.AddDate(0, -(a.monthsToAnalyze - 1), 0)
+		monthly = slices.DeleteFunc(monthly, func(m domain.MonthlyBugStats) bool { return m.Month.Before(first) })
+	}
+	if trim := min(a.trimLeading, len(monthly)-1); trim > 0 {
+		monthly = monthly[trim:]
+	}
+	return monthly
+}
+
+// FetchStart returns the earliest creation date to fetch for a window of the given months: the
+// month before the window gives the first month its change and the furthest goal baseline, and
+// one more month covers that baseline's trailing 30 days
+func FetchStart(now time.Time, months int) time.Time {
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return currentMonth.AddDate(0, -(months + 1), 0)
+}
+
+// knownPriorityOrder is the display order for standard Jira priorities
+var knownPriorityOrder = []string{"Highest", "Critical", "High", "Medium", "Low", "Lowest"}
+
+// priorityRank returns a sort rank for a priority (unknown priorities sort last)
+func priorityRank(priority string) int {
+	for i, p := range knownPriorityOrder {
+		if p == priority {
+			return i
+		}
+	}
+	return len(knownPriorityOrder)
+}
+
+// CalculateResolutionByPriority computes the mean created-to-resolved time for resolved bugs per priority
+func CalculateResolutionByPriority(bugs []*domain.Bug) []domain.PriorityResolution {
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+
+	for _, bug := range bugs {
+		if bug.ResolutionDate == nil {
+			continue
+		}
+		totals[bug.Priority] += bug.ResolutionDate.Sub(bug.Created).Hours() / 24
+		counts[bug.Priority]++
+	}
+
+	results := make([]domain.PriorityResolution, 0, len(counts))
+	for priority, count := range counts {
+		results = append(results, domain.PriorityResolution{
+			Priority: priority,
+			Count:    count,
+			MeanDays: totals[priority] / float64(count),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		ri, rj := priorityRank(results[i].Priority), priorityRank(results[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		return results[i].Priority < results[j].Priority
+	})
+
+	return results
+}
+
+// rollingWindows are the trailing day windows shown in the stats header
+var rollingWindows = []int{30, 60, 90}
+
+// CountCreatedInWindows counts bugs created within each trailing window of days before now
+func CountCreatedInWindows(bugs []*domain.Bug, now time.Time, windows []int) []domain.RollingCount {
+	counts := make([]domain.RollingCount, len(windows))
+	for i, days := range windows {
+		cutoff := now.AddDate(0, 0, -days)
+		count := 0
+		for _, bug := range bugs {
+			if bug.Created.After(cutoff) && !bug.Created.After(now) {
+				count++
+			}
+		}
+		counts[i] = domain.RollingCount{Days: days, Count: count}
+	}
+	return counts
+}
+
 // groupByMonth groups bugs by their creation month
-func groupByMonth(bugs []*domain.Bug) map[time.Time][]*domain.Bug {
+// Bugs created after now (clock skew) go into the current month, or are dropped if excludeFuture is set
+func (a *Analyzer) groupByMonth(bugs []*domain.Bug, now time.Time) map[time.Time][]*domain.Bug {
 	grouped := make(map[time.Time][]*domain.Bug)
+	futureCount := 0
 
 	for _, bug := range bugs {
+		created := bug.Created
+		if created.After(now) {
+			futureCount++
+			if a.excludeFuture {
+				continue
+			}
+			created = now
+		}
+
 		// Normalize to first day of month (UTC)
-		month := time.Date(bug.Created.Year(), bug.Created.Month(), 1, 0, 0, 0, 0, time.UTC)
+		month := time.Date(created.Year(), created.Month(), 1, 0, 0, 0, 0, time.UTC)
 		grouped[month] = append(grouped[month], bug)
 	}
 
+	if futureCount > 0 {
+		slog.Warn("Found bugs created in the future",
+			"count", futureCount,
+			"excluded", a.excludeFuture,
+		)
+	}
+
 	return grouped
 }
 
-// calculateGoalTarget calculates the target bug count based on last year and reduction percentage
-func calculateGoalTarget(lastYearCount int, reductionPercent float64) int {
-	reduction := float64(lastYearCount) * (reductionPercent / 100.0)
-	target := float64(lastYearCount) - reduction
-	return int(math.Round(target))
+// unresolveIgnored returns bugs with ignored resolutions replaced by unresolved copies
+func (a *Analyzer) unresolveIgnored(bugs []*domain.Bug) []*domain.Bug {
+	if len(a.ignoredResolved) == 0 {
+		return bugs
+	}
+
+	result := make([]*domain.Bug, len(bugs))
+	unresolved := 0
+	for i, bug := range bugs {
+		result[i] = bug
+		if bug.Resolution != "" && slices.ContainsFunc(a.ignoredResolved, func(r string) bool {
+			return strings.EqualFold(r, bug.Resolution)
+		}) {
+			copied := *bug
+			copied.Resolution = ""
+			copied.ResolutionDate = nil
+			result[i] = &copied
+			unresolved++
+		}
+	}
+
+	if unresolved > 0 {
+		slog.Debug("Counting bugs with ignored resolutions as unresolved", "count", unresolved, "resolutions", a.ignoredResolved)
+	}
+	return result
+}
+
+// excludeShortLived drops bugs resolved less than minLifetime after they were created
+func (a *Analyzer) excludeShortLived(bugs []*domain.Bug) []*domain.Bug {
+	if a.minLifetime <= 0 {
+		return bugs
+	}
+
+	kept := make([]*domain.Bug, 0, len(bugs))
+	for _, bug := range bugs {
+		if bug.ResolutionDate != nil && bug.ResolutionDate.Sub(bug.Created) < a.minLifetime {
+			continue
+		}
+		kept = append(kept, bug)
+	}
+
+	if excluded := len(bugs) - len(kept); excluded > 0 {
+		slog.Info("Excluding short-lived bugs from stats", "count", excluded, "min_lifetime", a.minLifetime)
+	}
+	return kept
+}
+
+// calculateGoalTarget calculates the target bug count from the baseline count and reduction percentage
+func calculateGoalTarget(baselineCount int, reductionPercent float64, rounding string) int {
+	reduction := float64(baselineCount) * (reductionPercent / 100.0)
+	target := float64(baselineCount) - reduction
+	switch rounding {
+	case GoalRoundFloor:
+		return int(math.Floor(target))
+	case GoalRoundCeil:
+		return int(math.Ceil(target))
+	default:
+		return int(math.Round(target))
+	}
+}
+
+// countCreatedBetween counts bugs created in the window (start, end]
+// An empty priority counts bugs of every priority
+func countCreatedBetween(bugs []*domain.Bug, start, end time.Time, priority string) int {
+	count := 0
+	for _, bug := range bugs {
+		if priority != "" && bug.Priority != priority {
+			continue
+		}
+		if bug.Created.After(start) && !bug.Created.After(end) {
+			count++
+		}
+	}
+	return count
+}
+
+// goalProgress compares the current period against the baseline offset months earlier,
+// overall and for each priority goal; ok is false without data for both months
+func (a *Analyzer) goalProgress(bugs []*domain.Bug, now time.Time, monthlyData []domain.MonthlyBugStats, currentMonth *domain.MonthlyBugStats, offset int) (goal domain.GoalProgress, ok bool) {
+	if currentMonth == nil {
+		return goal, false
+	}
+	baselineStart := currentMonth.Month.AddDate(0, -offset, 0)
+	var baselineMonth *domain.MonthlyBugStats
+	for i := range monthlyData {
+		if monthlyData[i].Month.Equal(baselineStart) {
+			baselineMonth = &monthlyData[i]
+		}
+	}
+	if baselineMonth == nil {
+		return goal, false
+	}
+
+	current, baseline := a.goalCounts(bugs, now, currentMonth, baselineMonth, offset, "")
+	target := calculateGoalTarget(baseline, a.reductionGoal, a.goalRounding)
+	goal = domain.GoalProgress{
+		OffsetMonths:  offset,
+		BaselineMonth: baselineMonth,
+		Baseline:      baseline,
+		Current:       current,
+		Target:        target,
+		OnTrack:       current <= target,
+	}
+
+	// Track per-priority goals with the same comparison mode
+	for priority, reduction := range a.priorityGoals {
+		pCurrent, pBaseline := a.goalCounts(bugs, now, currentMonth, baselineMonth, offset, priority)
+		pTarget := calculateGoalTarget(pBaseline, reduction, a.goalRounding)
+		goal.PriorityGoals = append(goal.PriorityGoals, domain.PriorityGoal{
+			Priority:      priority,
+			ReductionGoal: reduction,
+			Baseline:      pBaseline,
+			Current:       pCurrent,
+			Target:        pTarget,
+			OnTrack:       pCurrent <= pTarget,
+		})
+	}
+	sort.Slice(goal.PriorityGoals, func(i, j int) bool {
+		ri, rj := priorityRank(goal.PriorityGoals[i].Priority), priorityRank(goal.PriorityGoals[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		return goal.PriorityGoals[i].Priority < goal.PriorityGoals[j].Priority
+	})
+	return goal, true
+}
+
+// goalCounts returns this period's and the baseline period's created counts for the goal
+// comparison mode, the baseline being offset months earlier
+// An empty priority counts bugs of every priority
+func (a *Analyzer) goalCounts(bugs []*domain.Bug, now time.Time, currentMonth, baselineMonth *domain.MonthlyBugStats, offset int, priority string) (current, baseline int) {
+	monthCount := func(m *domain.MonthlyBugStats) int {
+		if priority == "" {
+			return m.TotalCreated
+		}
+		return m.ByPriority[priority]
+	}
+
+	switch a.goalMode {
+	case GoalTrailing30Days:
+		then := now.AddDate(0, -offset, 0)
+		current = countCreatedBetween(bugs, now.AddDate(0, 0, -30), now, priority)
+		baseline = countCreatedBetween(bugs, then.AddDate(0, 0, -30), then, priority)
+	case GoalProrated:
+		current = monthCount(currentMonth)
+		baseline = prorateMonthCount(monthCount(baselineMonth), now)
+	default:
+		current = monthCount(currentMonth)
+		baseline = monthCount(baselineMonth)
+	}
+	return current, baseline
+}
+
+// prorateMonthCount scales a full-month count by the fraction of now's month that has elapsed
+func prorateMonthCount(count int, now time.Time) int {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	fraction := float64(now.Sub(monthStart)) / float64(monthEnd.Sub(monthStart))
+	return int(math.Round(float64(count) * fraction))
 }
 
 // buildPriorityBreakdown creates a map of priority to count
@@ -142,24 +565,87 @@ func buildPriorityBreakdown(bugs []*domain.Bug) map[string]int {
 	return breakdown
 }
 
+// backlogPhases counts the bugs unresolved now per status phase (nil if no phases are configured)
+func (a *Analyzer) backlogPhases(bugs []*domain.Bug, now time.Time) []domain.PhaseCount {
+	if len(a.statusPhases) == 0 {
+		return nil
+	}
+
+	var backlog []*domain.Bug
+	for _, bug := range bugs {
+		if unresolvedAtDate(bug, now, a.resolvedStatuses) {
+			backlog = append(backlog, bug)
+		}
+	}
+	return CountByPhase(backlog, a.statusPhases)
+}
+
 // countUnresolvedAtDate counts bugs that were unresolved at a specific date
 // A bug is unresolved at date X if: created <= X AND (resolution is empty OR resolved > X)
-func countUnresolvedAtDate(bugs []*domain.Bug, date time.Time) int {
+// Bugs in resolvedStatuses without a resolution count as resolved from their last update
+func countUnresolvedAtDate(bugs []*domain.Bug, date time.Time, resolvedStatuses []string) int {
 	count := 0
 	for _, bug := range bugs {
-		// Bug must have been created before or at this date
-		if bug.Created.After(date) {
-			continue
-		}
-
-		// Bug is unresolved if it has no resolution or was resolved after this date
-		if bug.Resolution == "" || (bug.ResolutionDate != nil && bug.ResolutionDate.After(date)) {
+		if unresolvedAtDate(bug, date, resolvedStatuses) {
 			count++
 		}
 	}
 	return count
 }
 
+// countUnresolvedByPriorityAtDate counts bugs unresolved at a specific date per priority
+func countUnresolvedByPriorityAtDate(bugs []*domain.Bug, date time.Time, resolvedStatuses []string) map[string]int {
+	counts := make(map[string]int)
+	for _, bug := range bugs {
+		if unresolvedAtDate(bug, date, resolvedStatuses) {
+			counts[bug.Priority]++
+		}
+	}
+	return counts
+}
+
+// weighUnresolvedAtDate sums the priority weights of bugs unresolved at a specific date
+// Priorities missing from weights weigh 1, so no weights gives the plain count
+func weighUnresolvedAtDate(bugs []*domain.Bug, date time.Time, resolvedStatuses []string, weights map[string]float64) float64 {
+	total := 0.0
+	for _, bug := range bugs {
+		if !unresolvedAtDate(bug, date, resolvedStatuses) {
+			continue
+		}
+		if weight, ok := weights[bug.Priority]; ok {
+			total += weight
+		} else {
+			total++
+		}
+	}
+	return total
+}
+
+// unresolvedAtDate reports whether a bug was unresolved at a specific date
+func unresolvedAtDate(bug *domain.Bug, date time.Time, resolvedStatuses []string) bool {
+	// Bug must have been created before or at this date
+	if bug.Created.After(date) {
+		return false
+	}
+
+	// Bug is unresolved if it has no resolution or was resolved after this date
+	switch {
+	case bug.Resolution != "":
+		return bug.ResolutionDate != nil && bug.ResolutionDate.After(date)
+	case hasResolvedStatus(bug, resolvedStatuses):
+		return bug.Updated.After(date)
+	default:
+		return true
+	}
+}
+
+// hasResolvedStatus reports whether a bug's status is one of the statuses treated as resolved
+func hasResolvedStatus(bug *domain.Bug, resolvedStatuses []string) bool {
+	return slices.ContainsFunc(resolvedStatuses, func(s string) bool {
+		return strings.EqualFold(s, bug.Status)
+	})
+}
+
 // countResolvedInMonth counts bugs that were resolved in a specific month
 func countResolvedInMonth(bugs []*domain.Bug, month time.Time) int {
 	// Calculate month boundaries
@@ -320,6 +806,7 @@ func (a *Analyzer) CalculateSprintStats(sprintIssues []*domain.Bug, sprintNameBe
 	// Group issues by sprint
 	sprintGroups := make(map[string][]*domain.Bug)
 	sprintNames := make(map[string]string)
+	sprintStarts := make(map[string]time.Time)
 
 	for _, issue := range sprintIssues {
 		if issue.SprintID != "" {
@@ -332,8 +819,22 @@ func (a *Analyzer) CalculateSprintStats(sprintIssues []*domain.Bug, sprintNameBe
 				continue
 			}
 
+			// Apply sprint state filter if configured
+			if len(a.sprintStates) > 0 && !slices.ContainsFunc(a.sprintStates, func(s string) bool {
+				return strings.EqualFold(s, issue.SprintState)
+			}) {
+				slog.Debug("Excluding sprint due to state filter",
+					"sprint_name", issue.SprintName,
+					"sprint_state", issue.SprintState,
+				)
+				continue
+			}
+
 			sprintGroups[issue.SprintID] = append(sprintGroups[issue.SprintID], issue)
 			sprintNames[issue.SprintID] = issue.SprintName
+			if issue.SprintStart != nil {
+				sprintStarts[issue.SprintID] = *issue.SprintStart
+			}
 		}
 	}
 
@@ -343,20 +844,44 @@ func (a *Analyzer) CalculateSprintStats(sprintIssues []*domain.Bug, sprintNameBe
 	for sprintID, issues := range sprintGroups {
 		bugCount := 0
 		otherCount := 0
+		reopenedCount := 0
+		unestimatedCount := 0
 		bugStoryPoints := 0.0
 		totalStoryPoints := 0.0
 
+		start, started := sprintStarts[sprintID]
 		for _, issue := range issues {
-			if issue.IssueType == "Bug" {
+			// Issues without points add nothing to either total unless a default estimate is set
+			points := issue.StoryPoints
+			if points == 0 {
+				unestimatedCount++
+				points = a.defaultPoints
+			}
+
+			if issue.IsBugType(a.bugIssueTypes) {
 				bugCount++
-				bugStoryPoints += issue.StoryPoints
+				bugStoryPoints += points
+				if started && issue.ReopenedSince(start) {
+					reopenedCount++
+				}
 			} else {
 				otherCount++
 			}
-			totalStoryPoints += issue.StoryPoints
+			totalStoryPoints += points
 		}
 
 		totalCount := bugCount + otherCount
+
+		// Skip tiny sprints, whose bug percentages would skew the averages
+		if totalCount < a.sprintMinIssues {
+			slog.Info("Excluding sprint below minimum size",
+				"sprint_name", sprintNames[sprintID],
+				"issues", totalCount,
+				"min_issues", a.sprintMinIssues,
+			)
+			continue
+		}
+
 		bugPercentage := 0.0
 		pointsPercentage := 0.0
 
@@ -371,6 +896,7 @@ func (a *Analyzer) CalculateSprintStats(sprintIssues []*domain.Bug, sprintNameBe
 		stats = append(stats, domain.SprintStats{
 			SprintID:         sprintID,
 			SprintName:       sprintNames[sprintID],
+			StartDate:        sprintStarts[sprintID],
 			BugCount:         bugCount,
 			OtherCount:       otherCount,
 			TotalCount:       totalCount,
@@ -378,13 +904,67 @@ func (a *Analyzer) CalculateSprintStats(sprintIssues []*domain.Bug, sprintNameBe
 			BugStoryPoints:   bugStoryPoints,
 			TotalStoryPoints: totalStoryPoints,
 			PointsPercentage: pointsPercentage,
+			ReopenedInSprint: reopenedCount,
+			UnestimatedCount: unestimatedCount,
 		})
 	}
 
-	// Sort by sprint name for consistent display
-	sort.Slice(stats, func(i, j int) bool {
-		return stats[i].SprintName < stats[j].SprintName
-	})
+	sortSprintStats(stats, a.sprintSortBy)
 
 	return stats
 }
+
+// sortSprintStats orders sprint stats by the given sort order, falling back to natural name order
+func sortSprintStats(stats []domain.SprintStats, sortBy string) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		switch sortBy {
+		case SprintSortBugPercent:
+			if a.BugPercentage != b.BugPercentage {
+				return a.BugPercentage > b.BugPercentage
+			}
+		case SprintSortStartDate:
+			if !a.StartDate.Equal(b.StartDate) {
+				// Unstarted sprints (zero start date) sort last
+				if a.StartDate.IsZero() || b.StartDate.IsZero() {
+					return b.StartDate.IsZero()
+				}
+				return a.StartDate.Before(b.StartDate)
+			}
+		}
+		return naturalLess(a.SprintName, b.SprintName)
+	})
+}
+
+// naturalLess compares strings treating runs of digits as numbers, so "Sprint 9" < "Sprint 10"
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aNum, bNum := leadingDigits(a), leadingDigits(b)
+		if aNum != "" && bNum != "" {
+			// Compare numerically: ignore leading zeros, then longer means larger
+			aTrim, bTrim := strings.TrimLeft(aNum, "0"), strings.TrimLeft(bNum, "0")
+			if len(aTrim) != len(bTrim) {
+				return len(aTrim) < len(bTrim)
+			}
+			if aTrim != bTrim {
+				return aTrim < bTrim
+			}
+			a, b = a[len(aNum):], b[len(bNum):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingDigits returns the run of ASCII digits at the start of s
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
@@ -0,0 +1,748 @@
+package stats
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestCountUnresolvedAtDateResolvedStatuses(t *testing.T) {
+	created := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	doneAt := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)
+	resolvedAt := time.Date(2025, 2, 12, 9, 0, 0, 0, time.UTC)
+
+	bugs := []*domain.Bug{
+		{Key: "OPEN-1", Status: "In Progress", Created: created, Updated: doneAt},
+		{Key: "DONE-1", Status: "Done", Created: created, Updated: doneAt},
+		{Key: "FIXED-1", Status: "Closed", Created: created, Updated: resolvedAt, Resolution: "Fixed", ResolutionDate: &resolvedAt},
+	}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		statuses []string
+		want     int
+	}{
+		{"status-only done counts as open without config", doneAt.AddDate(0, 0, 5), nil, 2},
+		{"status-only done leaves the backlog", doneAt.AddDate(0, 0, 5), []string{"Done"}, 1},
+		{"status match is case-insensitive", doneAt.AddDate(0, 0, 5), []string{"done"}, 1},
+		{"still open before its last update", doneAt.AddDate(0, 0, -1), []string{"Done"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countUnresolvedAtDate(bugs, tt.date, tt.statuses); got != tt.want {
+				t.Errorf("countUnresolvedAtDate = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateSprintStatsBugIssueTypes(t *testing.T) {
+	issues := []*domain.Bug{
+		{Key: "S-1", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1"},
+		{Key: "S-2", IssueType: "Defect", SprintID: "1", SprintName: "Sprint 1"},
+		{Key: "S-3", IssueType: "defect", SprintID: "1", SprintName: "Sprint 1"},
+		{Key: "S-4", IssueType: "Story", SprintID: "1", SprintName: "Sprint 1"},
+	}
+
+	tests := []struct {
+		name      string
+		types     []string
+		wantBugs  int
+		wantOther int
+	}{
+		{"defaults to Bug only", nil, 1, 3},
+		{"Bug and Defect", []string{"Bug", "Defect"}, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer(10, 12)
+			a.SetBugIssueTypes(tt.types)
+
+			stats := a.CalculateSprintStats(issues, "", "")
+			if len(stats) != 1 {
+				t.Fatalf("got %d sprints, want 1", len(stats))
+			}
+			if stats[0].BugCount != tt.wantBugs || stats[0].OtherCount != tt.wantOther {
+				t.Errorf("BugCount, OtherCount = %d, %d, want %d, %d",
+					stats[0].BugCount, stats[0].OtherCount, tt.wantBugs, tt.wantOther)
+			}
+		})
+	}
+}
+
+func TestCalculateSprintStatsSprintStates(t *testing.T) {
+	issues := []*domain.Bug{
+		{Key: "S-1", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", SprintState: "closed"},
+		{Key: "S-2", IssueType: "Story", SprintID: "2", SprintName: "Sprint 2", SprintState: "closed"},
+		{Key: "S-3", IssueType: "Bug", SprintID: "3", SprintName: "Sprint 3", SprintState: "active"},
+	}
+
+	tests := []struct {
+		name   string
+		states []string
+		want   []string
+	}{
+		{"all states by default", nil, []string{"Sprint 1", "Sprint 2", "Sprint 3"}},
+		{"closed only", []string{"CLOSED"}, []string{"Sprint 1", "Sprint 2"}},
+		{"active only", []string{"active"}, []string{"Sprint 3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer(10, 12)
+			a.SetSprintStates(tt.states)
+
+			var names []string
+			for _, s := range a.CalculateSprintStats(issues, "", "") {
+				names = append(names, s.SprintName)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("sprints = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountCreatedInWindows(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysBefore := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	bugs := []*domain.Bug{
+		{Key: "B-1", Created: daysBefore(5)},
+		{Key: "B-2", Created: daysBefore(29)},
+		{Key: "B-3", Created: daysBefore(45)},
+		{Key: "B-4", Created: daysBefore(75)},
+		{Key: "B-5", Created: daysBefore(89)},
+		{Key: "B-6", Created: daysBefore(120)},
+		{Key: "FUTURE-1", Created: now.Add(time.Hour)},
+	}
+
+	got := CountCreatedInWindows(bugs, now, []int{30, 60, 90})
+	want := []domain.RollingCount{{Days: 30, Count: 2}, {Days: 60, Count: 3}, {Days: 90, Count: 5}}
+	if !slices.Equal(got, want) {
+		t.Errorf("CountCreatedInWindows = %v, want %v", got, want)
+	}
+}
+
+func TestCalculateResolutionByPriority(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolvedAfter := func(days int) *time.Time {
+		t := created.AddDate(0, 0, days)
+		return &t
+	}
+
+	bugs := []*domain.Bug{
+		{Key: "L-1", Priority: "Low", Created: created, ResolutionDate: resolvedAfter(30)},
+		{Key: "C-1", Priority: "Critical", Created: created, ResolutionDate: resolvedAfter(1)},
+		{Key: "C-2", Priority: "Critical", Created: created, ResolutionDate: resolvedAfter(3)},
+		{Key: "H-1", Priority: "High", Created: created, ResolutionDate: resolvedAfter(4)},
+		{Key: "H-2", Priority: "High", Created: created, ResolutionDate: resolvedAfter(6)},
+		{Key: "H-3", Priority: "High", Created: created, ResolutionDate: resolvedAfter(11)},
+		{Key: "H-OPEN", Priority: "High", Created: created},
+		{Key: "X-1", Priority: "Sev-X", Created: created, ResolutionDate: resolvedAfter(2)},
+	}
+
+	got := CalculateResolutionByPriority(bugs)
+	want := []domain.PriorityResolution{
+		{Priority: "Critical", Count: 2, MeanDays: 2},
+		{Priority: "High", Count: 3, MeanDays: 7},
+		{Priority: "Low", Count: 1, MeanDays: 30},
+		{Priority: "Sev-X", Count: 1, MeanDays: 2},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("CalculateResolutionByPriority = %+v, want %+v", got, want)
+	}
+}
+
+func TestGroupByMonthFutureDated(t *testing.T) {
+	now := time.Date(2025, 3, 31, 22, 0, 0, 0, time.UTC)
+	bugs := []*domain.Bug{
+		{Key: "PAST-1", Created: time.Date(2025, 2, 14, 9, 0, 0, 0, time.UTC)},
+		{Key: "SKEW-1", Created: time.Date(2025, 4, 1, 3, 0, 0, 0, time.UTC)},
+	}
+	february := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	a := NewAnalyzer(0, 12)
+	grouped := a.groupByMonth(bugs, now)
+	if len(grouped) != 2 || len(grouped[february]) != 1 || len(grouped[march]) != 1 {
+		t.Errorf("groupByMonth = %v, want PAST-1 in February and SKEW-1 in the current month", grouped)
+	}
+
+	a.SetExcludeFutureDated(true)
+	grouped = a.groupByMonth(bugs, now)
+	if len(grouped) != 1 || len(grouped[february]) != 1 {
+		t.Errorf("groupByMonth excluding future bugs = %v, want only PAST-1", grouped)
+	}
+}
+
+func TestProrateMonthCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		now   time.Time
+		want  int
+	}{
+		{"start of month", 30, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"half of a 30-day month", 30, time.Date(2025, 4, 16, 0, 0, 0, 0, time.UTC), 15},
+		{"a third of a 30-day month", 31, time.Date(2025, 4, 11, 0, 0, 0, 0, time.UTC), 10},
+		{"a quarter of February", 40, time.Date(2025, 2, 8, 0, 0, 0, 0, time.UTC), 10},
+		{"end of month", 31, time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC), 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prorateMonthCount(tt.count, tt.now); got != tt.want {
+				t.Errorf("prorateMonthCount(%d, %s) = %d, want %d", tt.count, tt.now.Format(time.DateTime), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateSprintStatsMinIssues(t *testing.T) {
+	var issues []*domain.Bug
+	addSprint := func(id string, bugs, other int) {
+		for i := range bugs + other {
+			issueType := "Story"
+			if i < bugs {
+				issueType = "Bug"
+			}
+			issues = append(issues, &domain.Bug{IssueType: issueType, SprintID: id, SprintName: "Sprint " + id})
+		}
+	}
+	addSprint("1", 2, 8) // 20% bugs
+	addSprint("2", 3, 7) // 30% bugs
+	addSprint("3", 2, 0) // 100% bugs, too small to count
+
+	a := NewAnalyzer(10, 12)
+	a.SetSprintMinIssues(5)
+	sprints := a.CalculateSprintStats(issues, "", "")
+
+	var names []string
+	bugs, total := 0, 0
+	for _, s := range sprints {
+		names = append(names, s.SprintName)
+		bugs += s.BugCount
+		total += s.TotalCount
+	}
+	slices.Sort(names)
+	if want := []string{"Sprint 1", "Sprint 2"}; !slices.Equal(names, want) {
+		t.Fatalf("sprints = %v, want %v", names, want)
+	}
+	// The summary average covers only the remaining sprints: 5 of 20 issues
+	if bugs != 5 || total != 20 {
+		t.Errorf("bugs, total = %d, %d, want 5, 20", bugs, total)
+	}
+}
+
+func TestCalculateGoalTargetRounding(t *testing.T) {
+	tests := []struct {
+		rounding string
+		want     int
+	}{
+		{GoalRoundNearest, 11},
+		{"", 11},
+		{GoalRoundFloor, 10},
+		{GoalRoundCeil, 11},
+	}
+
+	// 15 bugs reduced by 30% is a target of 10.5
+	for _, tt := range tests {
+		if got := calculateGoalTarget(15, 30, tt.rounding); got != tt.want {
+			t.Errorf("calculateGoalTarget(15, 30, %q) = %d, want %d", tt.rounding, got, tt.want)
+		}
+	}
+
+	// A whole target is the same in every mode
+	for _, rounding := range []string{GoalRoundNearest, GoalRoundFloor, GoalRoundCeil} {
+		if got := calculateGoalTarget(20, 50, rounding); got != 10 {
+			t.Errorf("calculateGoalTarget(20, 50, %q) = %d, want 10", rounding, got)
+		}
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Sprint 9", "Sprint 10", true},
+		{"Sprint 10", "Sprint 9", false},
+		{"Sprint 2", "Sprint 2", false},
+		{"Sprint 02", "Sprint 3", true},
+		{"Sprint 10a", "Sprint 10b", true},
+		{"Sprint 10", "Sprint 10.1", true},
+		{"Alpha 99", "Beta 1", true},
+	}
+
+	for _, tt := range tests {
+		if got := naturalLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("naturalLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSortSprintStats(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	sprints := []domain.SprintStats{
+		{SprintName: "Sprint 10", BugPercentage: 10, StartDate: day(20)},
+		{SprintName: "Sprint 9", BugPercentage: 40, StartDate: day(6)},
+		{SprintName: "Sprint 11", BugPercentage: 40},
+		{SprintName: "Sprint 1", BugPercentage: 5, StartDate: day(1)},
+	}
+
+	tests := []struct {
+		sortBy string
+		want   []string
+	}{
+		{SprintSortName, []string{"Sprint 1", "Sprint 9", "Sprint 10", "Sprint 11"}},
+		{SprintSortBugPercent, []string{"Sprint 9", "Sprint 11", "Sprint 10", "Sprint 1"}},
+		{SprintSortStartDate, []string{"Sprint 1", "Sprint 9", "Sprint 10", "Sprint 11"}},
+	}
+
+	for _, tt := range tests {
+		sorted := slices.Clone(sprints)
+		sortSprintStats(sorted, tt.sortBy)
+
+		var names []string
+		for _, s := range sorted {
+			names = append(names, s.SprintName)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("sort by %s = %v, want %v", tt.sortBy, names, tt.want)
+		}
+	}
+}
+
+func TestExcludeShortLived(t *testing.T) {
+	created := time.Date(2025, 5, 5, 9, 0, 0, 0, time.UTC)
+	resolvedAfter := func(d time.Duration) *time.Time {
+		resolved := created.Add(d)
+		return &resolved
+	}
+	bugs := []*domain.Bug{
+		{Key: "DUP-1", Created: created, ResolutionDate: resolvedAfter(2 * time.Minute)},
+		{Key: "EDGE-1", Created: created, ResolutionDate: resolvedAfter(10 * time.Minute)},
+		{Key: "REAL-1", Created: created, ResolutionDate: resolvedAfter(3 * time.Hour)},
+		{Key: "OPEN-1", Created: created},
+	}
+
+	a := NewAnalyzer(10, 12)
+	if got := a.excludeShortLived(bugs); len(got) != len(bugs) {
+		t.Errorf("with no minimum lifetime kept %d bugs, want all %d", len(got), len(bugs))
+	}
+
+	a.SetMinLifetime(10 * time.Minute)
+	var keys []string
+	for _, bug := range a.excludeShortLived(bugs) {
+		keys = append(keys, bug.Key)
+	}
+	if want := []string{"EDGE-1", "REAL-1", "OPEN-1"}; !slices.Equal(keys, want) {
+		t.Errorf("kept %v, want %v", keys, want)
+	}
+}
+
+func TestGoalProgressPerPriority(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	monthly := []domain.MonthlyBugStats{
+		{Month: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), TotalCreated: 30, ByPriority: map[string]int{"Critical": 10, "High": 8, "Low": 12}},
+		{Month: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), TotalCreated: 24, ByPriority: map[string]int{"Critical": 6, "High": 6, "Low": 12}},
+	}
+
+	a := NewAnalyzer(20, 24)
+	a.SetPriorityGoals(map[string]float64{"Low": 0, "Critical": 50, "High": 25})
+
+	goal, ok := a.goalProgress(nil, now, monthly, &monthly[1], 12)
+	if !ok {
+		t.Fatal("goalProgress found no baseline month")
+	}
+	if goal.Baseline != 30 || goal.Current != 24 || goal.Target != 24 || !goal.OnTrack {
+		t.Errorf("overall goal = %+v, want baseline 30, current 24, target 24, on track", goal)
+	}
+
+	// Priority goals are ordered by priority rank
+	want := []domain.PriorityGoal{
+		{Priority: "Critical", ReductionGoal: 50, Baseline: 10, Current: 6, Target: 5, OnTrack: false},
+		{Priority: "High", ReductionGoal: 25, Baseline: 8, Current: 6, Target: 6, OnTrack: true},
+		{Priority: "Low", ReductionGoal: 0, Baseline: 12, Current: 12, Target: 12, OnTrack: true},
+	}
+	if !slices.Equal(goal.PriorityGoals, want) {
+		t.Errorf("priority goals = %+v, want %+v", goal.PriorityGoals, want)
+	}
+}
+
+func TestAnalyzeMarksCurrentMonthPartial(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	bugs := []*domain.Bug{
+		{Key: "OLD-1", Priority: "High", Created: thisMonth.AddDate(0, -2, 3)},
+		{Key: "NEW-1", Priority: "High", Created: thisMonth},
+	}
+
+	trend, err := NewAnalyzer(10, 6).Analyze(bugs)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if len(trend.MonthlyData) == 0 {
+		t.Fatal("Analyze returned no months")
+	}
+	partial := 0
+	for _, m := range trend.MonthlyData {
+		if want := m.Month.Equal(thisMonth); m.Partial != want {
+			t.Errorf("%s Partial = %v, want %v", m.Month.Format("2006-01"), m.Partial, want)
+		}
+		if m.Partial {
+			partial++
+		}
+	}
+	if partial != 1 {
+		t.Errorf("got %d partial months, want the current month only", partial)
+	}
+}
+
+func TestAnalyzeTrimLeadingMonths(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", Priority: "High", Created: thisMonth.AddDate(0, -2, 3)},
+		{Key: "DEMO-2", Priority: "High", Created: thisMonth.AddDate(0, -1, 3)},
+		{Key: "DEMO-3", Priority: "High", Created: thisMonth},
+	}
+
+	tests := []struct {
+		trim int
+		want []time.Time
+	}{
+		{0, []time.Time{thisMonth.AddDate(0, -2, 0), thisMonth.AddDate(0, -1, 0), thisMonth}},
+		{1, []time.Time{thisMonth.AddDate(0, -1, 0), thisMonth}},
+		// Trimming never drops the latest month
+		{5, []time.Time{thisMonth}},
+	}
+	for _, tt := range tests {
+		analyzer := NewAnalyzer(10, 6)
+		analyzer.SetTrimLeadingMonths(tt.trim)
+		trend, err := analyzer.Analyze(bugs)
+		if err != nil {
+			t.Fatalf("Analyze: %v", err)
+		}
+
+		var months []time.Time
+		for _, m := range trend.MonthlyData {
+			months = append(months, m.Month)
+		}
+		if !slices.EqualFunc(months, tt.want, time.Time.Equal) {
+			t.Errorf("trim %d months = %v, want %v", tt.trim, months, tt.want)
+		}
+		// The backlog still counts bugs created in trimmed months
+		if last := trend.MonthlyData[len(trend.MonthlyData)-1]; last.TotalUnresolved != 3 {
+			t.Errorf("trim %d latest unresolved = %d, want 3", tt.trim, last.TotalUnresolved)
+		}
+	}
+}
+
+func TestAnalyzeMonthsToAnalyze(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var bugs []*domain.Bug
+	for back := 8; back >= 0; back-- {
+		bugs = append(bugs, &domain.Bug{Key: fmt.Sprintf("DEMO-%d", back), Priority: "High", Created: thisMonth.AddDate(0, -back, 1)})
+	}
+
+	trend, err := NewAnalyzer(10, 3).Analyze(bugs)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	// Only the last 3 months are reported, though the backlog counts every fetched bug
+	want := []time.Time{thisMonth.AddDate(0, -2, 0), thisMonth.AddDate(0, -1, 0), thisMonth}
+	var months []time.Time
+	for _, m := range trend.MonthlyData {
+		months = append(months, m.Month)
+	}
+	if !slices.EqualFunc(months, want, time.Time.Equal) {
+		t.Errorf("months = %v, want %v", months, want)
+	}
+	if first := trend.MonthlyData[0]; first.TotalUnresolved != 7 || first.NetChange != 0 {
+		t.Errorf("first month unresolved = %d, net change = %d, want 7 and 0 against the month before", first.TotalUnresolved, first.NetChange)
+	}
+}
+
+func TestFetchStart(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	// Six months end in June; the fetch adds December and November for baselines
+	if got, want := FetchStart(now, 6), time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("FetchStart = %v, want %v", got, want)
+	}
+}
+
+func TestAnalyzeTrimWithinWindow(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var bugs []*domain.Bug
+	for back := 8; back >= 0; back-- {
+		bugs = append(bugs, &domain.Bug{Key: fmt.Sprintf("DEMO-%d", back), Priority: "High", Created: thisMonth.AddDate(0, -back, 1)})
+	}
+
+	// Trimming drops the earliest months of the 4-month window, not of everything fetched
+	analyzer := NewAnalyzer(10, 4)
+	analyzer.SetTrimLeadingMonths(1)
+	trend, err := analyzer.Analyze(bugs)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	want := []time.Time{thisMonth.AddDate(0, -2, 0), thisMonth.AddDate(0, -1, 0), thisMonth}
+	var months []time.Time
+	for _, m := range trend.MonthlyData {
+		months = append(months, m.Month)
+	}
+	if !slices.EqualFunc(months, want, time.Time.Equal) {
+		t.Errorf("months = %v, want %v", months, want)
+	}
+}
+
+func TestWeighUnresolvedAtDate(t *testing.T) {
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	created := date.AddDate(0, -2, 0)
+	resolved := date.AddDate(0, -1, 0)
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", Priority: "Critical", Created: created},
+		{Key: "DEMO-2", Priority: "High", Created: created},
+		{Key: "DEMO-3", Priority: "Low", Created: created},
+		{Key: "DEMO-4", Priority: "Critical", Created: created, Resolution: "Fixed", ResolutionDate: &resolved},
+		{Key: "DEMO-5", Priority: "Critical", Created: date.AddDate(0, 0, 1)},
+	}
+	weights := map[string]float64{"Critical": 4, "High": 2, "Medium": 1.5}
+
+	// Unweighted priorities (Low) count 1; resolved and not-yet-created bugs count nothing
+	if got := weighUnresolvedAtDate(bugs, date, nil, weights); got != 7 {
+		t.Errorf("weighUnresolvedAtDate = %v, want 7", got)
+	}
+	if got := weighUnresolvedAtDate(bugs, date, nil, nil); got != 3 {
+		t.Errorf("weighUnresolvedAtDate without weights = %v, want the plain count 3", got)
+	}
+}
+
+func TestCountUnresolvedByPriorityAtDate(t *testing.T) {
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	created := date.AddDate(0, -2, 0)
+	resolved := date.AddDate(0, -1, 0)
+	later := date.AddDate(0, 0, 5)
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", Priority: "Critical", Created: created},
+		{Key: "DEMO-2", Priority: "Critical", Created: created, Resolution: "Fixed", ResolutionDate: &later},
+		{Key: "DEMO-3", Priority: "High", Created: created},
+		{Key: "DEMO-4", Priority: "High", Created: created, Resolution: "Fixed", ResolutionDate: &resolved},
+		{Key: "DEMO-5", Priority: "Low", Created: date.AddDate(0, 0, 1)},
+		{Key: "DEMO-6", Created: created},
+	}
+
+	// DEMO-2 was still open on the date; DEMO-4 was already resolved and DEMO-5 not yet created
+	want := map[string]int{"Critical": 2, "High": 1, "": 1}
+	if got := countUnresolvedByPriorityAtDate(bugs, date, nil); !maps.Equal(got, want) {
+		t.Errorf("countUnresolvedByPriorityAtDate = %v, want %v", got, want)
+	}
+
+	// The per-priority counts always add up to the overall backlog
+	total := 0
+	for _, count := range want {
+		total += count
+	}
+	if got := countUnresolvedAtDate(bugs, date, nil); got != total {
+		t.Errorf("countUnresolvedAtDate = %d, want the per-priority total %d", got, total)
+	}
+}
+
+func TestCalculateSprintStatsReopenedInSprint(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+	after := start.Add(48 * time.Hour)
+
+	issues := []*domain.Bug{
+		{Key: "S-1", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", SprintStart: &start, ReopenedAt: []time.Time{before}},
+		{Key: "S-2", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", SprintStart: &start, ReopenedAt: []time.Time{after}},
+		{Key: "S-3", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", SprintStart: &start, ReopenedAt: []time.Time{start}},
+		{Key: "S-4", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", SprintStart: &start, ReopenedAt: []time.Time{before, after}},
+		{Key: "S-5", IssueType: "Story", SprintID: "1", SprintName: "Sprint 1", SprintStart: &start, ReopenedAt: []time.Time{after}},
+		{Key: "S-6", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", SprintStart: &start},
+		// Without a known start nothing can count as reopened mid-sprint
+		{Key: "S-7", IssueType: "Bug", SprintID: "2", SprintName: "Sprint 2", ReopenedAt: []time.Time{after}},
+	}
+
+	reopened := make(map[string]int)
+	for _, s := range NewAnalyzer(10, 12).CalculateSprintStats(issues, "", "") {
+		reopened[s.SprintName] = s.ReopenedInSprint
+	}
+	if reopened["Sprint 1"] != 3 {
+		t.Errorf("Sprint 1 ReopenedInSprint = %d, want 3 (S-2, S-3, S-4)", reopened["Sprint 1"])
+	}
+	if reopened["Sprint 2"] != 0 {
+		t.Errorf("Sprint 2 ReopenedInSprint = %d, want 0", reopened["Sprint 2"])
+	}
+}
+
+func TestAnalyzeIgnoredResolutionsStayInBacklog(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	created := thisMonth.AddDate(0, -3, 2)
+	resolved := thisMonth.AddDate(0, -2, 5)
+	cannotRepro := &domain.Bug{Key: "DEMO-1", Priority: "High", Created: created, Resolution: "Cannot Reproduce", ResolutionDate: &resolved}
+	fixed := &domain.Bug{Key: "DEMO-2", Priority: "High", Created: created, Resolution: "Fixed", ResolutionDate: &resolved}
+	// Open bugs created in the resolution month and this month, so both months are analyzed
+	bugs := []*domain.Bug{
+		cannotRepro,
+		fixed,
+		{Key: "DEMO-3", Priority: "High", Created: resolved},
+		{Key: "DEMO-4", Priority: "High", Created: thisMonth},
+	}
+
+	tests := []struct {
+		name         string
+		ignored      []string
+		wantBacklog  int
+		wantResolved int
+	}{
+		{"both resolved by default", nil, 2, 2},
+		{"ignored resolution stays in the backlog", []string{"cannot reproduce"}, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnalyzer(10, 6)
+			a.SetIgnoredResolutions(tt.ignored)
+			trend, err := a.Analyze(bugs)
+			if err != nil {
+				t.Fatalf("Analyze: %v", err)
+			}
+
+			resolvedTotal := 0
+			for _, m := range trend.MonthlyData {
+				resolvedTotal += m.TotalResolved
+			}
+			if resolvedTotal != tt.wantResolved {
+				t.Errorf("resolved across months = %d, want %d", resolvedTotal, tt.wantResolved)
+			}
+			latest := trend.MonthlyData[len(trend.MonthlyData)-1]
+			if latest.TotalUnresolved != tt.wantBacklog {
+				t.Errorf("latest backlog = %d, want %d", latest.TotalUnresolved, tt.wantBacklog)
+			}
+		})
+	}
+
+	// The caller's bugs keep their resolution
+	if cannotRepro.Resolution != "Cannot Reproduce" || cannotRepro.ResolutionDate == nil {
+		t.Errorf("Analyze modified the input bug: %+v", cannotRepro)
+	}
+}
+
+func TestGoalProgressBaselineOffsets(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	monthly := []domain.MonthlyBugStats{
+		{Month: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), TotalCreated: 40},
+		{Month: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), TotalCreated: 20},
+		{Month: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), TotalCreated: 18},
+	}
+	current := &monthly[2]
+
+	tests := []struct {
+		name         string
+		offset       int
+		wantBaseline time.Time
+		wantCounts   [3]int // Baseline, target, current
+		wantOnTrack  bool
+	}{
+		{"year over year", 12, monthly[0].Month, [3]int{40, 32, 18}, true},
+		{"quarter over quarter", 3, monthly[1].Month, [3]int{20, 16, 18}, false},
+	}
+	for _, tt := range tests {
+		goal, ok := NewAnalyzer(20, 24).goalProgress(nil, now, monthly, current, tt.offset)
+		if !ok {
+			t.Fatalf("%s: goalProgress found no baseline month", tt.name)
+		}
+		if goal.OffsetMonths != tt.offset || !goal.BaselineMonth.Month.Equal(tt.wantBaseline) {
+			t.Errorf("%s: baseline = %d months back at %v, want %d months back at %v",
+				tt.name, goal.OffsetMonths, goal.BaselineMonth.Month, tt.offset, tt.wantBaseline)
+		}
+		if got := [3]int{goal.Baseline, goal.Target, goal.Current}; got != tt.wantCounts || goal.OnTrack != tt.wantOnTrack {
+			t.Errorf("%s: baseline, target, current = %v (on track %v), want %v (on track %v)",
+				tt.name, got, goal.OnTrack, tt.wantCounts, tt.wantOnTrack)
+		}
+	}
+
+	// Without data that far back there's no goal for the offset
+	if _, ok := NewAnalyzer(20, 24).goalProgress(nil, now, monthly, current, 6); ok {
+		t.Error("goalProgress with no month 6 months back = ok, want no goal")
+	}
+}
+
+func TestAnalyzeSkipsBaselinesBeyondWindow(t *testing.T) {
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	var bugs []*domain.Bug
+	for back := 13; back >= 0; back-- {
+		bugs = append(bugs, &domain.Bug{Key: fmt.Sprintf("DEMO-%d", back), Priority: "High", Created: thisMonth.AddDate(0, -back, 1)})
+	}
+
+	analyzer := NewAnalyzer(10, 6)
+	analyzer.SetGoalBaselineOffsets([]int{12, 6, 3})
+	trend, err := analyzer.Analyze(bugs)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	// The 12-month baseline has data here, but a 6-month window is never fetched that far back
+	var offsets []int
+	for _, goal := range trend.Goals {
+		offsets = append(offsets, goal.OffsetMonths)
+	}
+	if want := []int{6, 3}; !slices.Equal(offsets, want) {
+		t.Errorf("goal offsets = %v, want %v", offsets, want)
+	}
+}
+
+func TestCalculateSprintStatsUnestimatedIssues(t *testing.T) {
+	issues := []*domain.Bug{
+		{Key: "S-1", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1", StoryPoints: 2},
+		{Key: "S-2", IssueType: "Bug", SprintID: "1", SprintName: "Sprint 1"},
+		{Key: "S-3", IssueType: "Story", SprintID: "1", SprintName: "Sprint 1", StoryPoints: 5},
+		{Key: "S-4", IssueType: "Story", SprintID: "1", SprintName: "Sprint 1"},
+		{Key: "S-5", IssueType: "Story", SprintID: "1", SprintName: "Sprint 1"},
+	}
+
+	tests := []struct {
+		name               string
+		defaultPoints      float64
+		wantBug, wantTotal float64
+		wantPercent        float64
+	}{
+		// Unestimated issues add nothing, so the percentage covers estimated issues only
+		{"excluded without a default", 0, 2, 7, 2.0 / 7 * 100},
+		{"counted at the default estimate", 3, 5, 16, 5.0 / 16 * 100},
+	}
+	for _, tt := range tests {
+		a := NewAnalyzer(10, 12)
+		a.SetDefaultStoryPoints(tt.defaultPoints)
+		stats := a.CalculateSprintStats(issues, "", "")
+		if len(stats) != 1 {
+			t.Fatalf("%s: got %d sprints, want 1", tt.name, len(stats))
+		}
+		s := stats[0]
+		if s.BugStoryPoints != tt.wantBug || s.TotalStoryPoints != tt.wantTotal {
+			t.Errorf("%s: points = %v of %v, want %v of %v", tt.name, s.BugStoryPoints, s.TotalStoryPoints, tt.wantBug, tt.wantTotal)
+		}
+		if math.Abs(s.PointsPercentage-tt.wantPercent) > 1e-9 {
+			t.Errorf("%s: PointsPercentage = %v, want %v", tt.name, s.PointsPercentage, tt.wantPercent)
+		}
+		if s.UnestimatedCount != 3 {
+			t.Errorf("%s: UnestimatedCount = %d, want 3", tt.name, s.UnestimatedCount)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// resolvedAnomalyWindow is how many preceding months a zero-resolved month is compared against
+const resolvedAnomalyWindow = 3
+
+// DetectResolvedAnomalies flags completed months with zero resolved bugs whose preceding months
+// averaged at least minAverage resolved per month, which usually points to a data or workflow
+// problem rather than a real stall. The in-progress month is skipped; minAverage <= 0 disables
+func DetectResolvedAnomalies(monthly []domain.MonthlyBugStats, window int, minAverage float64) []domain.ResolvedAnomaly {
+	if minAverage <= 0 || window < 1 {
+		return nil
+	}
+
+	var anomalies []domain.ResolvedAnomaly
+	for i := window; i < len(monthly); i++ {
+		if monthly[i].TotalResolved != 0 || monthly[i].Partial {
+			continue
+		}
+
+		total := 0
+		for _, m := range monthly[i-window : i] {
+			total += m.TotalResolved
+		}
+		average := float64(total) / float64(window)
+		if average >= minAverage {
+			anomalies = append(anomalies, domain.ResolvedAnomaly{
+				Month:           monthly[i].Month,
+				TrailingAverage: average,
+				WindowMonths:    window,
+			})
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestDetectResolvedAnomalies(t *testing.T) {
+	// monthsResolving builds consecutive months from January 2025 with the given resolved counts
+	monthsResolving := func(resolved ...int) []domain.MonthlyBugStats {
+		monthly := make([]domain.MonthlyBugStats, len(resolved))
+		for i, n := range resolved {
+			monthly[i] = domain.MonthlyBugStats{Month: time.Date(2025, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC), TotalResolved: n}
+		}
+		return monthly
+	}
+	april := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	partial := monthsResolving(10, 12, 8, 0)
+	partial[3].Partial = true
+
+	tests := []struct {
+		name       string
+		monthly    []domain.MonthlyBugStats
+		minAverage float64
+		want       []domain.ResolvedAnomaly
+	}{
+		{
+			"zero after a busy history",
+			monthsResolving(10, 12, 8, 0, 9),
+			5,
+			[]domain.ResolvedAnomaly{{Month: april, TrailingAverage: 10, WindowMonths: 3}},
+		},
+		{"zero after a quiet history", monthsResolving(1, 0, 2, 0), 5, nil},
+		{"sensitivity raised above the average", monthsResolving(10, 12, 8, 0), 11, nil},
+		{"in-progress month skipped", partial, 5, nil},
+		{"zero within the first window", monthsResolving(10, 0, 8, 9), 5, nil},
+		{"disabled", monthsResolving(10, 12, 8, 0), 0, nil},
+	}
+	for _, tt := range tests {
+		if got := DetectResolvedAnomalies(tt.monthly, resolvedAnomalyWindow, tt.minAverage); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: DetectResolvedAnomalies = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
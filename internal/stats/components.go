@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// ComponentGroup is the bugs filed against a single component
+type ComponentGroup struct {
+	Component string        // Component name (empty for bugs without a component)
+	Bugs      []*domain.Bug // Bugs with the component; a bug with several components is in each group
+}
+
+// GroupByComponent groups bugs by component for per-component trends, most bugs first;
+// bugs without a component are grouped under an empty Component, last
+func GroupByComponent(bugs []*domain.Bug) []ComponentGroup {
+	index := make(map[string]int)
+	var groups []ComponentGroup
+	add := func(component string, bug *domain.Bug) {
+		i, ok := index[component]
+		if !ok {
+			i = len(groups)
+			index[component] = i
+			groups = append(groups, ComponentGroup{Component: component})
+		}
+		groups[i].Bugs = append(groups[i].Bugs, bug)
+	}
+	for _, bug := range bugs {
+		if len(bug.Components) == 0 {
+			add("", bug)
+		}
+		for _, component := range bug.Components {
+			add(component, bug)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Component == "") != (groups[j].Component == "") {
+			return groups[j].Component == ""
+		}
+		if len(groups[i].Bugs) != len(groups[j].Bugs) {
+			return len(groups[i].Bugs) > len(groups[j].Bugs)
+		}
+		return groups[i].Component < groups[j].Component
+	})
+	return groups
+}
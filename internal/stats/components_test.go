@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestGroupByComponent(t *testing.T) {
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", Components: []string{"Checkout"}},
+		{Key: "DEMO-2", Components: []string{"Search", "Checkout"}},
+		{Key: "DEMO-3"},
+		{Key: "DEMO-4", Components: []string{"Search"}},
+		{Key: "DEMO-5", Components: []string{"Accounts"}},
+		{Key: "DEMO-6"},
+		{Key: "DEMO-7"},
+	}
+
+	type group struct {
+		component string
+		keys      []string
+	}
+	var got []group
+	for _, g := range GroupByComponent(bugs) {
+		var keys []string
+		for _, bug := range g.Bugs {
+			keys = append(keys, bug.Key)
+		}
+		got = append(got, group{g.Component, keys})
+	}
+
+	// A bug with two components counts in both; ties go alphabetically and no component goes last
+	want := []group{
+		{"Checkout", []string{"DEMO-1", "DEMO-2"}},
+		{"Search", []string{"DEMO-2", "DEMO-4"}},
+		{"Accounts", []string{"DEMO-5"}},
+		{"", []string{"DEMO-3", "DEMO-6", "DEMO-7"}},
+	}
+	if !slices.EqualFunc(got, want, func(a, b group) bool {
+		return a.component == b.component && slices.Equal(a.keys, b.keys)
+	}) {
+		t.Errorf("GroupByComponent = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,18 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// CreatedAfter returns the bugs created strictly after t (e.g., since the last deploy)
+func CreatedAfter(bugs []*domain.Bug, t time.Time) []*domain.Bug {
+	var result []*domain.Bug
+	for _, bug := range bugs {
+		if bug.Created.After(t) {
+			result = append(result, bug)
+		}
+	}
+	return result
+}
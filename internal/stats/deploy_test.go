@@ -0,0 +1,29 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestCreatedAfter(t *testing.T) {
+	deployed := time.Date(2025, 10, 1, 14, 0, 0, 0, time.UTC)
+	bugs := []*domain.Bug{
+		{Key: "A-1", Created: deployed.Add(-time.Second)},
+		{Key: "A-2", Created: deployed},
+		{Key: "A-3", Created: deployed.Add(time.Second)},
+		// Same instant as the deploy, reported in another offset
+		{Key: "A-4", Created: deployed.In(time.FixedZone("PDT", -7*60*60))},
+		{Key: "A-5", Created: deployed.Add(48 * time.Hour)},
+	}
+
+	var keys []string
+	for _, bug := range CreatedAfter(bugs, deployed) {
+		keys = append(keys, bug.Key)
+	}
+	if want := []string{"A-3", "A-5"}; !slices.Equal(keys, want) {
+		t.Errorf("CreatedAfter = %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,146 @@
+package stats
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// summaryStopWords are ignored when comparing summaries
+var summaryStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "in": true, "on": true,
+	"of": true, "to": true, "for": true, "is": true, "when": true, "with": true,
+	"not": true, "at": true, "from": true, "by": true, "or": true,
+}
+
+// summaryTokens normalizes a summary into a set of lowercase word tokens
+func summaryTokens(summary string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(summary), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make(map[string]bool, len(words))
+	for _, word := range words {
+		if len(word) < 2 || summaryStopWords[word] {
+			continue
+		}
+		tokens[word] = true
+	}
+	return tokens
+}
+
+// NormalizeSummary returns a canonical form of a summary (sorted normalized tokens)
+// Summaries differing only in case, punctuation, word order, or stop words normalize the same
+func NormalizeSummary(summary string) string {
+	tokens := summaryTokens(summary)
+	words := make([]string, 0, len(tokens))
+	for token := range tokens {
+		words = append(words, token)
+	}
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// GroupBySummary groups bugs with the same normalized summary, in order of first appearance
+// Bugs whose summaries normalize to nothing are never grouped
+func GroupBySummary(bugs []*domain.Bug) [][]*domain.Bug {
+	var groups [][]*domain.Bug
+	index := make(map[string]int)
+
+	for _, bug := range bugs {
+		key := NormalizeSummary(bug.Summary)
+		if i, ok := index[key]; ok && key != "" {
+			groups[i] = append(groups[i], bug)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, []*domain.Bug{bug})
+	}
+
+	return groups
+}
+
+// SummarySimilarity returns the Jaccard similarity (0-1) of two summaries' normalized tokens
+func SummarySimilarity(a, b string) float64 {
+	return jaccard(summaryTokens(a), summaryTokens(b))
+}
+
+// jaccard computes |A ∩ B| / |A ∪ B| for two token sets
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for token := range a {
+		if b[token] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// FindDuplicates clusters bugs whose summaries are at least threshold similar
+// Similarity is transitive within a cluster (A~B and B~C puts A, B, C together)
+// Only clusters with two or more bugs are returned, largest first
+func FindDuplicates(bugs []*domain.Bug, threshold float64) [][]*domain.Bug {
+	tokens := make([]map[string]bool, len(bugs))
+	for i, bug := range bugs {
+		tokens[i] = summaryTokens(bug.Summary)
+	}
+
+	// Union-find over bug indexes
+	parent := make([]int, len(bugs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := 0; i < len(bugs); i++ {
+		for j := i + 1; j < len(bugs); j++ {
+			if jaccard(tokens[i], tokens[j]) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	// Collect clusters in original bug order
+	groups := make(map[int][]*domain.Bug)
+	var roots []int
+	for i, bug := range bugs {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], bug)
+	}
+
+	clusters := make([][]*domain.Bug, 0)
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			clusters = append(clusters, groups[root])
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i]) > len(clusters[j])
+	})
+
+	slog.Debug("Duplicate detection complete",
+		"bug_count", len(bugs),
+		"threshold", threshold,
+		"clusters", len(clusters),
+	)
+
+	return clusters
+}
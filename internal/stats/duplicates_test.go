@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestSummarySimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"Login fails on Safari", "login FAILS on safari!", 1},
+		{"Login fails on Safari", "Safari: login fails", 1},
+		{"Login fails on Safari", "Login fails on Firefox", 2.0 / 4},
+		{"Login fails", "Export is slow", 0},
+		{"", "Login fails", 0},
+	}
+
+	for _, tt := range tests {
+		if got := SummarySimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("SummarySimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	bugs := []*domain.Bug{
+		{Key: "D-1", Summary: "Checkout page crashes on submit"},
+		{Key: "D-2", Summary: "Export to CSV is slow"},
+		{Key: "D-3", Summary: "Checkout page crashes when submitting"},
+		{Key: "D-4", Summary: "checkout page crashes on submit!"},
+		{Key: "D-5", Summary: "CSV export slow"},
+		{Key: "D-6", Summary: "Dark mode colors wrong"},
+	}
+
+	clusters := FindDuplicates(bugs, 0.6)
+
+	var got [][]string
+	for _, cluster := range clusters {
+		var keys []string
+		for _, bug := range cluster {
+			keys = append(keys, bug.Key)
+		}
+		got = append(got, keys)
+	}
+
+	want := [][]string{{"D-1", "D-3", "D-4"}, {"D-2", "D-5"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("FindDuplicates clusters = %v, want %v", got, want)
+	}
+
+	if clusters := FindDuplicates(bugs, 1.01); len(clusters) != 0 {
+		t.Errorf("got %d clusters above perfect similarity, want none", len(clusters))
+	}
+}
+
+func TestGroupBySummary(t *testing.T) {
+	bugs := []*domain.Bug{
+		{Key: "WEB-1", Summary: "Login fails on Safari"},
+		{Key: "API-7", Summary: "Export times out"},
+		{Key: "MOB-3", Summary: "login FAILS - safari"},
+		{Key: "WEB-9", Summary: "???"},
+		{Key: "API-8", Summary: "!!!"},
+		{Key: "WEB-4", Summary: "Safari: login fails"},
+	}
+
+	var got [][]string
+	for _, group := range GroupBySummary(bugs) {
+		var keys []string
+		for _, bug := range group {
+			keys = append(keys, bug.Key)
+		}
+		got = append(got, keys)
+	}
+
+	// Groups keep first-appearance order, and empty normalized summaries stay separate
+	want := [][]string{{"WEB-1", "MOB-3", "WEB-4"}, {"API-7"}, {"WEB-9"}, {"API-8"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBySummary = %v, want %v", got, want)
+	}
+}
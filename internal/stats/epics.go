@@ -0,0 +1,36 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// GroupByEpic counts bugs per linked epic, most bugs first; bugs without an epic
+// are counted under an empty EpicKey
+func GroupByEpic(bugs []*domain.Bug) []domain.EpicRollup {
+	index := make(map[string]int)
+	var rollups []domain.EpicRollup
+	for _, bug := range bugs {
+		i, ok := index[bug.EpicKey]
+		if !ok {
+			i = len(rollups)
+			index[bug.EpicKey] = i
+			rollups = append(rollups, domain.EpicRollup{EpicKey: bug.EpicKey, ByPriority: make(map[string]int)})
+		}
+		rollups[i].Count++
+		rollups[i].ByPriority[bug.Priority]++
+	}
+
+	sort.SliceStable(rollups, func(i, j int) bool {
+		// Unlinked bugs go last regardless of count
+		if (rollups[i].EpicKey == "") != (rollups[j].EpicKey == "") {
+			return rollups[j].EpicKey == ""
+		}
+		if rollups[i].Count != rollups[j].Count {
+			return rollups[i].Count > rollups[j].Count
+		}
+		return rollups[i].EpicKey < rollups[j].EpicKey
+	})
+	return rollups
+}
@@ -0,0 +1,35 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestGroupByEpic(t *testing.T) {
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", EpicKey: "DEMO-100", Priority: "High"},
+		{Key: "DEMO-2", Priority: "High"},
+		{Key: "DEMO-3", Priority: "Low"},
+		{Key: "DEMO-4", Priority: "Low"},
+		{Key: "DEMO-5", EpicKey: "DEMO-200", Priority: "High"},
+		{Key: "DEMO-6", EpicKey: "DEMO-200", Priority: "Low"},
+		{Key: "DEMO-7", EpicKey: "DEMO-300", Priority: "High"},
+	}
+
+	got := GroupByEpic(bugs)
+	want := []domain.EpicRollup{
+		{EpicKey: "DEMO-200", Count: 2, ByPriority: map[string]int{"High": 1, "Low": 1}},
+		{EpicKey: "DEMO-100", Count: 1, ByPriority: map[string]int{"High": 1}},
+		{EpicKey: "DEMO-300", Count: 1, ByPriority: map[string]int{"High": 1}},
+		{EpicKey: "", Count: 3, ByPriority: map[string]int{"High": 1, "Low": 2}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupByEpic = %+v, want %+v", got, want)
+	}
+
+	if got := GroupByEpic(nil); len(got) != 0 {
+		t.Errorf("GroupByEpic(nil) = %+v, want none", got)
+	}
+}
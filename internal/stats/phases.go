@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// OtherPhase is the phase of bugs whose status isn't mapped to any configured phase
+const OtherPhase = "Other"
+
+// PhaseOf returns the phase a status is mapped to (case-insensitive), or OtherPhase if none
+func PhaseOf(status string, phases map[string][]string) string {
+	for phase, statuses := range phases {
+		if slices.ContainsFunc(statuses, func(s string) bool { return strings.EqualFold(s, status) }) {
+			return phase
+		}
+	}
+	return OtherPhase
+}
+
+// CountByPhase counts bugs per status phase: every configured phase in name order (0 when empty),
+// then OtherPhase for bugs in unmapped statuses if there are any
+func CountByPhase(bugs []*domain.Bug, phases map[string][]string) []domain.PhaseCount {
+	counts := make(map[string]int)
+	for _, bug := range bugs {
+		counts[PhaseOf(bug.Status, phases)]++
+	}
+
+	names := make([]string, 0, len(phases))
+	for phase := range phases {
+		names = append(names, phase)
+	}
+	sort.Strings(names)
+	if _, ok := phases[OtherPhase]; !ok && counts[OtherPhase] > 0 {
+		names = append(names, OtherPhase)
+	}
+
+	result := make([]domain.PhaseCount, len(names))
+	for i, phase := range names {
+		result[i] = domain.PhaseCount{Phase: phase, Bugs: counts[phase]}
+	}
+	return result
+}
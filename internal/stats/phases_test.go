@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+var testPhases = map[string][]string{
+	"Triage": {"Needs Triage", "New"},
+	"Active": {"In Progress", "In Review"},
+}
+
+func TestPhaseOf(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"New", "Triage"},
+		{"in progress", "Active"},
+		{"IN REVIEW", "Active"},
+		{"Blocked", OtherPhase},
+		{"", OtherPhase},
+	}
+
+	for _, tt := range tests {
+		if got := PhaseOf(tt.status, testPhases); got != tt.want {
+			t.Errorf("PhaseOf(%q) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestCountByPhase(t *testing.T) {
+	bugs := []*domain.Bug{
+		{Key: "A-1", Status: "In Progress"},
+		{Key: "A-2", Status: "in review"},
+		{Key: "A-3", Status: "Blocked"},
+		{Key: "A-4", Status: "Waiting"},
+	}
+
+	// Configured phases come in name order, zero counts included, with Other last
+	want := []domain.PhaseCount{
+		{Phase: "Active", Bugs: 2},
+		{Phase: "Triage", Bugs: 0},
+		{Phase: OtherPhase, Bugs: 2},
+	}
+	if got := CountByPhase(bugs, testPhases); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountByPhase = %+v, want %+v", got, want)
+	}
+
+	// Other is left out when every status is mapped
+	want = []domain.PhaseCount{
+		{Phase: "Active", Bugs: 1},
+		{Phase: "Triage", Bugs: 0},
+	}
+	if got := CountByPhase(bugs[:1], testPhases); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountByPhase without unmapped statuses = %+v, want %+v", got, want)
+	}
+}
+
+func TestAnalyzeBacklogPhases(t *testing.T) {
+	created := time.Now().AddDate(0, -1, 0)
+	resolved := time.Now().AddDate(0, 0, -1)
+	bugs := []*domain.Bug{
+		{Key: "A-1", Status: "New", Created: created, Updated: created},
+		{Key: "A-2", Status: "In Progress", Created: created, Updated: created},
+		{Key: "A-3", Status: "Closed", Created: created, Updated: resolved, Resolution: "Fixed", ResolutionDate: &resolved},
+	}
+
+	analyzer := NewAnalyzer(10, 12)
+	analyzer.SetStatusPhases(testPhases)
+	trend, err := analyzer.Analyze(bugs)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	// Only the unresolved bugs make up the backlog
+	want := []domain.PhaseCount{
+		{Phase: "Active", Bugs: 1},
+		{Phase: "Triage", Bugs: 1},
+	}
+	if !reflect.DeepEqual(trend.Phases, want) {
+		t.Errorf("Phases = %+v, want %+v", trend.Phases, want)
+	}
+}
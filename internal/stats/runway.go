@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// runwayWindowDays is the trailing window the net backlog velocity is measured over
+const runwayWindowDays = 90
+
+// CalculateRunway estimates how many days until the backlog doubles (if growing) or halves
+// (if shrinking) at the net created-minus-resolved rate of the trailing window
+// Returns nil when there is no unresolved backlog to project from
+func CalculateRunway(bugs []*domain.Bug, now time.Time, windowDays int, resolvedStatuses []string) *domain.BacklogRunway {
+	unresolved := countUnresolvedAtDate(bugs, now, resolvedStatuses)
+	if unresolved == 0 {
+		return nil
+	}
+
+	cutoff := now.AddDate(0, 0, -windowDays)
+	net := 0
+	for _, bug := range bugs {
+		if bug.Created.After(cutoff) && !bug.Created.After(now) {
+			net++
+		}
+		if bug.ResolutionDate != nil && bug.ResolutionDate.After(cutoff) && !bug.ResolutionDate.After(now) {
+			net--
+		}
+	}
+
+	runway := &domain.BacklogRunway{
+		WindowDays: windowDays,
+		Unresolved: unresolved,
+		NetPerDay:  float64(net) / float64(windowDays),
+		Days:       math.Inf(1),
+	}
+
+	// Doubling adds another full backlog; halving removes half of it
+	switch {
+	case net > 0:
+		runway.Days = float64(unresolved) / runway.NetPerDay
+	case net < 0:
+		runway.Days = float64(unresolved) / 2 / -runway.NetPerDay
+	}
+	return runway
+}
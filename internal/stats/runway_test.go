@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestCalculateRunway(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysBefore := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	resolvedBefore := func(days int) *time.Time {
+		resolved := daysBefore(days)
+		return &resolved
+	}
+
+	// backlog returns n bugs created long before the window, the first resolved of them resolved within it
+	backlog := func(n, resolved int) []*domain.Bug {
+		bugs := make([]*domain.Bug, n)
+		for i := range bugs {
+			bugs[i] = &domain.Bug{Created: daysBefore(200)}
+			if i < resolved {
+				bugs[i].Resolution = "Fixed"
+				bugs[i].ResolutionDate = resolvedBefore(5)
+			}
+		}
+		return bugs
+	}
+
+	t.Run("growing", func(t *testing.T) {
+		bugs := backlog(10, 0)
+		for range 6 {
+			bugs = append(bugs, &domain.Bug{Created: daysBefore(10)})
+		}
+
+		// 16 unresolved, growing by 6 per 30 days: doubles in 16 / 0.2 = 80 days
+		runway := CalculateRunway(bugs, now, 30, nil)
+		if runway == nil || !runway.Growing() || runway.Unresolved != 16 || math.Abs(runway.Days-80) > 1e-9 {
+			t.Errorf("runway = %+v, want growing with 16 unresolved, doubling in 80 days", runway)
+		}
+	})
+
+	t.Run("shrinking", func(t *testing.T) {
+		// 14 unresolved, shrinking by 6 per 30 days: halves in 7 / 0.2 = 35 days
+		runway := CalculateRunway(backlog(20, 6), now, 30, nil)
+		if runway == nil || runway.Growing() || runway.Stable() || runway.Unresolved != 14 || math.Abs(runway.Days-35) > 1e-9 {
+			t.Errorf("runway = %+v, want shrinking with 14 unresolved, halving in 35 days", runway)
+		}
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		runway := CalculateRunway(backlog(5, 0), now, 30, nil)
+		if runway == nil || !runway.Stable() || !math.IsInf(runway.Days, 1) {
+			t.Errorf("runway = %+v, want stable with infinite runway", runway)
+		}
+	})
+
+	t.Run("no backlog", func(t *testing.T) {
+		if runway := CalculateRunway(backlog(3, 3), now, 30, nil); runway != nil {
+			t.Errorf("runway = %+v, want nil without unresolved bugs", runway)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// FindThrashing returns unresolved bugs with at least minComments comments, most discussed first
+// Lots of discussion without a resolution often means a bug is stuck or being bounced around
+func FindThrashing(bugs []*domain.Bug, minComments int) []*domain.Bug {
+	if minComments <= 0 {
+		return nil
+	}
+
+	var result []*domain.Bug
+	for _, bug := range bugs {
+		if bug.Resolution == "" && bug.CommentCount >= minComments {
+			result = append(result, bug)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CommentCount != result[j].CommentCount {
+			return result[i].CommentCount > result[j].CommentCount
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+// WeekStart returns the Monday 00:00 UTC starting the week containing t
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+// GroupResolvedByWeek counts bugs resolved in [start, end) per week with a priority breakdown
+// Every week overlapping the range is included, even without resolutions, in chronological order
+func GroupResolvedByWeek(bugs []*domain.Bug, start, end time.Time) []domain.WeeklyResolvedStats {
+	var weeks []domain.WeeklyResolvedStats
+	index := make(map[time.Time]int)
+	for week := WeekStart(start); week.Before(end); week = week.AddDate(0, 0, 7) {
+		index[week] = len(weeks)
+		weeks = append(weeks, domain.WeeklyResolvedStats{
+			WeekStart:  week,
+			ByPriority: make(map[string]int),
+		})
+	}
+
+	for _, bug := range bugs {
+		if bug.ResolutionDate == nil || bug.ResolutionDate.Before(start) || !bug.ResolutionDate.Before(end) {
+			continue
+		}
+
+		i, ok := index[WeekStart(*bug.ResolutionDate)]
+		if !ok {
+			continue
+		}
+		weeks[i].Resolved++
+		weeks[i].ByPriority[bug.Priority]++
+	}
+
+	return weeks
+}
+
+// CountCreatedByWeekday counts bugs created at or after since per day of the week, Monday first
+// The weekday is taken in each bug's own timestamp offset (as returned by Jira)
+func CountCreatedByWeekday(bugs []*domain.Bug, since time.Time) []domain.WeekdayCount {
+	counts := make([]domain.WeekdayCount, 7)
+	for i := range counts {
+		counts[i].Weekday = time.Weekday((i + 1) % 7)
+	}
+	for _, bug := range bugs {
+		if bug.Created.Before(since) {
+			continue
+		}
+		counts[(int(bug.Created.Weekday())+6)%7].Created++
+	}
+	return counts
+}
@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neilmpatterson/bug-butler/internal/domain"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	tests := []time.Time{
+		monday,
+		time.Date(2025, 3, 5, 15, 30, 0, 0, time.UTC),
+		time.Date(2025, 3, 9, 23, 59, 0, 0, time.UTC),
+		// Monday morning in Tokyo is still Sunday in UTC
+		time.Date(2025, 3, 10, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+	}
+
+	for _, ts := range tests {
+		if got := WeekStart(ts); !got.Equal(monday) {
+			t.Errorf("WeekStart(%s) = %s, want %s", ts, got, monday)
+		}
+	}
+}
+
+func TestGroupResolvedByWeek(t *testing.T) {
+	at := func(month time.Month, day, hour int) *time.Time {
+		ts := time.Date(2025, month, day, hour, 0, 0, 0, time.UTC)
+		return &ts
+	}
+	bugs := []*domain.Bug{
+		{Key: "A-1", Priority: "High", ResolutionDate: at(3, 3, 9)},
+		{Key: "A-2", Priority: "Low", ResolutionDate: at(3, 9, 23)},
+		{Key: "A-3", Priority: "High", ResolutionDate: at(3, 5, 12)},
+		{Key: "A-4", Priority: "High", ResolutionDate: at(3, 19, 10)},
+		{Key: "A-5", Priority: "High", ResolutionDate: at(2, 28, 10)}, // Before the range
+		{Key: "A-6", Priority: "High", ResolutionDate: at(3, 24, 0)},  // At the exclusive end
+		{Key: "A-7", Priority: "High"},                                // Unresolved
+	}
+
+	weeks := GroupResolvedByWeek(bugs, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 24, 0, 0, 0, 0, time.UTC))
+
+	want := []domain.WeeklyResolvedStats{
+		{WeekStart: time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC), Resolved: 0, ByPriority: map[string]int{}},
+		{WeekStart: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), Resolved: 3, ByPriority: map[string]int{"High": 2, "Low": 1}},
+		{WeekStart: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), Resolved: 0, ByPriority: map[string]int{}},
+		{WeekStart: time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC), Resolved: 1, ByPriority: map[string]int{"High": 1}},
+	}
+	if len(weeks) != len(want) {
+		t.Fatalf("got %d weeks, want %d", len(weeks), len(want))
+	}
+	for i, w := range want {
+		got := weeks[i]
+		if !got.WeekStart.Equal(w.WeekStart) || got.Resolved != w.Resolved || !maps.Equal(got.ByPriority, w.ByPriority) {
+			t.Errorf("week %d = %s: %d %v, want %s: %d %v", i,
+				got.WeekStart.Format(time.DateOnly), got.Resolved, got.ByPriority,
+				w.WeekStart.Format(time.DateOnly), w.Resolved, w.ByPriority)
+		}
+	}
+}
+
+func TestCountCreatedByWeekday(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	// A bug filed late Sunday evening in New York is already Monday in UTC
+	newYork := time.FixedZone("EST", -5*60*60)
+	bugs := []*domain.Bug{
+		{Key: "DEMO-1", Created: time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)},   // Monday
+		{Key: "DEMO-2", Created: time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)}, // Monday
+		{Key: "DEMO-3", Created: time.Date(2025, 3, 5, 11, 0, 0, 0, time.UTC)},  // Wednesday
+		{Key: "DEMO-4", Created: time.Date(2025, 3, 9, 22, 0, 0, 0, newYork)},   // Sunday
+		{Key: "DEMO-5", Created: since},                                         // Saturday, on the boundary
+		{Key: "DEMO-6", Created: time.Date(2025, 2, 24, 9, 0, 0, 0, time.UTC)},  // Monday, before the window
+	}
+
+	got := CountCreatedByWeekday(bugs, since)
+
+	want := []domain.WeekdayCount{
+		{Weekday: time.Monday, Created: 2},
+		{Weekday: time.Tuesday},
+		{Weekday: time.Wednesday, Created: 1},
+		{Weekday: time.Thursday},
+		{Weekday: time.Friday},
+		{Weekday: time.Saturday, Created: 1},
+		{Weekday: time.Sunday, Created: 1},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("CountCreatedByWeekday = %v, want %v", got, want)
+	}
+}